@@ -0,0 +1,38 @@
+terraform {
+  required_providers {
+    tama = {
+      source = "upmaru/tama"
+    }
+  }
+}
+
+variable "space_id" {
+  description = "ID of the space the specification is created in"
+  type        = string
+}
+
+# Validate an OpenAPI schema before creating a specification from it
+data "tama_specification_validate" "elasticsearch" {
+  endpoint = "https://elasticsearch.example.com"
+  schema   = file("${path.module}/elasticsearch-openapi.json")
+}
+
+# Only create the specification once the schema passes validation
+resource "tama_specification" "elasticsearch" {
+  space_id = var.space_id
+  version  = "1.0.0"
+  endpoint = data.tama_specification_validate.elasticsearch.endpoint
+  schema   = data.tama_specification_validate.elasticsearch.schema
+
+  lifecycle {
+    precondition {
+      condition     = data.tama_specification_validate.elasticsearch.valid
+      error_message = "The schema is invalid: ${join("; ", data.tama_specification_validate.elasticsearch.errors)}"
+    }
+  }
+}
+
+output "specification_errors" {
+  description = "Problems found in the schema, empty when it is valid"
+  value       = data.tama_specification_validate.elasticsearch.errors
+}
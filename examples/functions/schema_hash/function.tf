@@ -0,0 +1,16 @@
+locals {
+  article_schema = jsonencode({
+    title       = "article"
+    description = "A published article"
+    type        = "object"
+    properties = {
+      title = { type = "string" }
+      body  = { type = "string" }
+    }
+  })
+}
+
+resource "terraform_data" "article_schema" {
+  # Changes only when the schema changes, not when it is reformatted
+  input = provider::tama::schema_hash(local.article_schema)
+}
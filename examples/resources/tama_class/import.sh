@@ -0,0 +1,6 @@
+# Import a class by ID. The schema is imported into the schema block when
+# the block can hold it, and into schema_json otherwise.
+terraform import tama_class.example <class_id>
+
+# Import a class generated from a specification.
+terraform import tama_class.example <specification_id>/<name>
@@ -58,6 +58,17 @@ resource "tama_model" "gpt_3_5_turbo" {
   path       = "/chat/completions"
 }
 
+# Keep the project key out of state with write-only parameters
+resource "tama_model" "gpt_4o" {
+  source_id  = tama_source.openai.id
+  identifier = "gpt-4o"
+  path       = "/chat/completions"
+  parameters = jsonencode({ temperature = 0.7 })
+
+  parameters_wo         = jsonencode({ project = var.openai_project_key })
+  parameters_wo_version = 1
+}
+
 # Variables for API keys
 variable "mistral_api_key" {
   description = "API key for Mistral AI"
@@ -71,6 +82,12 @@ variable "openai_api_key" {
   sensitive   = true
 }
 
+variable "openai_project_key" {
+  description = "Project key for OpenAI"
+  type        = string
+  sensitive   = true
+}
+
 # Output the model IDs
 output "mistral_small_model_id" {
   description = "ID of the Mistral Small model"
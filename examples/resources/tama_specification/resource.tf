@@ -425,16 +425,16 @@ resource "tama_specification" "nested_wait" {
   version  = "3.0.0"
   endpoint = "https://api.nested.com"
 
-  # Wait for nested field using JSON path notation
+  # Wait for a nested field using JSON path notation
   wait_for {
     field {
-      name = "metadata.deployment.status"
+      name = "current_state"
       in   = ["completed"]
     }
 
     field {
-      name = "config.health.status"
-      in   = ["active", "healthy"]
+      name = "schema.info.version"
+      in   = ["3.0.0"]
     }
   }
 }
@@ -9,7 +9,9 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
-	"github.com/upmaru/terraform-provider-tama/tama"
+	tama "github.com/upmaru/tama-go"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	provider "github.com/upmaru/terraform-provider-tama/tama"
 )
 
 // TestAccProtoV6ProviderFactories is used to instantiate a provider during
@@ -17,7 +19,7 @@ import (
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var TestAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"tama": providerserver.NewProtocol6WithError(tama.New("test")()),
+	"tama": providerserver.NewProtocol6WithError(provider.New("test")()),
 }
 
 // ProviderConfig is a shared configuration to combine with the actual
@@ -39,3 +41,22 @@ func TestAccPreCheck(t *testing.T) {
 		t.Fatal("TAMA_CLIENT_SECRET must be set for acceptance tests")
 	}
 }
+
+// Client returns an API client configured from the acceptance test
+// environment, for changing resources outside of Terraform between steps.
+func Client(t *testing.T) *tama.Client {
+	t.Helper()
+
+	apiClient, err := client.New(client.Config{
+		Config: tama.Config{
+			BaseURL:      os.Getenv("TAMA_BASE_URL"),
+			ClientID:     os.Getenv("TAMA_CLIENT_ID"),
+			ClientSecret: os.Getenv("TAMA_CLIENT_SECRET"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to create Tama client: %s", err)
+	}
+
+	return apiClient
+}
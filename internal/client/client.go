@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	tama "github.com/upmaru/tama-go"
+	"golang.org/x/time/rate"
+)
+
+// Connection pooling defaults, sized so the connections opened by a large
+// apply running many operations in parallel are reused rather than closed.
+const (
+	DefaultMaxIdleConns    = 100
+	DefaultIdleConnTimeout = 90 * time.Second
+)
+
+// Config describes how the Tama API client is built.
+type Config struct {
+	tama.Config
+
+	// APIVersion pins the engine API version through the Accept header.
+	// When empty the tama-go default Accept header is kept and the engine
+	// picks the version.
+	APIVersion string
+
+	// RequestsPerSecond throttles outbound API requests with a token bucket
+	// shared by every resource and data source. Zero disables throttling.
+	RequestsPerSecond float64
+
+	// InsecureSkipVerify disables verification of the server certificate.
+	// Only intended for test engines using self-signed certificates.
+	InsecureSkipVerify bool
+
+	// TLSMinVersion is the minimum TLS version accepted, as one of the
+	// crypto/tls version constants. Zero keeps the Go default.
+	TLSMinVersion uint16
+
+	// MaxIdleConns is the maximum number of idle connections kept open to
+	// the API for reuse. Zero uses DefaultMaxIdleConns.
+	MaxIdleConns int
+
+	// MaxConnsPerHost limits the connections open to the API, including
+	// those in use. Zero means no limit.
+	MaxConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection is kept open. Zero uses
+	// DefaultIdleConnTimeout.
+	IdleConnTimeout time.Duration
+
+	// Responses, when set, records the body of every API response for
+	// debugging. Nil records nothing.
+	Responses *Responses
+}
+
+// tlsVersions maps the accepted tls_min_version values to their constants.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TLSVersions returns the accepted TLS version names in ascending order.
+func TLSVersions() []string {
+	names := make([]string, 0, len(tlsVersions))
+	for name := range tlsVersions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// TLSVersion returns the crypto/tls constant for a TLS version name such as "1.2".
+func TLSVersion(name string) (uint16, error) {
+	version, ok := tlsVersions[name]
+	if !ok {
+		return 0, fmt.Errorf("unsupported TLS version %q", name)
+	}
+
+	return version, nil
+}
+
+// New creates a Tama API client and applies the provider level settings
+// that are not part of the tama-go configuration.
+func New(config Config) (*tama.Client, error) {
+	transport := newTransport(config)
+
+	// tama-go requests OAuth2 tokens through its own transport, which cannot
+	// be configured. Disable its token flow so tokens are requested through
+	// the shared transport, which honours the TLS and pooling settings.
+	tamaConfig := config.Config
+	var tokens *tokenSource
+	if tamaConfig.APIKey == "" && !tamaConfig.SkipTokenFetch {
+		timeout := tamaConfig.Timeout
+		if timeout == 0 {
+			timeout = tama.DefaultTimeout
+		}
+
+		tokens = newTokenSource(config, transport, timeout)
+		tamaConfig.SkipTokenFetch = true
+	}
+
+	client, err := tama.NewClient(tamaConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := client.GetHTTPClient()
+	httpClient.SetTransport(transport)
+
+	if tokens != nil {
+		if _, err := tokens.AccessToken(); err != nil {
+			return nil, fmt.Errorf("failed to obtain initial token: %w", err)
+		}
+
+		httpClient.OnBeforeRequest(func(_ *resty.Client, request *resty.Request) error {
+			token, err := tokens.AccessToken()
+			if err != nil {
+				return fmt.Errorf("failed to refresh token: %w", err)
+			}
+
+			request.SetAuthToken(token)
+			return nil
+		})
+	}
+
+	if config.APIVersion != "" {
+		client.SetHeader("Accept", AcceptHeader(config.APIVersion))
+	}
+
+	if config.RequestsPerSecond > 0 {
+		limiter := rate.NewLimiter(rate.Limit(config.RequestsPerSecond), 1)
+
+		httpClient.OnBeforeRequest(func(_ *resty.Client, request *resty.Request) error {
+			return limiter.Wait(request.Context())
+		})
+	}
+
+	if config.Responses != nil {
+		httpClient.OnAfterResponse(func(_ *resty.Client, response *resty.Response) error {
+			config.Responses.Record(response.Body())
+			return nil
+		})
+	}
+
+	return client, nil
+}
+
+// newTransport returns the transport shared by API and token requests, with
+// the TLS and connection pooling settings of config.
+func newTransport(config Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: config.InsecureSkipVerify,
+		MinVersion:         config.TLSMinVersion,
+	}
+
+	maxIdleConns := config.MaxIdleConns
+	if maxIdleConns == 0 {
+		maxIdleConns = DefaultMaxIdleConns
+	}
+
+	idleConnTimeout := config.IdleConnTimeout
+	if idleConnTimeout == 0 {
+		idleConnTimeout = DefaultIdleConnTimeout
+	}
+
+	// Every request goes to the API host, so all idle connections may be
+	// kept for it instead of the two per host Go keeps by default
+	transport.MaxIdleConns = maxIdleConns
+	transport.MaxIdleConnsPerHost = maxIdleConns
+	transport.MaxConnsPerHost = config.MaxConnsPerHost
+	transport.IdleConnTimeout = idleConnTimeout
+
+	return transport
+}
+
+// AcceptHeader returns the versioned media type sent in the Accept header.
+func AcceptHeader(apiVersion string) string {
+	return fmt.Sprintf("application/vnd.tama.%s+json", apiVersion)
+}
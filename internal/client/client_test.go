@@ -0,0 +1,302 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	tama "github.com/upmaru/tama-go"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+)
+
+// testRequest is an API request recorded by the test server.
+type testRequest struct {
+	accept        string
+	authorization string
+	at            time.Time
+}
+
+// newTestServer returns a server issuing OAuth2 tokens and serving a space,
+// recording the Accept and Authorization headers and arrival time of every
+// API request.
+func newTestServer(t *testing.T) (*httptest.Server, func() []testRequest) {
+	t.Helper()
+
+	return startTestServer(t, httptest.NewServer)
+}
+
+// newTLSTestServer is like newTestServer, but serves HTTPS with a self-signed
+// certificate and accepts TLS versions up to maxVersion.
+func newTLSTestServer(t *testing.T, maxVersion uint16) (*httptest.Server, func() []testRequest) {
+	t.Helper()
+
+	return startTestServer(t, func(handler http.Handler) *httptest.Server {
+		server := httptest.NewUnstartedServer(handler)
+		server.TLS = &tls.Config{MaxVersion: maxVersion}
+		server.StartTLS()
+		return server
+	})
+}
+
+func startTestServer(t *testing.T, start func(http.Handler) *httptest.Server) (*httptest.Server, func() []testRequest) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var requests []testRequest
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "test-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	})
+	mux.HandleFunc("/provision/neural/spaces/space-1", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, testRequest{
+			accept:        r.Header.Get("Accept"),
+			authorization: r.Header.Get("Authorization"),
+			at:            time.Now(),
+		})
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"id": "space-1", "name": "test", "type": "root", "engine_only": true},
+		})
+	})
+
+	server := start(mux)
+	t.Cleanup(server.Close)
+
+	return server, func() []testRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]testRequest(nil), requests...)
+	}
+}
+
+func TestNew_AcceptHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		apiVersion string
+		expected   string
+	}{
+		{"no version", "", "application/json"},
+		{"pinned version", "v2", "application/vnd.tama.v2+json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, requests := newTestServer(t)
+
+			tamaClient, err := client.New(client.Config{
+				Config: tama.Config{
+					BaseURL:      server.URL,
+					ClientID:     "client-id",
+					ClientSecret: "client-secret",
+				},
+				APIVersion: tt.apiVersion,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %s", err)
+			}
+
+			if _, err := tamaClient.Neural.GetSpace("space-1"); err != nil {
+				t.Fatalf("unexpected error reading space: %s", err)
+			}
+
+			got := requests()
+			if len(got) != 1 {
+				t.Fatalf("expected 1 request, got %d", len(got))
+			}
+
+			if got[0].accept != tt.expected {
+				t.Errorf("expected Accept header %q, got %q", tt.expected, got[0].accept)
+			}
+
+			if got[0].authorization != "Bearer test-token" {
+				t.Errorf("expected the issued token to be sent, got Authorization header %q", got[0].authorization)
+			}
+		})
+	}
+}
+
+func TestNew_Responses(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newTestServer(t)
+	responses := client.NewResponses()
+
+	tamaClient, err := client.New(client.Config{
+		Config: tama.Config{
+			BaseURL:      server.URL,
+			ClientID:     "client-id",
+			ClientSecret: "client-secret",
+		},
+		Responses: responses,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	if _, err := tamaClient.Neural.GetSpace("space-1"); err != nil {
+		t.Fatalf("unexpected error reading space: %s", err)
+	}
+
+	body, ok := responses.Take("space-1")
+	if !ok {
+		t.Fatal("expected the space response to be recorded")
+	}
+
+	var decoded map[string]map[string]any
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("recorded body is not JSON: %s", err)
+	}
+
+	if decoded["data"]["engine_only"] != true {
+		t.Errorf("expected fields the client does not model to be recorded, got %s", body)
+	}
+
+	if _, ok := responses.Take("space-1"); ok {
+		t.Error("expected the recorded body to be taken once")
+	}
+}
+
+func TestNew_RequestsPerSecond(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		requestsPerSecond float64
+		minSpacing        time.Duration
+	}{
+		{"unlimited", 0, 0},
+		{"throttled", 20, 50 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, requests := newTestServer(t)
+
+			tamaClient, err := client.New(client.Config{
+				Config: tama.Config{
+					BaseURL:      server.URL,
+					ClientID:     "client-id",
+					ClientSecret: "client-secret",
+				},
+				RequestsPerSecond: tt.requestsPerSecond,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %s", err)
+			}
+
+			const count = 5
+			for range count {
+				if _, err := tamaClient.Neural.GetSpace("space-1"); err != nil {
+					t.Fatalf("unexpected error reading space: %s", err)
+				}
+			}
+
+			got := requests()
+			if len(got) != count {
+				t.Fatalf("expected %d requests, got %d", count, len(got))
+			}
+
+			// Allow for scheduling jitter between the limiter and the server clock
+			const tolerance = 5 * time.Millisecond
+			for i := 1; i < len(got); i++ {
+				if spacing := got[i].at.Sub(got[i-1].at); spacing < tt.minSpacing-tolerance {
+					t.Errorf("request %d arrived %s after the previous one, expected at least %s", i, spacing, tt.minSpacing)
+				}
+			}
+		})
+	}
+}
+
+func TestNew_TLS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		serverMaxVersion   uint16
+		insecureSkipVerify bool
+		tlsMinVersion      uint16
+		expectError        bool
+	}{
+		{"self-signed certificate rejected", tls.VersionTLS13, false, 0, true},
+		{"self-signed certificate with skip verify", tls.VersionTLS13, true, 0, false},
+		{"minimum version satisfied", tls.VersionTLS13, true, tls.VersionTLS13, false},
+		{"minimum version not satisfied", tls.VersionTLS12, true, tls.VersionTLS13, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, requests := newTLSTestServer(t, tt.serverMaxVersion)
+
+			tamaClient, err := client.New(client.Config{
+				Config: tama.Config{
+					BaseURL:      server.URL,
+					ClientID:     "client-id",
+					ClientSecret: "client-secret",
+				},
+				InsecureSkipVerify: tt.insecureSkipVerify,
+				TLSMinVersion:      tt.tlsMinVersion,
+			})
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected the TLS handshake to fail")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %s", err)
+			}
+
+			if _, err := tamaClient.Neural.GetSpace("space-1"); err != nil {
+				t.Fatalf("unexpected error reading space: %s", err)
+			}
+
+			if got := requests(); len(got) != 1 {
+				t.Fatalf("expected 1 request, got %d", len(got))
+			}
+		})
+	}
+}
+
+func TestTLSVersion(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range client.TLSVersions() {
+		if _, err := client.TLSVersion(name); err != nil {
+			t.Errorf("expected %q to be supported, got %s", name, err)
+		}
+	}
+
+	if version, _ := client.TLSVersion("1.3"); version != tls.VersionTLS13 {
+		t.Errorf("expected 1.3 to map to TLS 1.3, got %x", version)
+	}
+
+	if _, err := client.TLSVersion("2.0"); err == nil {
+		t.Error("expected an error for an unsupported version")
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tama "github.com/upmaru/tama-go"
+	"github.com/upmaru/tama-go/contexts"
+	"github.com/upmaru/tama-go/memory"
+	"github.com/upmaru/tama-go/motor"
+	"github.com/upmaru/tama-go/neural"
+	"github.com/upmaru/tama-go/perception"
+	"github.com/upmaru/tama-go/sensory"
+	"github.com/upmaru/tama-go/system"
+	"github.com/upmaru/tama-go/tools"
+)
+
+// Category classifies a failed API call. Its value is used as the summary of
+// the diagnostic reporting the failure.
+type Category string
+
+const (
+	// CategoryValidation is a request the API rejected as invalid (400, 422).
+	CategoryValidation Category = "Validation Error"
+
+	// CategoryAuth is a request with missing or insufficient credentials
+	// (401, 403).
+	CategoryAuth Category = "Authentication Error"
+
+	// CategoryNotFound is a request for a resource which does not exist (404).
+	CategoryNotFound Category = "Not Found Error"
+
+	// CategoryConflict is a request conflicting with the current state of a
+	// resource (409).
+	CategoryConflict Category = "Conflict Error"
+
+	// CategoryRateLimit is a request rejected by rate limiting (429).
+	CategoryRateLimit Category = "Rate Limit Error"
+
+	// CategoryServer is a failure on the server side (5xx).
+	CategoryServer Category = "Server Error"
+
+	// CategoryClient is any other failure, such as another 4xx status, a
+	// transport error or a request rejected before it was sent.
+	CategoryClient Category = "Client Error"
+)
+
+// StatusCode returns the HTTP status code of an API error returned by any
+// tama-go service, or zero when err does not carry one.
+func StatusCode(err error) int {
+	var (
+		clientErr     *tama.Error
+		contextsErr   *contexts.Error
+		memoryErr     *memory.Error
+		motorErr      *motor.Error
+		neuralErr     *neural.Error
+		perceptionErr *perception.Error
+		sensoryErr    *sensory.Error
+		systemErr     *system.Error
+		toolsErr      *tools.Error
+	)
+
+	switch {
+	case errors.As(err, &clientErr):
+		return clientErr.StatusCode
+	case errors.As(err, &contextsErr):
+		return contextsErr.StatusCode
+	case errors.As(err, &memoryErr):
+		return memoryErr.StatusCode
+	case errors.As(err, &motorErr):
+		return motorErr.StatusCode
+	case errors.As(err, &neuralErr):
+		return neuralErr.StatusCode
+	case errors.As(err, &perceptionErr):
+		return perceptionErr.StatusCode
+	case errors.As(err, &sensoryErr):
+		return sensoryErr.StatusCode
+	case errors.As(err, &systemErr):
+		return systemErr.StatusCode
+	case errors.As(err, &toolsErr):
+		return toolsErr.StatusCode
+	default:
+		return 0
+	}
+}
+
+// IsNotFound reports whether err is an API error with status 404.
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// Classify returns the category of a failed API call from its status code.
+func Classify(err error) Category {
+	status := StatusCode(err)
+
+	switch {
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return CategoryValidation
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return CategoryAuth
+	case status == http.StatusNotFound:
+		return CategoryNotFound
+	case status == http.StatusConflict:
+		return CategoryConflict
+	case status == http.StatusTooManyRequests:
+		return CategoryRateLimit
+	case status >= http.StatusInternalServerError:
+		return CategoryServer
+	default:
+		return CategoryClient
+	}
+}
+
+// ErrorDiagnostic returns the error diagnostic reporting a failed API call,
+// summarized by the category of err.
+func ErrorDiagnostic(err error, detail string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(string(Classify(err)), detail)
+}
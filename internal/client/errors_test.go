@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	tama "github.com/upmaru/tama-go"
+	"github.com/upmaru/tama-go/neural"
+	"github.com/upmaru/tama-go/perception"
+	"github.com/upmaru/tama-go/sensory"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+)
+
+func TestClassify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected client.Category
+	}{
+		{"bad request", &neural.Error{StatusCode: 400}, client.CategoryValidation},
+		{"unprocessable entity", &sensory.Error{StatusCode: 422, Errors: map[string][]string{"name": {"can't be blank"}}}, client.CategoryValidation},
+		{"unauthorized", &perception.Error{StatusCode: 401}, client.CategoryAuth},
+		{"forbidden", &tama.Error{StatusCode: 403}, client.CategoryAuth},
+		{"not found", &neural.Error{StatusCode: 404}, client.CategoryNotFound},
+		{"conflict", &neural.Error{StatusCode: 409}, client.CategoryConflict},
+		{"rate limited", &neural.Error{StatusCode: 429}, client.CategoryRateLimit},
+		{"other client status", &neural.Error{StatusCode: 418}, client.CategoryClient},
+		{"internal server error", &neural.Error{StatusCode: 500}, client.CategoryServer},
+		{"bad gateway", &sensory.Error{StatusCode: 502}, client.CategoryServer},
+		{"wrapped", fmt.Errorf("failed to create space: %w", &neural.Error{StatusCode: 422}), client.CategoryValidation},
+		{"transport error", errors.New("failed to get space: connection refused"), client.CategoryClient},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if category := client.Classify(tt.err); category != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, category)
+			}
+		})
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	t.Parallel()
+
+	if !client.IsNotFound(fmt.Errorf("failed to get processor: %w", &neural.Error{StatusCode: 404})) {
+		t.Error("expected a wrapped 404 to be not found")
+	}
+	if client.IsNotFound(&neural.Error{StatusCode: 500}) {
+		t.Error("expected a 500 not to be not found")
+	}
+	if client.IsNotFound(errors.New("connection refused")) {
+		t.Error("expected a transport error not to be not found")
+	}
+}
+
+func TestErrorDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected string
+	}{
+		{"validation", http.StatusUnprocessableEntity, `{"errors":{"name":["can't be blank"]}}`, "Validation Error"},
+		{"server", http.StatusInternalServerError, `{"errors":{"detail":"Internal Server Error"}}`, "Server Error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			t.Cleanup(server.Close)
+
+			tamaClient, err := client.New(client.Config{
+				Config: tama.Config{
+					BaseURL: server.URL,
+					APIKey:  "api-key",
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %s", err)
+			}
+
+			_, err = tamaClient.Neural.GetSpace("space-1")
+			if err == nil {
+				t.Fatal("expected the request to fail")
+			}
+
+			detail := fmt.Sprintf("Unable to read space, got error: %s", err)
+			diagnostic := client.ErrorDiagnostic(err, detail)
+
+			if diagnostic.Summary() != tt.expected {
+				t.Errorf("expected summary %q, got %q", tt.expected, diagnostic.Summary())
+			}
+			if diagnostic.Detail() != detail {
+				t.Errorf("expected detail %q, got %q", detail, diagnostic.Detail())
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	tama "github.com/upmaru/tama-go"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+)
+
+// poolReads is the number of reads made against the pooling test server,
+// spread over poolWorkers concurrent workers like a Terraform apply running
+// operations in parallel.
+const (
+	poolReads   = 100
+	poolWorkers = 10
+)
+
+// newPoolTestServer returns a server serving a space and counting the
+// connections opened to it.
+func newPoolTestServer(tb testing.TB) (*httptest.Server, *atomic.Int64) {
+	tb.Helper()
+
+	var connections atomic.Int64
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"id": "space-1", "name": "test", "type": "root"},
+		})
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	tb.Cleanup(server.Close)
+
+	return server, &connections
+}
+
+// readSpaces reads a space poolReads times from poolWorkers workers.
+func readSpaces(tb testing.TB, tamaClient *tama.Client) {
+	tb.Helper()
+
+	reads := make(chan struct{}, poolReads)
+	for range poolReads {
+		reads <- struct{}{}
+	}
+	close(reads)
+
+	var wg sync.WaitGroup
+	for range poolWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range reads {
+				if _, err := tamaClient.Neural.GetSpace("space-1"); err != nil {
+					tb.Errorf("unexpected error reading space: %s", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func newPoolTestClient(tb testing.TB, serverURL string, maxIdleConns int) *tama.Client {
+	tb.Helper()
+
+	tamaClient, err := client.New(client.Config{
+		Config: tama.Config{
+			BaseURL: serverURL,
+			APIKey:  "api-key",
+		},
+		MaxIdleConns: maxIdleConns,
+	})
+	if err != nil {
+		tb.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	return tamaClient
+}
+
+func TestNew_ConnectionPooling(t *testing.T) {
+	t.Parallel()
+
+	server, connections := newPoolTestServer(t)
+	readSpaces(t, newPoolTestClient(t, server.URL, 0))
+
+	// Each worker needs at most one connection, which is then reused
+	if got := connections.Load(); got > poolWorkers {
+		t.Errorf("expected at most %d connections for %d reads, got %d", poolWorkers, poolReads, got)
+	}
+}
+
+func TestNew_MaxConnsPerHost(t *testing.T) {
+	t.Parallel()
+
+	server, connections := newPoolTestServer(t)
+
+	tamaClient, err := client.New(client.Config{
+		Config: tama.Config{
+			BaseURL: server.URL,
+			APIKey:  "api-key",
+		},
+		MaxConnsPerHost: 2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %s", err)
+	}
+
+	readSpaces(t, tamaClient)
+
+	if got := connections.Load(); got > 2 {
+		t.Errorf("expected at most 2 connections, got %d", got)
+	}
+}
+
+// BenchmarkConnectionPooling compares poolReads concurrent reads with the
+// default pool against a pool of two idle connections, the number Go keeps
+// per host when the transport is not tuned.
+func BenchmarkConnectionPooling(b *testing.B) {
+	benchmarks := []struct {
+		name         string
+		maxIdleConns int
+	}{
+		{"tuned", 0},
+		{"untuned", 2},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			server, connections := newPoolTestServer(b)
+			tamaClient := newPoolTestClient(b, server.URL, bm.maxIdleConns)
+
+			for b.Loop() {
+				readSpaces(b, tamaClient)
+			}
+
+			b.ReportMetric(float64(connections.Load())/float64(b.N), "conns/op")
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// Responses keeps the body of the last API response returned for each
+// object, keyed by the ID in its data envelope, so resources can expose the
+// response exactly as the engine sent it. Fields tama-go does not model are
+// kept. It is safe for concurrent use.
+type Responses struct {
+	mu     sync.Mutex
+	bodies map[string][]byte
+}
+
+// NewResponses returns an empty response store.
+func NewResponses() *Responses {
+	return &Responses{bodies: map[string][]byte{}}
+}
+
+// Record stores body when it is a JSON object whose data member has an ID.
+// List responses and errors are ignored.
+func (r *Responses) Record(body []byte) {
+	var envelope struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Data.ID == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.bodies[envelope.Data.ID] = append([]byte(nil), body...)
+}
+
+// Take returns the last response body recorded for the object with the
+// given ID and forgets it, so bodies are only held until a resource reads
+// them.
+func (r *Responses) Take(id string) ([]byte, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	body, ok := r.bodies[id]
+	delete(r.bodies, id)
+
+	return body, ok
+}
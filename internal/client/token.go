@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	tama "github.com/upmaru/tama-go"
+)
+
+// defaultScope is requested when no scopes are configured.
+const defaultScope = "provision.all"
+
+// tokenSource obtains and caches OAuth2 client credentials tokens. It mirrors
+// the token flow of tama-go, but sends token requests through a transport
+// that honours the provider TLS and connection pooling settings.
+type tokenSource struct {
+	http         *resty.Client
+	clientID     string
+	clientSecret string
+	scope        string
+
+	mu    sync.Mutex
+	token *tama.Token
+}
+
+func newTokenSource(config Config, transport *http.Transport, timeout time.Duration) *tokenSource {
+	scope := defaultScope
+	if len(config.Scopes) > 0 {
+		scope = strings.Join(config.Scopes, " ")
+	}
+
+	return &tokenSource{
+		http: resty.New().
+			SetBaseURL(config.BaseURL).
+			SetTimeout(timeout).
+			SetTransport(transport).
+			SetHeader("Content-Type", "application/json").
+			SetHeader("Accept", "application/json"),
+		clientID:     config.ClientID,
+		clientSecret: config.ClientSecret,
+		scope:        scope,
+	}
+}
+
+// AccessToken returns a valid access token, requesting a new one when the
+// cached token is missing or about to expire.
+func (s *tokenSource) AccessToken() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && !s.token.IsExpired() {
+		return s.token.AccessToken, nil
+	}
+
+	credentials := base64.StdEncoding.EncodeToString([]byte(s.clientID + ":" + s.clientSecret))
+
+	var tokenResponse tama.TokenResponse
+	resp, err := s.http.R().
+		SetHeader("Authorization", "Bearer "+credentials).
+		SetBody(map[string]string{
+			"grant_type": "client_credentials",
+			"scope":      s.scope,
+		}).
+		SetResult(&tokenResponse).
+		Post("/auth/tokens")
+
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %w", err)
+	}
+
+	if resp.IsError() {
+		return "", fmt.Errorf("token request failed with status %d: %s", resp.StatusCode(), resp.String())
+	}
+
+	s.token = &tama.Token{
+		AccessToken: tokenResponse.AccessToken,
+		TokenType:   tokenResponse.TokenType,
+		Scope:       tokenResponse.Scope,
+		ExpiresAt:   time.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second),
+	}
+
+	return s.token.AccessToken, nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package argvalidate reports consistent diagnostics for data sources which
+// can be looked up in several mutually exclusive ways.
+package argvalidate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Mode is one way of looking up a data source, such as by id alone or by
+// space_id and name together.
+type Mode struct {
+	// Arguments names the attributes used together by this mode.
+	Arguments []string
+
+	// Set reports whether every argument of the mode is configured.
+	Set bool
+}
+
+// NewMode returns a lookup mode over the given arguments, which is set when
+// every value is known and not empty.
+func NewMode(arguments []string, values ...types.String) Mode {
+	return Mode{Arguments: arguments, Set: IsSet(values...)}
+}
+
+// IsSet reports whether every value is known and not empty.
+func IsSet(values ...types.String) bool {
+	for _, value := range values {
+		if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Select returns the index of the single configured lookup mode. When no
+// mode or more than one mode is configured, an error diagnostic is returned
+// instead.
+func Select(modes ...Mode) (int, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	selected := -1
+	count := 0
+	for i, mode := range modes {
+		if mode.Set {
+			selected = i
+			count++
+		}
+	}
+
+	switch {
+	case count == 0:
+		diags.AddError(
+			"Missing Required Arguments",
+			fmt.Sprintf("You must provide one of the following: %s.", describe(modes)),
+		)
+	case count > 1:
+		diags.AddError(
+			"Conflicting Arguments",
+			fmt.Sprintf("You can only use one approach at a time: %s.", describe(modes)),
+		)
+	}
+
+	return selected, diags
+}
+
+// describe lists the lookup modes, e.g. "'id' alone, or 'space_id' + 'name'".
+func describe(modes []Mode) string {
+	descriptions := make([]string, len(modes))
+	for i, mode := range modes {
+		quoted := make([]string, len(mode.Arguments))
+		for j, argument := range mode.Arguments {
+			quoted[j] = "'" + argument + "'"
+		}
+
+		descriptions[i] = strings.Join(quoted, " + ")
+		if len(quoted) == 1 {
+			descriptions[i] += " alone"
+		}
+	}
+
+	if len(descriptions) < 2 {
+		return strings.Join(descriptions, "")
+	}
+
+	last := len(descriptions) - 1
+	return strings.Join(descriptions[:last], ", ") + ", or " + descriptions[last]
+}
@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package argvalidate_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/upmaru/terraform-provider-tama/internal/datasource/argvalidate"
+)
+
+func TestIsSet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		values   []types.String
+		expected bool
+	}{
+		{"no values", nil, true},
+		{"all set", []types.String{types.StringValue("a"), types.StringValue("b")}, true},
+		{"null", []types.String{types.StringValue("a"), types.StringNull()}, false},
+		{"unknown", []types.String{types.StringUnknown()}, false},
+		{"empty", []types.String{types.StringValue("")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := argvalidate.IsSet(tt.values...); got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	t.Parallel()
+
+	const description = "'id' alone, 'specification_id' + 'name', or 'space_id' + 'name'"
+
+	tests := []struct {
+		name            string
+		id              types.String
+		specificationID types.String
+		spaceID         types.String
+		lookupName      types.String
+		expectedMode    int
+		expectedSummary string
+		expectedDetail  string
+	}{
+		{
+			name:            "nothing set",
+			expectedMode:    -1,
+			expectedSummary: "Missing Required Arguments",
+			expectedDetail:  "You must provide one of the following: " + description + ".",
+		},
+		{
+			name:            "name alone",
+			lookupName:      types.StringValue("class"),
+			expectedMode:    -1,
+			expectedSummary: "Missing Required Arguments",
+			expectedDetail:  "You must provide one of the following: " + description + ".",
+		},
+		{
+			name:            "space_id without name",
+			spaceID:         types.StringValue("space-1"),
+			expectedMode:    -1,
+			expectedSummary: "Missing Required Arguments",
+			expectedDetail:  "You must provide one of the following: " + description + ".",
+		},
+		{
+			name:         "id",
+			id:           types.StringValue("class-1"),
+			expectedMode: 0,
+		},
+		{
+			name:            "specification_id and name",
+			specificationID: types.StringValue("spec-1"),
+			lookupName:      types.StringValue("class"),
+			expectedMode:    1,
+		},
+		{
+			name:         "space_id and name",
+			spaceID:      types.StringValue("space-1"),
+			lookupName:   types.StringValue("class"),
+			expectedMode: 2,
+		},
+		{
+			name:         "id with unrelated space_id",
+			id:           types.StringValue("class-1"),
+			spaceID:      types.StringValue("space-1"),
+			expectedMode: 0,
+		},
+		{
+			name:            "id and space_id with name",
+			id:              types.StringValue("class-1"),
+			spaceID:         types.StringValue("space-1"),
+			lookupName:      types.StringValue("class"),
+			expectedMode:    2,
+			expectedSummary: "Conflicting Arguments",
+			expectedDetail:  "You can only use one approach at a time: " + description + ".",
+		},
+		{
+			name:            "specification_id and space_id with name",
+			specificationID: types.StringValue("spec-1"),
+			spaceID:         types.StringValue("space-1"),
+			lookupName:      types.StringValue("class"),
+			expectedMode:    2,
+			expectedSummary: "Conflicting Arguments",
+			expectedDetail:  "You can only use one approach at a time: " + description + ".",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mode, diags := argvalidate.Select(
+				argvalidate.NewMode([]string{"id"}, tt.id),
+				argvalidate.NewMode([]string{"specification_id", "name"}, tt.specificationID, tt.lookupName),
+				argvalidate.NewMode([]string{"space_id", "name"}, tt.spaceID, tt.lookupName),
+			)
+
+			if tt.expectedSummary == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				if mode != tt.expectedMode {
+					t.Errorf("expected mode %d, got %d", tt.expectedMode, mode)
+				}
+				return
+			}
+
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected 1 error, got %v", diags)
+			}
+
+			if got := diags.Errors()[0].Summary(); got != tt.expectedSummary {
+				t.Errorf("expected summary %q, got %q", tt.expectedSummary, got)
+			}
+
+			if got := diags.Errors()[0].Detail(); got != tt.expectedDetail {
+				t.Errorf("expected detail %q, got %q", tt.expectedDetail, got)
+			}
+		})
+	}
+}
+
+func TestSelect_TwoModes(t *testing.T) {
+	t.Parallel()
+
+	_, diags := argvalidate.Select(
+		argvalidate.NewMode([]string{"id"}, types.StringNull()),
+		argvalidate.NewMode([]string{"path", "method"}, types.StringValue("/"), types.StringNull()),
+	)
+
+	expected := "You must provide one of the following: 'id' alone, or 'path' + 'method'."
+	if !diags.HasError() || diags.Errors()[0].Detail() != expected {
+		t.Errorf("expected detail %q, got %v", expected, diags)
+	}
+}
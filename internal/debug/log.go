@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package debug
+
+import (
+	"encoding/json"
+)
+
+// LogFields returns a copy of fields that is safe to pass to tflog. Values of
+// credential keys are redacted, including credentials nested inside maps and
+// slices such as user supplied parameters.
+func LogFields(fields map[string]any) map[string]any {
+	safe := make(map[string]any, len(fields))
+
+	for key, value := range fields {
+		if isSensitive(key) {
+			safe[key] = RedactedValue
+			continue
+		}
+
+		switch value.(type) {
+		case map[string]any, []any:
+			safe[key] = redactCopy(value)
+		default:
+			safe[key] = value
+		}
+	}
+
+	return safe
+}
+
+// redactCopy redacts a JSON compatible value without modifying the original.
+func redactCopy(value any) any {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return RedactedValue
+	}
+
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return RedactedValue
+	}
+
+	return redact(decoded)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package debug_test
+
+import (
+	"testing"
+
+	"github.com/upmaru/terraform-provider-tama/internal/debug"
+)
+
+func TestLogFields(t *testing.T) {
+	t.Parallel()
+
+	parameters := map[string]any{"max_tokens": 100, "api_key": "sk-123"}
+	fields := debug.LogFields(map[string]any{
+		"id":            "identity-1",
+		"client_secret": "super-secret",
+		"parameters":    parameters,
+	})
+
+	if fields["id"] != "identity-1" {
+		t.Errorf("expected id to be kept, got %v", fields["id"])
+	}
+
+	if fields["client_secret"] != debug.RedactedValue {
+		t.Errorf("expected client_secret to be redacted, got %v", fields["client_secret"])
+	}
+
+	nested, ok := fields["parameters"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected parameters to be a map, got %T", fields["parameters"])
+	}
+
+	if nested["api_key"] != debug.RedactedValue {
+		t.Errorf("expected nested api_key to be redacted, got %v", nested["api_key"])
+	}
+
+	if parameters["api_key"] != "sk-123" {
+		t.Error("expected the original parameters to be left untouched")
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package debug
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+)
+
+// RedactedValue replaces sensitive values in raw responses.
+const RedactedValue = "REDACTED"
+
+// sensitiveKeys lists the object keys whose values are always redacted.
+var sensitiveKeys = map[string]bool{
+	"access_token":  true,
+	"api_key":       true,
+	"apikey":        true,
+	"authorization": true,
+	"client_secret": true,
+	"credential":    true,
+	"credentials":   true,
+	"password":      true,
+	"refresh_token": true,
+	"secret":        true,
+	"token":         true,
+}
+
+// RawResponse returns the body of the last API response recorded for the
+// object with the given ID, with sensitive values redacted. A null string is
+// returned when responses is nil, i.e. debug_expose_raw is disabled, or no
+// response was recorded for the object.
+func RawResponse(responses *client.Responses, id string) types.String {
+	if responses == nil {
+		return types.StringNull()
+	}
+
+	body, ok := responses.Take(id)
+	if !ok {
+		return types.StringNull()
+	}
+
+	// Only JSON bodies are recorded, so redacting cannot fail
+	redacted, err := Redact(json.RawMessage(body))
+	if err != nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(redacted)
+}
+
+// Redact encodes value as JSON, replacing the values of sensitive keys and
+// of name/value pairs with a sensitive name (e.g. an Authorization header).
+func Redact(value any) (string, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return "", err
+	}
+
+	result, err := json.Marshal(redact(decoded))
+	if err != nil {
+		return "", err
+	}
+
+	return string(result), nil
+}
+
+func redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if isSensitive(key) {
+				v[key] = RedactedValue
+				continue
+			}
+			v[key] = redact(item)
+		}
+
+		// Header style pairs keep the name but hide the value
+		if name, ok := v["name"].(string); ok && isSensitive(name) {
+			if _, ok := v["value"]; ok {
+				v["value"] = RedactedValue
+			}
+		}
+
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = redact(item)
+		}
+
+		return v
+	default:
+		return v
+	}
+}
+
+func isSensitive(key string) bool {
+	normalized := strings.ReplaceAll(strings.ToLower(key), "-", "_")
+	return sensitiveKeys[normalized]
+}
+
+// RawResponseAttribute returns the common schema attribute used to expose the
+// last raw API response of a resource.
+func RawResponseAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "Body of the last API response for this object as sent by the engine, including fields the provider does not model, with sensitive values redacted. Only populated when `debug_expose_raw` is enabled on the provider.",
+		Computed:            true,
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package debug_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/debug"
+)
+
+func TestRawResponse_Disabled(t *testing.T) {
+	t.Parallel()
+
+	if raw := debug.RawResponse(nil, "source-1"); !raw.IsNull() {
+		t.Errorf("expected null raw response when disabled, got %q", raw.ValueString())
+	}
+}
+
+func TestRawResponse_EnabledAndRedacted(t *testing.T) {
+	t.Parallel()
+
+	responses := client.NewResponses()
+	responses.Record([]byte(`{"data":{"id":"source-1","name":"openai","engine_only":true,` +
+		`"request":{"headers":[{"name":"Authorization","value":"Bearer secret-token"},{"name":"x-api-version","value":"v1"}]}}}`))
+
+	raw := debug.RawResponse(responses, "source-1")
+	if raw.IsNull() {
+		t.Fatal("expected raw response to be populated when enabled")
+	}
+
+	value := raw.ValueString()
+
+	if !strings.Contains(value, `"id":"source-1"`) {
+		t.Errorf("expected raw response to contain the source id, got %s", value)
+	}
+
+	if !strings.Contains(value, `"engine_only":true`) {
+		t.Errorf("expected fields the client does not model to be kept, got %s", value)
+	}
+
+	if strings.Contains(value, "secret-token") {
+		t.Errorf("expected authorization header to be redacted, got %s", value)
+	}
+
+	if !strings.Contains(value, `"value":"v1"`) {
+		t.Errorf("expected non-sensitive header to be kept, got %s", value)
+	}
+
+	// Bodies are handed out once
+	if raw := debug.RawResponse(responses, "source-1"); !raw.IsNull() {
+		t.Errorf("expected the recorded body to be taken, got %q", raw.ValueString())
+	}
+}
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    any
+		expected string
+	}{
+		{
+			name:     "api key",
+			input:    map[string]any{"api_key": "sk-123", "name": "source"},
+			expected: `{"api_key":"REDACTED","name":"source"}`,
+		},
+		{
+			name:     "nested client secret",
+			input:    map[string]any{"credential": map[string]any{"client_id": "id"}, "config": map[string]any{"client_secret": "s"}},
+			expected: `{"config":{"client_secret":"REDACTED"},"credential":"REDACTED"}`,
+		},
+		{
+			name:     "token counts are not redacted",
+			input:    map[string]any{"max_tokens": 512},
+			expected: `{"max_tokens":512}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := debug.Redact(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package endpoint
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Join returns the URL requests are sent to when path is appended to base,
+// mirroring how the engine combines a source endpoint with a model path.
+func Join(base, path string) string {
+	return base + path
+}
+
+// Lint inspects a joined URL and returns a description of each suspicious
+// construct, such as duplicated path segments ("/v1/v1/") or empty path
+// segments ("//"). Engine variables such as ${TAMA_REGION} are resolved by
+// the server, so they are treated as opaque. An empty result means no problems
+// were found.
+func Lint(joined string) []string {
+	var issues []string
+
+	parsed, err := url.Parse(variablePattern.ReplaceAllString(joined, placeholderHost))
+	if err != nil {
+		return []string{fmt.Sprintf("%q is not a valid URL: %s", joined, err)}
+	}
+
+	if strings.Contains(parsed.Path, "//") {
+		issues = append(issues, fmt.Sprintf("%q contains a double slash in its path", joined))
+	}
+
+	segments := strings.Split(parsed.Path, "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i] != "" && segments[i] == segments[i-1] {
+			issues = append(issues, fmt.Sprintf("%q repeats the path segment %q", joined, segments[i]))
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package endpoint_test
+
+import (
+	"testing"
+
+	"github.com/upmaru/terraform-provider-tama/internal/endpoint"
+)
+
+func TestJoinAndLint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		base     string
+		path     string
+		expected string
+		issues   int
+	}{
+		{"clean join", "https://api.openai.com/v1", "/chat/completions", "https://api.openai.com/v1/chat/completions", 0},
+		{"duplicate version", "https://api.openai.com/v1", "/v1/chat/completions", "https://api.openai.com/v1/v1/chat/completions", 1},
+		{"double slash", "https://api.mistral.ai/v1/", "/chat/completions", "https://api.mistral.ai/v1//chat/completions", 1},
+		{"root endpoint", "https://api.example.com", "/v1/embeddings", "https://api.example.com/v1/embeddings", 0},
+		{"templated host", "https://${TAMA_REGION}.api.example.com/v1", "/chat/completions", "https://${TAMA_REGION}.api.example.com/v1/chat/completions", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			joined := endpoint.Join(tt.base, tt.path)
+			if joined != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, joined)
+			}
+
+			if issues := endpoint.Lint(joined); len(issues) != tt.issues {
+				t.Errorf("expected %d issues, got %d: %v", tt.issues, len(issues), issues)
+			}
+		})
+	}
+}
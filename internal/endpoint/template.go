@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package endpoint
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = templateValidator{}
+
+// variablePattern matches a well formed engine variable such as ${TAMA_REGION}.
+var variablePattern = regexp.MustCompile(`\$\{[A-Za-z_][A-Za-z0-9_]*\}`)
+
+// placeholderHost replaces variables when a templated URL is parsed, so the
+// surrounding URL can still be checked without knowing the resolved value.
+const placeholderHost = "variable"
+
+// ValidateTemplate reports a malformed variable reference in a templated
+// endpoint. Endpoints without variables are always valid.
+func ValidateTemplate(template string) error {
+	remaining := variablePattern.ReplaceAllString(template, placeholderHost)
+
+	if index := strings.Index(remaining, "${"); index >= 0 {
+		return fmt.Errorf("malformed variable reference at %q, variables must be of the form ${NAME} using letters, digits and underscores", remaining[index:])
+	}
+
+	return nil
+}
+
+// templateValidator ensures variable references in an endpoint are well formed.
+type templateValidator struct{}
+
+// Template returns a validator which ensures that every engine variable in a
+// templated endpoint is written as ${NAME}.
+func Template() validator.String {
+	return templateValidator{}
+}
+
+func (v templateValidator) Description(ctx context.Context) string {
+	return "engine variables must be of the form ${NAME}"
+}
+
+func (v templateValidator) MarkdownDescription(ctx context.Context) string {
+	return "engine variables must be of the form `${NAME}`"
+}
+
+func (v templateValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := ValidateTemplate(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Endpoint Template",
+			err.Error(),
+		)
+	}
+}
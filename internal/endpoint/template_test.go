@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package endpoint_test
+
+import (
+	"testing"
+
+	"github.com/upmaru/terraform-provider-tama/internal/endpoint"
+)
+
+func TestTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		valid    bool
+	}{
+		{"plain endpoint", "https://api.example.com/v1", true},
+		{"region host", "https://${TAMA_REGION}.api.example.com/v1", true},
+		{"multiple variables", "https://${TAMA_REGION}.example.com/${TAMA_VERSION}", true},
+		{"unterminated", "https://${TAMA_REGION.example.com", false},
+		{"empty name", "https://${}.example.com", false},
+		{"invalid name", "https://${TAMA-REGION}.example.com", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := endpoint.ValidateTemplate(tt.template)
+			if tt.valid && err != nil {
+				t.Errorf("expected %q to be valid, got %s", tt.template, err)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("expected %q to be invalid", tt.template)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package endpoint
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = urlValidator{}
+
+// ValidateURL reports whether endpoint is an absolute http or https URL with
+// a host. Engine variables such as ${TAMA_REGION} are treated as opaque.
+func ValidateURL(endpoint string) error {
+	parsed, err := url.Parse(variablePattern.ReplaceAllString(endpoint, placeholderHost))
+	if err != nil {
+		return fmt.Errorf("%q is not a valid URL: %s", endpoint, err)
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("%q must use the http or https scheme", endpoint)
+	}
+
+	if parsed.Host == "" {
+		return fmt.Errorf("%q must include a host", endpoint)
+	}
+
+	return nil
+}
+
+// urlValidator ensures an endpoint is an absolute http or https URL.
+type urlValidator struct{}
+
+// URL returns a validator which ensures that an endpoint is an absolute http
+// or https URL with a host, so malformed endpoints fail at plan time.
+func URL() validator.String {
+	return urlValidator{}
+}
+
+func (v urlValidator) Description(ctx context.Context) string {
+	return "value must be an absolute http or https URL"
+}
+
+func (v urlValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v urlValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := ValidateURL(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid URL",
+			err.Error(),
+		)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package endpoint_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/upmaru/terraform-provider-tama/internal/endpoint"
+)
+
+func TestURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value types.String
+		valid bool
+	}{
+		{"null", types.StringNull(), true},
+		{"unknown", types.StringUnknown(), true},
+		{"https", types.StringValue("https://api.example.com"), true},
+		{"http with port and path", types.StringValue("http://localhost:9200/v1"), true},
+		{"templated host", types.StringValue("https://${TAMA_REGION}.api.example.com/v1"), true},
+		{"empty", types.StringValue(""), false},
+		{"no scheme", types.StringValue("invalid-url"), false},
+		{"unsupported scheme", types.StringValue("ftp://files.example.com"), false},
+		{"missing host", types.StringValue("https:///v1"), false},
+		{"malformed", types.StringValue("https://exa mple.com"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("endpoint"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.StringResponse{}
+
+			endpoint.URL().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() == tt.valid {
+				t.Errorf("expected valid=%t, got diagnostics: %v", tt.valid, resp.Diagnostics)
+			}
+		})
+	}
+}
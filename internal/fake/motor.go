@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fake
+
+import (
+	"strings"
+
+	"github.com/upmaru/tama-go/motor"
+)
+
+// Actions is an in-memory implementation of the motor action lookups.
+type Actions struct {
+	Actions []*motor.Action
+
+	// Err, when set, is returned by every lookup.
+	Err error
+}
+
+// NewActions returns an action store holding the given actions.
+func NewActions(actions ...*motor.Action) *Actions {
+	return &Actions{Actions: actions}
+}
+
+func (f *Actions) GetActionByPathAndMethod(specID, path, method string) (*motor.Action, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	for _, action := range f.Actions {
+		if action.SpecificationID == specID && action.Path == path && strings.EqualFold(action.Method, method) {
+			return action, nil
+		}
+	}
+
+	return nil, &motor.Error{StatusCode: 404}
+}
@@ -0,0 +1,252 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package fake provides in-memory implementations of the Tama API used to
+// unit test resource logic without a live backend.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/upmaru/tama-go/neural"
+)
+
+// Classes is an in-memory implementation of the neural class operations.
+type Classes struct {
+	Classes        map[string]*neural.Class
+	CreateRequests []neural.CreateClassRequest
+	UpdateRequests []neural.UpdateClassRequest
+	Deleted        []string
+
+	// Err, when set, is returned from every operation.
+	Err error
+
+	// Defaults are merged into the schema of created and updated classes
+	// to mimic server side defaults.
+	Defaults map[string]any
+
+	// Specifications maps the ID of a class generated from a specification
+	// to the ID of that specification.
+	Specifications map[string]string
+
+	nextID int
+}
+
+// NewClasses returns an empty class store.
+func NewClasses() *Classes {
+	return &Classes{Classes: map[string]*neural.Class{}, Specifications: map[string]string{}}
+}
+
+func (f *Classes) GetClass(id string) (*neural.Class, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	class, ok := f.Classes[id]
+	if !ok {
+		return nil, &neural.Error{StatusCode: 404}
+	}
+
+	return class, nil
+}
+
+func (f *Classes) GetClassBySpecificationAndName(specificationID string, name string) (*neural.Class, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	for id, classSpecificationID := range f.Specifications {
+		if class, ok := f.Classes[id]; ok && classSpecificationID == specificationID && class.Name == name {
+			return class, nil
+		}
+	}
+
+	return nil, &neural.Error{StatusCode: 404}
+}
+
+func (f *Classes) CreateClass(spaceID string, req neural.CreateClassRequest) (*neural.Class, error) {
+	f.CreateRequests = append(f.CreateRequests, req)
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	f.nextID++
+	class := &neural.Class{
+		ID:             fmt.Sprintf("class-%d", f.nextID),
+		SpaceID:        spaceID,
+		ProvisionState: "active",
+		Schema:         f.withDefaults(decodedSchema(req.Class.Schema)),
+	}
+	class.Name, _ = class.Schema["title"].(string)
+	class.Description, _ = class.Schema["description"].(string)
+
+	f.Classes[class.ID] = class
+
+	return class, nil
+}
+
+func (f *Classes) UpdateClass(id string, req neural.UpdateClassRequest) (*neural.Class, error) {
+	f.UpdateRequests = append(f.UpdateRequests, req)
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	class, ok := f.Classes[id]
+	if !ok {
+		return nil, &neural.Error{StatusCode: 404}
+	}
+
+	class.Schema = f.withDefaults(decodedSchema(req.Class.Schema))
+	class.Name, _ = class.Schema["title"].(string)
+	class.Description, _ = class.Schema["description"].(string)
+
+	return class, nil
+}
+
+func (f *Classes) DeleteClass(id string) error {
+	if f.Err != nil {
+		return f.Err
+	}
+
+	delete(f.Classes, id)
+	f.Deleted = append(f.Deleted, id)
+
+	return nil
+}
+
+// decodedSchema returns schema as the API returns it, sent as JSON and
+// decoded again, so values such as json.RawMessage are read back the way
+// the server would store them.
+func decodedSchema(schema map[string]any) map[string]any {
+	encoded, err := json.Marshal(schema)
+	if err != nil {
+		return schema
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return schema
+	}
+
+	return decoded
+}
+
+// withDefaults returns schema with any missing Defaults filled in.
+func (f *Classes) withDefaults(schema map[string]any) map[string]any {
+	if len(f.Defaults) == 0 {
+		return schema
+	}
+
+	merged := map[string]any{}
+	for key, value := range f.Defaults {
+		merged[key] = value
+	}
+	for key, value := range schema {
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// Processors is an in-memory implementation of the neural processor
+// operations, keyed by space ID and processor type.
+type Processors struct {
+	Processors     map[string]*neural.Processor
+	CreateRequests []neural.CreateProcessorRequest
+	UpdateRequests []neural.UpdateProcessorRequest
+	Deleted        []string
+
+	// Err, when set, is returned from every operation.
+	Err error
+
+	// Defaults are merged into the configuration of created processors to
+	// mimic server side defaults.
+	Defaults map[string]any
+
+	nextID int
+}
+
+// NewProcessors returns an empty processor store.
+func NewProcessors() *Processors {
+	return &Processors{Processors: map[string]*neural.Processor{}}
+}
+
+func processorKey(spaceID, processorType string) string {
+	return spaceID + "/" + processorType
+}
+
+func (f *Processors) GetProcessor(spaceID, processorType string) (*neural.Processor, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	processor, ok := f.Processors[processorKey(spaceID, processorType)]
+	if !ok {
+		return nil, &neural.Error{StatusCode: 404}
+	}
+
+	return processor, nil
+}
+
+func (f *Processors) CreateProcessor(spaceID, processorType string, req neural.CreateProcessorRequest) (*neural.Processor, error) {
+	f.CreateRequests = append(f.CreateRequests, req)
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	configuration := map[string]any{}
+	for key, value := range f.Defaults {
+		configuration[key] = value
+	}
+	for key, value := range req.Processor.Configuration {
+		configuration[key] = value
+	}
+
+	f.nextID++
+	processor := &neural.Processor{
+		ID:             fmt.Sprintf("processor-%d", f.nextID),
+		SpaceID:        spaceID,
+		ModelID:        req.Processor.ModelID,
+		Configuration:  configuration,
+		ProvisionState: "active",
+		Type:           processorType,
+	}
+
+	f.Processors[processorKey(spaceID, processorType)] = processor
+
+	return processor, nil
+}
+
+func (f *Processors) UpdateProcessor(spaceID, processorType string, req neural.UpdateProcessorRequest) (*neural.Processor, error) {
+	f.UpdateRequests = append(f.UpdateRequests, req)
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	processor, ok := f.Processors[processorKey(spaceID, processorType)]
+	if !ok {
+		return nil, &neural.Error{StatusCode: 404}
+	}
+
+	if req.Processor.ModelID != "" {
+		processor.ModelID = req.Processor.ModelID
+	}
+	if req.Processor.Configuration != nil {
+		processor.Configuration = req.Processor.Configuration
+	}
+
+	return processor, nil
+}
+
+func (f *Processors) DeleteProcessor(spaceID, processorType string) error {
+	if f.Err != nil {
+		return f.Err
+	}
+
+	key := processorKey(spaceID, processorType)
+	delete(f.Processors, key)
+	f.Deleted = append(f.Deleted, key)
+
+	return nil
+}
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fake
+
+import (
+	"github.com/upmaru/tama-go/sensory"
+)
+
+// Models is an in-memory implementation of the sensory model lookups.
+type Models struct {
+	Models map[string]*sensory.Model
+}
+
+// NewModels returns a model store holding the given models, keyed by ID.
+func NewModels(models ...*sensory.Model) *Models {
+	f := &Models{Models: map[string]*sensory.Model{}}
+
+	for _, model := range models {
+		f.Models[model.ID] = model
+	}
+
+	return f
+}
+
+func (f *Models) GetModel(id string) (*sensory.Model, error) {
+	model, ok := f.Models[id]
+	if !ok {
+		return nil, &sensory.Error{StatusCode: 404}
+	}
+
+	return model, nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package meta
+
+import (
+	tama "github.com/upmaru/tama-go"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+)
+
+// ProviderMeta holds the configured API client along with provider level
+// settings. It is made available to resources and data sources through
+// their Configure methods.
+//
+// A single ProviderMeta is shared by every resource and data source, which
+// Terraform may run concurrently. Fields are set once in the provider's
+// Configure and only read afterwards. Shared state such as limiters or
+// caches must be safe for concurrent use, the rate limiter lives inside the
+// client for that reason.
+//
+// Migration note: resources and data sources used to receive the bare
+// *tama.Client as provider data. Configure must now assert
+// *meta.ProviderMeta and report "Unexpected Resource Configure Type" (or
+// "Unexpected Data Source Configure Type") otherwise, then take the client
+// and any settings it needs from the struct. New cross cutting settings are
+// added here as fields rather than by changing the provider data type.
+type ProviderMeta struct {
+	Client *tama.Client
+
+	// RequireSemver enforces that specification versions are valid
+	// semantic versions.
+	RequireSemver bool
+
+	// Responses records the raw API response bodies exposed through the
+	// raw_response_json attribute. It is nil unless debug_expose_raw is
+	// enabled.
+	Responses *client.Responses
+
+	// DisableJSONNormalization makes resources plan their JSON attributes
+	// exactly as configured, so formatting changes are reported.
+	DisableJSONNormalization bool
+
+	// StrictModelModality turns processor model modality mismatches into
+	// errors instead of warnings.
+	StrictModelModality bool
+
+	// StrictParameterConflicts turns conflicting model and completion
+	// parameters into errors instead of warnings.
+	StrictParameterConflicts bool
+
+	// SchemaSizeWarnBytes is the normalized class schema size above which
+	// a warning is emitted at plan time. Zero disables the warning.
+	SchemaSizeWarnBytes int64
+}
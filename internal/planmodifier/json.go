@@ -7,11 +7,36 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
 	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// DebugNormalizationEnv names the environment variable enabling logging, at
+// debug level, of the values JSONNormalize compares and the decision it
+// takes. Values are logged as is, sensitive ones included. It is read when a
+// plan modifier is built with its schema, as plan modifiers cannot reach the
+// configuration of a provider block.
+const DebugNormalizationEnv = "TAMA_DEBUG_NORMALIZATION"
+
+// debugNormalizationEnabled reports whether DebugNormalizationEnv is set to
+// a true value.
+func debugNormalizationEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(DebugNormalizationEnv))
+	return enabled
+}
+
 // JSONNormalize returns a plan modifier that normalizes JSON strings to prevent
 // formatting differences from causing unnecessary updates.
 //
@@ -19,12 +44,24 @@ import (
 // If the planned value and state value are semantically equivalent JSON, it will
 // suppress the diff and keep the existing state value.
 func JSONNormalize() planmodifier.String {
-	return jsonNormalizePlanModifier{}
+	return jsonNormalizePlanModifier{normalize: NormalizeJSON, debug: debugNormalizationEnabled()}
+}
+
+// JSONSchemaNormalize returns a plan modifier like JSONNormalize for JSON
+// Schema documents, such as class schemas, which also ignores the order of
+// "required" entries. The planned value itself is never rewritten.
+func JSONSchemaNormalize() planmodifier.String {
+	return jsonNormalizePlanModifier{normalize: NormalizeSchemaJSON, debug: debugNormalizationEnabled()}
 }
 
 // jsonNormalizePlanModifier implements a plan modifier that normalizes JSON strings
 // to prevent formatting differences from causing unnecessary updates.
-type jsonNormalizePlanModifier struct{}
+type jsonNormalizePlanModifier struct {
+	normalize func(string) (string, error)
+
+	// debug logs every decision, see DebugNormalizationEnv
+	debug bool
+}
 
 // Description returns a human-readable description of the plan modifier.
 func (m jsonNormalizePlanModifier) Description(_ context.Context) string {
@@ -41,6 +78,7 @@ func (m jsonNormalizePlanModifier) PlanModifyString(ctx context.Context, req pla
 	// If either value is null/unknown, no modification needed
 	if req.PlanValue.IsNull() || req.PlanValue.IsUnknown() ||
 		req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		m.logNormalization(ctx, req, "null or unknown value, kept planned value", nil)
 		return
 	}
 
@@ -49,36 +87,176 @@ func (m jsonNormalizePlanModifier) PlanModifyString(ctx context.Context, req pla
 
 	// If strings are identical, no need to normalize
 	if planString == stateString {
+		m.logNormalization(ctx, req, "identical values", nil)
 		return
 	}
 
 	// Normalize both JSON strings for comparison
-	planJSON, planErr := NormalizeJSON(planString)
-	stateJSON, stateErr := NormalizeJSON(stateString)
+	planJSON, planErr := m.normalize(planString)
+	stateJSON, stateErr := m.normalize(stateString)
+
+	fields := map[string]any{
+		"plan_normalized":  planJSON,
+		"state_normalized": stateJSON,
+	}
+	if planErr != nil {
+		fields["plan_error"] = planErr.Error()
+	}
+	if stateErr != nil {
+		fields["state_error"] = stateErr.Error()
+	}
 
 	// If normalization succeeds and they're semantically equal, keep the state value
 	if planErr == nil && stateErr == nil && planJSON == stateJSON {
 		resp.PlanValue = req.StateValue
+		m.logNormalization(ctx, req, "equivalent values, kept state value", fields)
 		return
 	}
 
 	// If there's an error normalizing the plan value, but the state value is valid,
 	// keep the plan value (let other validation catch the error)
 	// Otherwise, proceed with the planned value
+	m.logNormalization(ctx, req, "different values, kept planned value", fields)
 }
 
-// NormalizeJSON normalizes JSON by sorting keys recursively.
+// logNormalization logs a JSONNormalize decision along with the values it
+// compared, when enabled through DebugNormalizationEnv.
+func (m jsonNormalizePlanModifier) logNormalization(ctx context.Context, req planmodifier.StringRequest, decision string, fields map[string]any) {
+	if !m.debug {
+		return
+	}
+
+	entry := map[string]any{
+		"path":     req.Path.String(),
+		"decision": decision,
+		"plan":     rawValue(req.PlanValue),
+		"state":    rawValue(req.StateValue),
+	}
+	for key, value := range fields {
+		entry[key] = value
+	}
+
+	tflog.Debug(ctx, "JSON normalization", entry)
+}
+
+// rawValue returns the string held by value as is, or <null> and <unknown>.
+func rawValue(value types.String) string {
+	if value.IsNull() || value.IsUnknown() {
+		return value.String()
+	}
+
+	return value.ValueString()
+}
+
+// NormalizeJSON normalizes JSON by sorting keys recursively. Arrays keep
+// their order.
+//
+// Numbers are decoded without going through float64 first. Integral values,
+// whatever their notation (5, 5.0, 5e0), are written as exact integers, so
+// integers beyond 2^53 keep every digit. Other values are written as the
+// shortest decimal that round trips, so 0.90 and 0.9 normalize alike.
 func NormalizeJSON(jsonStr string) (string, error) {
+	return normalize(jsonStr, false)
+}
+
+// NormalizeSchemaJSON normalizes a JSON Schema document like NormalizeJSON,
+// and also sorts the entries of "required" arrays at every level, as their
+// order is not significant. Every other array keeps its order.
+func NormalizeSchemaJSON(jsonStr string) (string, error) {
+	return normalize(jsonStr, true)
+}
+
+// KeepEquivalent returns configured when it holds the same JSON as value once
+// both are normalized with normalize, and value otherwise. Resources use it
+// when writing a value read from the API to state, so an equivalent response
+// does not replace the configured string, which Terraform would report as an
+// inconsistent result after apply.
+func KeepEquivalent(configured types.String, value string, normalize func(string) (string, error)) types.String {
+	if configured.IsNull() || configured.IsUnknown() {
+		return types.StringValue(value)
+	}
+
+	configuredJSON, configuredErr := normalize(configured.ValueString())
+	valueJSON, valueErr := normalize(value)
+	if configuredErr == nil && valueErr == nil && configuredJSON == valueJSON {
+		return configured
+	}
+
+	return types.StringValue(value)
+}
+
+// KeepConfiguredJSON sets the string attributes matching expressions back to
+// their configured value in plan, undoing JSONNormalize, so any difference
+// from the prior value, formatting and key order included, is planned as a
+// change. The JSON syntax of configured values is still checked.
+//
+// Resources call it from ModifyPlan when their provider block sets
+// disable_json_normalization. Attribute plan modifiers are built with the
+// schema and cannot tell provider blocks apart, while resource plan
+// modification runs after them on a resource configured by one block.
+func KeepConfiguredJSON(ctx context.Context, config tfsdk.Config, plan *tfsdk.Plan, expressions ...path.Expression) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Nothing to plan on destroy
+	if plan.Raw.IsNull() {
+		return diags
+	}
+
+	for _, expression := range expressions {
+		paths, pathDiags := config.PathMatches(ctx, expression)
+		diags.Append(pathDiags...)
+
+		for _, attributePath := range paths {
+			// A null parent block is returned in place of its attributes
+			if !expression.Matches(attributePath) {
+				continue
+			}
+
+			var configured types.String
+			diags.Append(config.GetAttribute(ctx, attributePath, &configured)...)
+			if diags.HasError() {
+				return diags
+			}
+
+			if configured.IsNull() || configured.IsUnknown() {
+				continue
+			}
+
+			if configured.ValueString() != "" {
+				if _, err := NormalizeJSON(configured.ValueString()); err != nil {
+					diags.AddAttributeError(attributePath, "Invalid JSON", fmt.Sprintf("The value is not valid JSON: %s", err))
+					continue
+				}
+			}
+
+			diags.Append(plan.SetAttribute(ctx, attributePath, configured)...)
+		}
+	}
+
+	return diags
+}
+
+// normalize returns the canonical form of jsonStr, with the entries of
+// "required" arrays sorted when sortRequired is set.
+func normalize(jsonStr string, sortRequired bool) (string, error) {
 	if jsonStr == "" {
 		return "", nil
 	}
 
+	decoder := json.NewDecoder(strings.NewReader(jsonStr))
+	decoder.UseNumber()
+
 	var obj any
-	if err := json.Unmarshal([]byte(jsonStr), &obj); err != nil {
+	if err := decoder.Decode(&obj); err != nil {
 		return "", err
 	}
 
-	normalized := normalizeValue(obj)
+	// Reject trailing data, as json.Unmarshal does
+	if _, err := decoder.Token(); err != io.EOF {
+		return "", fmt.Errorf("invalid character after top-level value")
+	}
+
+	normalized := normalizeValue(obj, sortRequired)
 
 	var buf bytes.Buffer
 	encoder := json.NewEncoder(&buf)
@@ -98,8 +276,24 @@ func NormalizeJSON(jsonStr string) (string, error) {
 	return result, nil
 }
 
+// CompactJSON removes insignificant whitespace from JSON without reordering
+// object keys or rewriting numbers, for values whose authored key order is
+// significant, such as schemas shown in the Tama console.
+func CompactJSON(jsonStr string) (string, error) {
+	if jsonStr == "" {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(jsonStr)); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
 // normalizeValue recursively processes values to ensure consistent ordering.
-func normalizeValue(v any) any {
+func normalizeValue(v any, sortRequired bool) any {
 	switch val := v.(type) {
 	case map[string]any:
 		// Create a new map and process keys in sorted order
@@ -114,7 +308,13 @@ func normalizeValue(v any) any {
 
 		// Process each key-value pair recursively
 		for _, k := range keys {
-			normalized[k] = normalizeValue(val[k])
+			normalized[k] = normalizeValue(val[k], sortRequired)
+		}
+
+		if sortRequired {
+			if required, ok := sortedRequired(normalized["required"]); ok {
+				normalized["required"] = required
+			}
 		}
 		return normalized
 
@@ -122,12 +322,65 @@ func normalizeValue(v any) any {
 		// Process array elements recursively
 		normalized := make([]any, len(val))
 		for i, elem := range val {
-			normalized[i] = normalizeValue(elem)
+			normalized[i] = normalizeValue(elem, sortRequired)
 		}
 		return normalized
 
+	case json.Number:
+		return normalizeNumber(val)
+
 	default:
-		// For primitive types (string, number, bool, null), return as-is
+		// For other primitive types (string, bool, null), return as-is
 		return val
 	}
 }
+
+// maxIntegerBits bounds the size of integers written out digit by digit, so
+// a literal such as 1e1000000 is not expanded.
+const maxIntegerBits = 1024
+
+// normalizeNumber returns the canonical form of a JSON number literal.
+func normalizeNumber(n json.Number) any {
+	literal := n.String()
+
+	var rat big.Rat
+	if _, ok := rat.SetString(literal); !ok {
+		return n
+	}
+
+	if rat.IsInt() && rat.Num().BitLen() <= maxIntegerBits {
+		return json.Number(rat.Num().String())
+	}
+
+	value, err := strconv.ParseFloat(literal, 64)
+	if err != nil {
+		return n
+	}
+	return value
+}
+
+// sortedRequired returns a sorted copy of a JSON Schema "required" value. It
+// reports false when the value is not an array of strings, e.g. the boolean
+// "required" of an OpenAPI parameter, which is left as is.
+func sortedRequired(v any) ([]any, bool) {
+	values, ok := v.([]any)
+	if !ok {
+		return nil, false
+	}
+
+	names := make([]string, len(values))
+	for i, value := range values {
+		name, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		names[i] = name
+	}
+	sort.Strings(names)
+
+	sorted := make([]any, len(names))
+	for i, name := range names {
+		sorted[i] = name
+	}
+	return sorted, true
+}
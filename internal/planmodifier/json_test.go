@@ -4,11 +4,18 @@
 package planmodifier
 
 import (
+	"bytes"
 	"context"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
 )
 
 func TestJSONNormalize(t *testing.T) {
@@ -52,6 +59,34 @@ func TestJSONNormalize(t *testing.T) {
 			expectSuppression: true,
 			description:       "pretty formatted vs minified JSON should be suppressed",
 		},
+		{
+			name:              "reordered required entries",
+			planValue:         types.StringValue(`{"type": "object", "required": ["name", "id"]}`),
+			stateValue:        types.StringValue(`{"required":["id","name"],"type":"object"}`),
+			expectSuppression: false,
+			description:       "required entries are only unordered in JSON schemas, see JSONSchemaNormalize",
+		},
+		{
+			name:              "integer written with a fraction",
+			planValue:         types.StringValue(`{"top_n": 5.0, "threshold": 0.90}`),
+			stateValue:        types.StringValue(`{"threshold":0.9,"top_n":5}`),
+			expectSuppression: true,
+			description:       "equal numbers in a different notation should be suppressed",
+		},
+		{
+			name:              "large integers",
+			planValue:         types.StringValue(`{"id": 9007199254740993}`),
+			stateValue:        types.StringValue(`{"id":9007199254740992}`),
+			expectSuppression: false,
+			description:       "integers beyond 2^53 which differ should not be suppressed",
+		},
+		{
+			name:              "reordered enum entries",
+			planValue:         types.StringValue(`{"enum": ["b", "a"]}`),
+			stateValue:        types.StringValue(`{"enum":["a","b"]}`),
+			expectSuppression: false,
+			description:       "arrays other than required keep their order",
+		},
 		{
 			name:              "different JSON content",
 			planValue:         types.StringValue(`{"key": "value1"}`),
@@ -193,6 +228,12 @@ func TestNormalizeJSON(t *testing.T) {
 			expected: `{"outer":{"inner":{"deep":"value"}}}`,
 			hasError: false,
 		},
+		{
+			name:     "required order kept",
+			input:    `{"required": ["title", "body", "author"], "type": "object"}`,
+			expected: `{"required":["title","body","author"],"type":"object"}`,
+			hasError: false,
+		},
 		{
 			name:     "invalid JSON",
 			input:    `{"key": invalid}`,
@@ -228,6 +269,94 @@ func TestNormalizeJSON(t *testing.T) {
 	}
 }
 
+func TestNormalizeSchemaJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "required entries sorted",
+			input:    `{"required": ["title", "body", "author"], "type": "object"}`,
+			expected: `{"required":["author","body","title"],"type":"object"}`,
+		},
+		{
+			name:     "nested required entries sorted",
+			input:    `{"properties": {"entity": {"required": ["z", "a"], "type": "object"}}, "required": ["entity"]}`,
+			expected: `{"properties":{"entity":{"required":["a","z"],"type":"object"}},"required":["entity"]}`,
+		},
+		{
+			name:     "enum order kept",
+			input:    `{"enum": ["high", "medium", "low"], "required": ["b", "a"]}`,
+			expected: `{"enum":["high","medium","low"],"required":["a","b"]}`,
+		},
+		{
+			name:     "boolean required kept",
+			input:    `{"in": "query", "name": "q", "required": true}`,
+			expected: `{"in":"query","name":"q","required":true}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := NormalizeSchemaJSON(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestJSONSchemaNormalize(t *testing.T) {
+	t.Parallel()
+
+	plan := types.StringValue(`{"type": "object", "required": ["name", "id"]}`)
+	state := types.StringValue(`{"required":["id","name"],"type":"object"}`)
+
+	resp := &planmodifier.StringResponse{PlanValue: plan}
+	JSONSchemaNormalize().PlanModifyString(context.Background(), planmodifier.StringRequest{PlanValue: plan, StateValue: state}, resp)
+	if !resp.PlanValue.Equal(state) {
+		t.Errorf("expected reordered required entries to be suppressed, got %s", resp.PlanValue)
+	}
+
+	changed := types.StringValue(`{"type": "object", "required": ["name"]}`)
+	resp = &planmodifier.StringResponse{PlanValue: changed}
+	JSONSchemaNormalize().PlanModifyString(context.Background(), planmodifier.StringRequest{PlanValue: changed, StateValue: state}, resp)
+	if !resp.PlanValue.Equal(changed) {
+		t.Errorf("expected different required entries to be planned, got %s", resp.PlanValue)
+	}
+}
+
+func TestKeepEquivalent(t *testing.T) {
+	t.Parallel()
+
+	configured := types.StringValue(`{"type": "object", "required": ["name", "id"]}`)
+
+	if got := KeepEquivalent(configured, `{"required":["id","name"],"type":"object"}`, NormalizeSchemaJSON); !got.Equal(configured) {
+		t.Errorf("expected the configured value to be kept, got %s", got)
+	}
+
+	if got := KeepEquivalent(configured, `{"required":["id","name"],"type":"object"}`, NormalizeJSON); got.ValueString() != `{"required":["id","name"],"type":"object"}` {
+		t.Errorf("expected the value when only equivalent as a schema, got %s", got)
+	}
+
+	if got := KeepEquivalent(configured, `{"required":["id"],"type":"object"}`, NormalizeSchemaJSON); got.ValueString() != `{"required":["id"],"type":"object"}` {
+		t.Errorf("expected a different value to replace the configured one, got %s", got)
+	}
+
+	if got := KeepEquivalent(types.StringNull(), `{"a":1}`, NormalizeJSON); got.ValueString() != `{"a":1}` {
+		t.Errorf("expected the value without a configured one, got %s", got)
+	}
+}
+
 func TestJSONNormalizePlanModifier_Description(t *testing.T) {
 	modifier := jsonNormalizePlanModifier{}
 
@@ -332,3 +461,269 @@ func TestNormalizeJSON_KeyOrdering(t *testing.T) {
 		t.Errorf("Got:      %s", normalizedNested)
 	}
 }
+
+func TestNormalizeJSON_Numbers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"small integer", `{"top_n": 5}`, `{"top_n":5}`},
+		{"negative integer", `{"offset": -12}`, `{"offset":-12}`},
+		{"zero", `{"value": 0}`, `{"value":0}`},
+		{"integer beyond 2^53", `{"id": 9007199254740993}`, `{"id":9007199254740993}`},
+		{"integer beyond int64", `{"id": 123456789012345678901234567890}`, `{"id":123456789012345678901234567890}`},
+		{"integer with trailing zero fraction", `{"top_n": 5.0}`, `{"top_n":5}`},
+		{"decimal with trailing zeros", `{"threshold": 0.90}`, `{"threshold":0.9}`},
+		{"decimal", `{"threshold": 0.9}`, `{"threshold":0.9}`},
+		{"decimal sum artifact", `{"value": 0.30000000000000004}`, `{"value":0.30000000000000004}`},
+		{"exponent integer", `{"max_tokens": 1e3}`, `{"max_tokens":1000}`},
+		{"uppercase exponent integer", `{"max_tokens": 2.5E2}`, `{"max_tokens":250}`},
+		{"negative exponent", `{"epsilon": 1.5e-7}`, `{"epsilon":1.5e-7}`},
+		{"large exponent", `{"value": 1e21}`, `{"value":1000000000000000000000}`},
+		{"numbers in arrays", `[1.0, 2.50, 3e0]`, `[1,2.5,3]`},
+		{"number at top level", `42.0`, `42`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := NormalizeJSON(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+
+			again, err := NormalizeJSON(result)
+			if err != nil {
+				t.Fatalf("unexpected error normalizing again: %v", err)
+			}
+			if again != result {
+				t.Errorf("normalization is not idempotent: %s then %s", result, again)
+			}
+		})
+	}
+}
+
+func TestNormalizeJSON_NumbersEquivalence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		a     string
+		b     string
+		equal bool
+	}{
+		{"integer and trailing zero fraction", `{"top_n": 5}`, `{"top_n": 5.0}`, true},
+		{"integer and exponent", `{"n": 1000}`, `{"n": 1e3}`, true},
+		{"trailing zeros", `{"t": 0.9}`, `{"t": 0.900}`, true},
+		{"large integers one apart", `{"id": 9007199254740993}`, `{"id": 9007199254740992}`, false},
+		{"different decimals", `{"t": 0.9}`, `{"t": 0.8}`, false},
+		{"number and string", `{"n": 5}`, `{"n": "5"}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := NormalizeJSON(tt.a)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			b, err := NormalizeJSON(tt.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if (a == b) != tt.equal {
+				t.Errorf("expected equal=%t, got %s and %s", tt.equal, a, b)
+			}
+		})
+	}
+}
+
+func TestNormalizeJSON_TrailingData(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{`{"a": 1} {"b": 2}`, `{"a": 1}]`, `1 2`} {
+		if _, err := NormalizeJSON(input); err == nil {
+			t.Errorf("expected an error for %q", input)
+		}
+	}
+
+	if _, err := NormalizeJSON("{\"a\": 1}\n"); err != nil {
+		t.Errorf("unexpected error for trailing whitespace: %v", err)
+	}
+}
+
+func TestCompactJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"keeps key order", `{"zeta": 1, "alpha": {"b": true, "a": null}}`, `{"zeta":1,"alpha":{"b":true,"a":null}}`},
+		{"keeps number literals", "[1.50, 1e3, 12345678901234567890]", "[1.50,1e3,12345678901234567890]"},
+		{"keeps whitespace in strings", "{\n  \"title\": \"A  title\"\n}", `{"title":"A  title"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := CompactJSON(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+
+	if _, err := CompactJSON(`{"a": }`); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestJSONNormalize_DebugLogging(t *testing.T) {
+	t.Parallel()
+
+	run := func(debug bool) string {
+		var output bytes.Buffer
+		ctx := tflogtest.RootLogger(context.Background(), &output)
+
+		req := planmodifier.StringRequest{
+			Path:       path.Root("schema_json"),
+			PlanValue:  types.StringValue(`{"b": 2, "a": 1}`),
+			StateValue: types.StringValue(`{"a":1,"b":2}`),
+		}
+		resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+		jsonNormalizePlanModifier{normalize: NormalizeJSON, debug: debug}.PlanModifyString(ctx, req, resp)
+
+		return output.String()
+	}
+
+	if output := run(false); output != "" {
+		t.Errorf("expected no logs while disabled, got %s", output)
+	}
+
+	output := run(true)
+	for _, expected := range []string{
+		`"path":"schema_json"`,
+		`"decision":"equivalent values, kept state value"`,
+		`"plan_normalized":"{\"a\":1,\"b\":2}"`,
+		`"state_normalized":"{\"a\":1,\"b\":2}"`,
+		`"plan":"{\"b\": 2, \"a\": 1}"`,
+		`"state":"{\"a\":1,\"b\":2}"`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected logs to contain %s, got %s", expected, output)
+		}
+	}
+}
+
+func TestJSONNormalize_DebugEnv(t *testing.T) {
+	// Not parallel, it sets an environment variable.
+	t.Setenv(DebugNormalizationEnv, "true")
+	if modifier := JSONNormalize().(jsonNormalizePlanModifier); !modifier.debug {
+		t.Errorf("expected %s to enable logging", DebugNormalizationEnv)
+	}
+
+	t.Setenv(DebugNormalizationEnv, "")
+	if modifier := JSONSchemaNormalize().(jsonNormalizePlanModifier); modifier.debug {
+		t.Error("expected logging to be disabled by default")
+	}
+}
+
+func TestKeepConfiguredJSON(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"parameters": schema.StringAttribute{Optional: true},
+		},
+		Blocks: map[string]schema.Block{
+			"module": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"parameters": schema.StringAttribute{Optional: true},
+				},
+			},
+		},
+	}
+	tfType := testSchema.Type().TerraformType(ctx)
+	moduleType := tfType.(tftypes.Object).AttributeTypes["module"]
+
+	value := func(parameters, moduleParameters *string) tftypes.Value {
+		module := tftypes.NewValue(moduleType, nil)
+		if moduleParameters != nil {
+			module = tftypes.NewValue(moduleType, map[string]tftypes.Value{
+				"parameters": tftypes.NewValue(tftypes.String, *moduleParameters),
+			})
+		}
+
+		return tftypes.NewValue(tfType, map[string]tftypes.Value{
+			"parameters": tftypes.NewValue(tftypes.String, *parameters),
+			"module":     module,
+		})
+	}
+	ptr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name             string
+		parameters       string
+		moduleParameters *string
+		errors           int
+	}{
+		{"configured formatting kept", `{"b": 2, "a": 1}`, nil, 0},
+		{"nested configured formatting kept", `{"a":1,"b":2}`, ptr(`{ "a": 1 }`), 0},
+		{"invalid JSON reported", `{"a": 1,}`, nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := tfsdk.Config{Schema: testSchema, Raw: value(&tt.parameters, tt.moduleParameters)}
+
+			// The plan as JSONNormalize leaves it, with the prior values kept
+			var normalizedModule *string
+			if tt.moduleParameters != nil {
+				normalizedModule = ptr(`{"a":1}`)
+			}
+			plan := tfsdk.Plan{Schema: testSchema, Raw: value(ptr(`{"a":1,"b":2}`), normalizedModule)}
+
+			diags := KeepConfiguredJSON(ctx, config, &plan, path.MatchRoot("parameters"), path.MatchRoot("module").AtName("parameters"))
+			if diags.ErrorsCount() != tt.errors {
+				t.Fatalf("expected %d errors, got %v", tt.errors, diags)
+			}
+			if tt.errors > 0 {
+				return
+			}
+
+			var parameters types.String
+			plan.GetAttribute(ctx, path.Root("parameters"), &parameters)
+			if parameters.ValueString() != tt.parameters {
+				t.Errorf("expected planned parameters %s, got %s", tt.parameters, parameters.ValueString())
+			}
+
+			if tt.moduleParameters != nil {
+				var moduleParameters types.String
+				plan.GetAttribute(ctx, path.Root("module").AtName("parameters"), &moduleParameters)
+				if moduleParameters.ValueString() != *tt.moduleParameters {
+					t.Errorf("expected planned module parameters %s, got %s", *tt.moduleParameters, moduleParameters.ValueString())
+				}
+			}
+		})
+	}
+}
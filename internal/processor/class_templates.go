@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package processor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/upmaru/tama-go/neural"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+)
+
+// ClassGetter looks up a class so embedding templates can be generated from
+// its properties.
+type ClassGetter interface {
+	GetClass(id string) (*neural.Class, error)
+}
+
+// ClassTemplates returns the embedding templates generated from the
+// properties of class: a single document template listing each property,
+// sorted by name, as "name: {name}".
+func ClassTemplates(class *neural.Class) ([]TemplateModel, error) {
+	properties, _ := class.Schema["properties"].(map[string]any)
+	if len(properties) == 0 {
+		return nil, fmt.Errorf("class %s has no properties to generate templates from", class.ID)
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = fmt.Sprintf("%s: {%s}", name, name)
+	}
+
+	return []TemplateModel{
+		{
+			Type:    types.StringValue("document"),
+			Content: types.StringValue(strings.Join(lines, "\n")),
+		},
+	}, nil
+}
+
+// ResolveClassTemplates sets the templates of an embedding configuration
+// from the class referenced by templates_from_class_id, so they can be sent
+// to the API. Nothing is done when the attribute is not set.
+func ResolveClassTemplates(embedding *EmbeddingConfigModel, classes ClassGetter) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if embedding == nil || embedding.TemplatesFromClassId.IsNull() || embedding.TemplatesFromClassId.IsUnknown() {
+		return diags
+	}
+
+	attributePath := path.Root("embedding").AtName("templates_from_class_id")
+	classID := embedding.TemplatesFromClassId.ValueString()
+
+	class, err := classes.GetClass(classID)
+	if err != nil {
+		diags.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read class %s to generate embedding templates, got error: %s", classID, err)))
+		return diags
+	}
+
+	templates, err := ClassTemplates(class)
+	if err != nil {
+		diags.AddAttributeError(attributePath, "Invalid Class", err.Error())
+		return diags
+	}
+
+	embedding.Templates = templates
+
+	return diags
+}
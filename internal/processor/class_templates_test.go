@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package processor_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/upmaru/tama-go/neural"
+	"github.com/upmaru/terraform-provider-tama/internal/fake"
+	"github.com/upmaru/terraform-provider-tama/internal/processor"
+)
+
+func TestClassTemplates(t *testing.T) {
+	t.Parallel()
+
+	class := &neural.Class{
+		ID: "class-1",
+		Schema: map[string]any{
+			"title": "article",
+			"properties": map[string]any{
+				"title":   map[string]any{"type": "string"},
+				"body":    map[string]any{"type": "string"},
+				"summary": map[string]any{"type": "string"},
+			},
+		},
+	}
+
+	templates, err := processor.ClassTemplates(class)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(templates) != 1 {
+		t.Fatalf("expected 1 template, got %d", len(templates))
+	}
+	if templates[0].Type.ValueString() != "document" {
+		t.Errorf("expected a document template, got %s", templates[0].Type)
+	}
+	if want := "body: {body}\nsummary: {summary}\ntitle: {title}"; templates[0].Content.ValueString() != want {
+		t.Errorf("expected content %q, got %q", want, templates[0].Content.ValueString())
+	}
+
+	if _, err := processor.ClassTemplates(&neural.Class{ID: "class-2", Schema: map[string]any{"title": "empty"}}); err == nil {
+		t.Error("expected an error for a class without properties")
+	}
+}
+
+func TestResolveClassTemplates(t *testing.T) {
+	t.Parallel()
+
+	classes := fake.NewClasses()
+	classes.Classes["class-1"] = &neural.Class{
+		ID:     "class-1",
+		Schema: map[string]any{"properties": map[string]any{"name": map[string]any{"type": "string"}}},
+	}
+
+	embedding := &processor.EmbeddingConfigModel{TemplatesFromClassId: types.StringValue("class-1")}
+	if diags := processor.ResolveClassTemplates(embedding, classes); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(embedding.Templates) != 1 || embedding.Templates[0].Content.ValueString() != "name: {name}" {
+		t.Errorf("expected templates generated from the class, got %#v", embedding.Templates)
+	}
+
+	// Nothing is looked up without a class
+	classes.Err = &neural.Error{StatusCode: 500}
+	if diags := processor.ResolveClassTemplates(&processor.EmbeddingConfigModel{TemplatesFromClassId: types.StringNull()}, classes); diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+	if diags := processor.ResolveClassTemplates(nil, classes); diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+
+	classes.Err = nil
+	missing := &processor.EmbeddingConfigModel{TemplatesFromClassId: types.StringValue("class-2")}
+	if diags := processor.ResolveClassTemplates(missing, classes); !diags.HasError() {
+		t.Error("expected an error for a missing class")
+	}
+}
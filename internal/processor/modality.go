@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package processor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/upmaru/tama-go/sensory"
+)
+
+// ModelGetter looks up a model so its path and parameters can be compared
+// with the processor configuration.
+type ModelGetter interface {
+	GetModel(id string) (*sensory.Model, error)
+}
+
+// modalityPaths maps well known model path suffixes to the processor type
+// they serve. Longer suffixes are listed first so "/chat/completions" is not
+// mistaken for a bare "/completions".
+var modalityPaths = []struct {
+	suffix        string
+	processorType string
+}{
+	{"/chat/completions", "completion"},
+	{"/completions", "completion"},
+	{"/embeddings", "embedding"},
+	{"/rerank", "reranking"},
+}
+
+// ModalityForPath returns the processor type served by a model path, or an
+// empty string when the path is not recognized.
+func ModalityForPath(modelPath string) string {
+	trimmed := strings.TrimRight(modelPath, "/")
+
+	for _, candidate := range modalityPaths {
+		if strings.HasSuffix(trimmed, candidate.suffix) {
+			return candidate.processorType
+		}
+	}
+
+	return ""
+}
+
+// CheckPlannedModelModality runs CheckModelModality for a planned processor.
+// Only model_id and the presence of each configuration block are read, so
+// unknown values elsewhere in the plan do not affect the check.
+func CheckPlannedModelModality(ctx context.Context, plan tfsdk.Plan, models ModelGetter, strict bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Nothing to check on destroy
+	if models == nil || plan.Raw.IsNull() {
+		return diags
+	}
+
+	var modelID types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("model_id"), &modelID)...)
+
+	// The model may not exist yet, in which case there is nothing to compare
+	if diags.HasError() || modelID.IsUnknown() || modelID.IsNull() {
+		return diags
+	}
+
+	processorType := ""
+	for _, candidate := range []string{"completion", "embedding", "reranking"} {
+		var block types.Object
+		diags.Append(plan.GetAttribute(ctx, path.Root(candidate), &block)...)
+
+		if !block.IsNull() {
+			processorType = candidate
+			break
+		}
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(CheckModelModality(ctx, models, modelID.ValueString(), processorType, strict)...)
+
+	return diags
+}
+
+// CheckModelModality reads the referenced model from the API, compares its
+// path with the processor type and reports a mismatch on model_id. The check
+// is skipped when the model or type is unknown at plan time, the model cannot
+// be read, or the API does not report its path. A model planned for creation
+// has an unknown ID, and changing the modality of a model replaces it, so the
+// path read from the API is the one the processor will use. Mismatches are
+// warnings unless strict is set.
+func CheckModelModality(ctx context.Context, models ModelGetter, modelID, processorType string, strict bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if modelID == "" || processorType == "" {
+		return diags
+	}
+
+	model, err := models.GetModel(modelID)
+	if err != nil {
+		tflog.Debug(ctx, "Unable to read model for modality check during plan", map[string]any{
+			"model_id": modelID,
+			"error":    err.Error(),
+		})
+		return diags
+	}
+
+	modelPath := model.Path
+	if modelPath == "" {
+		tflog.Debug(ctx, "Path of model not reported by the API, skipping modality check", map[string]any{
+			"model_id": modelID,
+		})
+		return diags
+	}
+
+	modality := ModalityForPath(modelPath)
+	if modality == "" || modality == processorType {
+		return diags
+	}
+
+	summary := "Model Modality Mismatch"
+	detail := fmt.Sprintf("Model %q has path %q, which serves %s requests, but it is used by a %s processor. "+
+		"The API accepts this configuration, but requests will fail at runtime.", modelID, modelPath, modality, processorType)
+
+	if strict {
+		diags.AddAttributeError(path.Root("model_id"), summary, detail)
+	} else {
+		diags.AddAttributeWarning(path.Root("model_id"), summary, detail+" Set strict_model_modality on the provider to make this an error.")
+	}
+
+	return diags
+}
@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package processor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/upmaru/tama-go/sensory"
+	"github.com/upmaru/terraform-provider-tama/internal/fake"
+	"github.com/upmaru/terraform-provider-tama/internal/processor"
+)
+
+func TestModalityForPath(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"/chat/completions":    "completion",
+		"/v1/chat/completions": "completion",
+		"/completions":         "completion",
+		"/v1/embeddings":       "embedding",
+		"/embeddings/":         "embedding",
+		"/v1/rerank":           "reranking",
+		"/v1/models":           "",
+		"":                     "",
+	}
+
+	for modelPath, expected := range tests {
+		if modality := processor.ModalityForPath(modelPath); modality != expected {
+			t.Errorf("ModalityForPath(%q) = %q, expected %q", modelPath, modality, expected)
+		}
+	}
+}
+
+func TestCheckModelModality(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	models := fake.NewModels(
+		&sensory.Model{ID: "chat", Path: "/chat/completions"},
+		&sensory.Model{ID: "embed", Path: "/v1/embeddings"},
+		&sensory.Model{ID: "no-path"},
+	)
+
+	tests := []struct {
+		name          string
+		modelID       string
+		processorType string
+		strict        bool
+		warnings      int
+		errors        int
+	}{
+		{"matching", "chat", "completion", false, 0, 0},
+		{"mismatch warns", "embed", "completion", false, 1, 0},
+		{"mismatch errors when strict", "embed", "completion", true, 0, 1},
+		{"missing model skipped", "missing", "completion", true, 0, 0},
+		{"path not reported skipped", "no-path", "completion", true, 0, 0},
+		{"no type skipped", "embed", "", true, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := processor.CheckModelModality(ctx, models, tt.modelID, tt.processorType, tt.strict)
+			if diags.WarningsCount() != tt.warnings || diags.ErrorsCount() != tt.errors {
+				t.Errorf("expected %d warnings and %d errors, got %v", tt.warnings, tt.errors, diags)
+			}
+		})
+	}
+}
@@ -21,28 +21,34 @@ type TemplateModel struct {
 
 // CompletionConfigModel describes the completion configuration data model.
 type CompletionConfigModel struct {
-	Temperature  types.Float64      `tfsdk:"temperature"`
-	ToolChoice   types.String       `tfsdk:"tool_choice"`
-	RoleMappings []RoleMappingModel `tfsdk:"role_mappings"`
-	Parameters   types.String       `tfsdk:"parameters"`
+	Temperature        types.Float64      `tfsdk:"temperature"`
+	ToolChoice         types.String       `tfsdk:"tool_choice"`
+	ToolChoiceFunction types.String       `tfsdk:"tool_choice_function"`
+	ReasoningEffort    types.String       `tfsdk:"reasoning_effort"`
+	RoleMappings       []RoleMappingModel `tfsdk:"role_mappings"`
+	Parameters         types.String       `tfsdk:"parameters"`
 }
 
 // EmbeddingConfigModel describes the embedding configuration data model.
 type EmbeddingConfigModel struct {
-	MaxTokens types.Int64     `tfsdk:"max_tokens"`
-	Templates []TemplateModel `tfsdk:"templates"`
+	MaxTokens            types.Int64     `tfsdk:"max_tokens"`
+	Templates            []TemplateModel `tfsdk:"templates"`
+	TemplatesFromClassId types.String    `tfsdk:"templates_from_class_id"`
 }
 
 // RerankingConfigModel describes the reranking configuration data model.
 type RerankingConfigModel struct {
-	Parameters types.String `tfsdk:"parameters"`
+	TopN       types.Int64   `tfsdk:"top_n"`
+	Threshold  types.Float64 `tfsdk:"threshold"`
+	Parameters types.String  `tfsdk:"parameters"`
 }
 
 // ProcessorModel describes the common processor data model.
 type ProcessorModel struct {
-	Id      types.String `tfsdk:"id"`
-	ModelId types.String `tfsdk:"model_id"`
-	Type    types.String `tfsdk:"type"`
+	Id              types.String `tfsdk:"id"`
+	ModelId         types.String `tfsdk:"model_id"`
+	Type            types.String `tfsdk:"type"`
+	EffectiveConfig types.String `tfsdk:"effective_config"`
 }
 
 // NeuralProcessorModel for neural processors.
@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// typedCompletionParameters lists the completion attributes sent next to
+// parameters. The same key inside parameters would make the request
+// ambiguous.
+var typedCompletionParameters = []string{"temperature", "tool_choice", "reasoning_effort", "role_mappings"}
+
+// typedRerankingParameters lists the reranking attributes merged into
+// parameters.
+var typedRerankingParameters = []string{"top_n", "threshold"}
+
+// CheckParameterCollisions reports the keys of the completion and reranking
+// parameters JSON objects which are also configured through a typed
+// attribute.
+func CheckParameterCollisions(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Nothing to check on destroy
+	if config.Raw.IsNull() {
+		return diags
+	}
+
+	diags.Append(checkBlockParameterCollisions(ctx, config, "completion", typedCompletionParameters, "Conflicting Completion Parameter")...)
+	diags.Append(checkBlockParameterCollisions(ctx, config, "reranking", typedRerankingParameters, "Conflicting Reranking Parameter")...)
+
+	return diags
+}
+
+func checkBlockParameterCollisions(ctx context.Context, config tfsdk.Config, block string, typed []string, summary string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var object types.Object
+	diags.Append(config.GetAttribute(ctx, path.Root(block), &object)...)
+	if diags.HasError() || object.IsNull() || object.IsUnknown() {
+		return diags
+	}
+
+	attributes := object.Attributes()
+
+	parameters, ok := attributes["parameters"].(types.String)
+	if !ok || parameters.IsNull() || parameters.IsUnknown() || parameters.ValueString() == "" {
+		return diags
+	}
+
+	// Malformed parameters are reported when the request is built
+	var parametersMap map[string]any
+	if err := json.Unmarshal([]byte(parameters.ValueString()), &parametersMap); err != nil {
+		return diags
+	}
+
+	var collisions []string
+	for _, name := range typed {
+		value, ok := attributes[name]
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		if _, exists := parametersMap[name]; exists {
+			collisions = append(collisions, fmt.Sprintf("%q", name))
+		}
+	}
+
+	if len(collisions) == 0 {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		path.Root(block).AtName("parameters"),
+		summary,
+		fmt.Sprintf("The following keys are set both as an attribute and inside parameters: %s. "+
+			"Remove them from parameters and use the attributes instead.", strings.Join(collisions, ", ")),
+	)
+
+	return diags
+}
+
+// CheckModelParameterConflicts reports keys set both in the completion
+// parameters of a processor and in the parameters of the referenced model
+// with different values. The completion parameters take precedence over the
+// model's parameters for the same key. It runs when the processor is created
+// or updated: Terraform applies a referenced tama_model first, so the model
+// read from the API carries the parameters of the same apply rather than
+// values a pending change would replace. The check is skipped when there are
+// no completion parameters or the model cannot be read. Conflicts are
+// warnings unless strict is set.
+func CheckModelParameterConflicts(ctx context.Context, modelID string, completion *CompletionConfigModel, models ModelGetter, strict bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if models == nil || modelID == "" || completion == nil {
+		return diags
+	}
+
+	parameters := completion.Parameters
+	if parameters.IsNull() || parameters.IsUnknown() || parameters.ValueString() == "" {
+		return diags
+	}
+
+	// Malformed parameters are reported when the request is built
+	var parametersMap map[string]any
+	if err := json.Unmarshal([]byte(parameters.ValueString()), &parametersMap); err != nil {
+		return diags
+	}
+
+	model, err := models.GetModel(modelID)
+	if err != nil {
+		tflog.Debug(ctx, "Unable to read model for parameter check", map[string]any{
+			"model_id": modelID,
+			"error":    err.Error(),
+		})
+		return diags
+	}
+
+	var conflicts []string
+	for key, value := range parametersMap {
+		modelValue, exists := model.Parameters[key]
+		if exists && !reflect.DeepEqual(value, modelValue) {
+			conflicts = append(conflicts, fmt.Sprintf("%q", key))
+		}
+	}
+
+	if len(conflicts) == 0 {
+		return diags
+	}
+	slices.Sort(conflicts)
+
+	summary := "Conflicting Model Parameter"
+	detail := fmt.Sprintf("Model %q sets %s to a different value than the completion parameters. "+
+		"The completion parameters take precedence over the model parameters.", model.Identifier, strings.Join(conflicts, ", "))
+
+	if strict {
+		diags.AddAttributeError(path.Root("completion").AtName("parameters"), summary, detail)
+	} else {
+		diags.AddAttributeWarning(path.Root("completion").AtName("parameters"), summary, detail+" Set strict_parameter_conflicts on the provider to make this an error.")
+	}
+
+	return diags
+}
@@ -0,0 +1,265 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package processor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/upmaru/tama-go/sensory"
+	"github.com/upmaru/terraform-provider-tama/internal/fake"
+	"github.com/upmaru/terraform-provider-tama/internal/processor"
+)
+
+func TestCheckParameterCollisions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	attributes, blocks := processor.GetNeuralProcessorSchema()
+	resourceSchema := schema.Schema{Attributes: attributes, Blocks: blocks}
+	tfType := resourceSchema.Type().TerraformType(ctx)
+
+	tests := []struct {
+		name           string
+		completion     processor.CompletionConfigModel
+		expectedDetail string
+	}{
+		{
+			name: "no parameters",
+			completion: processor.CompletionConfigModel{
+				Temperature: types.Float64Value(0.7),
+				Parameters:  types.StringNull(),
+			},
+		},
+		{
+			name: "unrelated parameters",
+			completion: processor.CompletionConfigModel{
+				Temperature: types.Float64Value(0.7),
+				ToolChoice:  types.StringValue("auto"),
+				Parameters:  types.StringValue(`{"max_tokens": 1000, "top_p": 0.9}`),
+			},
+		},
+		{
+			name: "typed key in parameters without the attribute",
+			completion: processor.CompletionConfigModel{
+				Parameters: types.StringValue(`{"temperature": 0.2}`),
+			},
+		},
+		{
+			name: "temperature",
+			completion: processor.CompletionConfigModel{
+				Temperature: types.Float64Value(0.7),
+				Parameters:  types.StringValue(`{"temperature": 0.2}`),
+			},
+			expectedDetail: `The following keys are set both as an attribute and inside parameters: "temperature". Remove them from parameters and use the attributes instead.`,
+		},
+		{
+			name: "tool_choice",
+			completion: processor.CompletionConfigModel{
+				ToolChoice: types.StringValue("auto"),
+				Parameters: types.StringValue(`{"tool_choice": "required"}`),
+			},
+			expectedDetail: `The following keys are set both as an attribute and inside parameters: "tool_choice". Remove them from parameters and use the attributes instead.`,
+		},
+		{
+			name: "reasoning_effort",
+			completion: processor.CompletionConfigModel{
+				ReasoningEffort: types.StringValue("low"),
+				Parameters:      types.StringValue(`{"max_tokens": 1000, "reasoning_effort": "high"}`),
+			},
+			expectedDetail: `The following keys are set both as an attribute and inside parameters: "reasoning_effort". Remove them from parameters and use the attributes instead.`,
+		},
+		{
+			name: "role_mappings",
+			completion: processor.CompletionConfigModel{
+				RoleMappings: []processor.RoleMappingModel{{From: types.StringValue("user"), To: types.StringValue("human")}},
+				Parameters:   types.StringValue(`{"role_mappings": []}`),
+			},
+			expectedDetail: `The following keys are set both as an attribute and inside parameters: "role_mappings". Remove them from parameters and use the attributes instead.`,
+		},
+		{
+			name: "several collisions",
+			completion: processor.CompletionConfigModel{
+				Temperature:     types.Float64Value(0.7),
+				ToolChoice:      types.StringValue("auto"),
+				ReasoningEffort: types.StringValue("low"),
+				Parameters:      types.StringValue(`{"reasoning_effort": "high", "temperature": 0.2, "tool_choice": "any"}`),
+			},
+			expectedDetail: `The following keys are set both as an attribute and inside parameters: "temperature", "tool_choice", "reasoning_effort". Remove them from parameters and use the attributes instead.`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			completion := tt.completion
+			data := processor.NeuralProcessorModel{
+				ProcessorModel: processor.ProcessorModel{
+					Id:      types.StringNull(),
+					ModelId: types.StringValue("model-1"),
+					Type:    types.StringNull(),
+				},
+				SpaceId:    types.StringValue("space-1"),
+				Completion: &completion,
+			}
+
+			state := tfsdk.State{Schema: resourceSchema, Raw: tftypes.NewValue(tfType, nil)}
+			if diags := state.Set(ctx, &data); diags.HasError() {
+				t.Fatalf("unable to build config: %v", diags)
+			}
+
+			diags := processor.CheckParameterCollisions(ctx, tfsdk.Config{Schema: resourceSchema, Raw: state.Raw})
+
+			if tt.expectedDetail == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected 1 error, got %v", diags)
+			}
+			if got := diags.Errors()[0].Summary(); got != "Conflicting Completion Parameter" {
+				t.Errorf("unexpected summary %q", got)
+			}
+			if got := diags.Errors()[0].Detail(); got != tt.expectedDetail {
+				t.Errorf("expected detail %q, got %q", tt.expectedDetail, got)
+			}
+		})
+	}
+}
+
+func TestCheckModelParameterConflicts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	models := fake.NewModels(
+		&sensory.Model{ID: "chat", Identifier: "mistral-small-latest", Path: "/chat/completions", Parameters: map[string]any{"max_tokens": float64(1500), "top_p": 0.9}},
+	)
+
+	tests := []struct {
+		name       string
+		modelID    string
+		parameters types.String
+		strict     bool
+		warnings   int
+		errors     int
+	}{
+		{"no parameters", "chat", types.StringNull(), false, 0, 0},
+		{"same values", "chat", types.StringValue(`{"max_tokens": 1500}`), false, 0, 0},
+		{"other keys", "chat", types.StringValue(`{"stop": ["\n"]}`), false, 0, 0},
+		{"different value warns", "chat", types.StringValue(`{"max_tokens": 1000}`), false, 1, 0},
+		{"different value errors when strict", "chat", types.StringValue(`{"max_tokens": 1000}`), true, 0, 1},
+		{"missing model skipped", "missing", types.StringValue(`{"max_tokens": 1000}`), true, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			completion := &processor.CompletionConfigModel{Parameters: tt.parameters}
+
+			diags := processor.CheckModelParameterConflicts(ctx, tt.modelID, completion, models, tt.strict)
+			if diags.WarningsCount() != tt.warnings || diags.ErrorsCount() != tt.errors {
+				t.Errorf("expected %d warnings and %d errors, got %v", tt.warnings, tt.errors, diags)
+			}
+		})
+	}
+
+	// A model updated in the same apply is read with its new parameters
+	updated := fake.NewModels(&sensory.Model{ID: "chat", Parameters: map[string]any{"max_tokens": float64(1000)}})
+
+	completion := &processor.CompletionConfigModel{Parameters: types.StringValue(`{"max_tokens": 1000}`)}
+	if diags := processor.CheckModelParameterConflicts(ctx, "chat", completion, updated, true); diags.HasError() {
+		t.Errorf("expected no conflict with the updated model, got %v", diags)
+	}
+}
+
+func TestCheckParameterCollisions_Reranking(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	attributes, blocks := processor.GetNeuralProcessorSchema()
+	resourceSchema := schema.Schema{Attributes: attributes, Blocks: blocks}
+	tfType := resourceSchema.Type().TerraformType(ctx)
+
+	tests := []struct {
+		name           string
+		reranking      processor.RerankingConfigModel
+		expectedDetail string
+	}{
+		{
+			name: "typed attributes only",
+			reranking: processor.RerankingConfigModel{
+				TopN:       types.Int64Value(3),
+				Threshold:  types.Float64Value(0.5),
+				Parameters: types.StringNull(),
+			},
+		},
+		{
+			name: "typed key in parameters without the attribute",
+			reranking: processor.RerankingConfigModel{
+				TopN:       types.Int64Null(),
+				Threshold:  types.Float64Null(),
+				Parameters: types.StringValue(`{"top_n": 3}`),
+			},
+		},
+		{
+			name: "top_n and threshold",
+			reranking: processor.RerankingConfigModel{
+				TopN:       types.Int64Value(3),
+				Threshold:  types.Float64Value(0.5),
+				Parameters: types.StringValue(`{"threshold": 0.2, "top_n": 5}`),
+			},
+			expectedDetail: `The following keys are set both as an attribute and inside parameters: "top_n", "threshold". Remove them from parameters and use the attributes instead.`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reranking := tt.reranking
+			data := processor.NeuralProcessorModel{
+				ProcessorModel: processor.ProcessorModel{
+					Id:      types.StringNull(),
+					ModelId: types.StringValue("model-1"),
+					Type:    types.StringNull(),
+				},
+				SpaceId:   types.StringValue("space-1"),
+				Reranking: &reranking,
+			}
+
+			state := tfsdk.State{Schema: resourceSchema, Raw: tftypes.NewValue(tfType, nil)}
+			if diags := state.Set(ctx, &data); diags.HasError() {
+				t.Fatalf("unable to build config: %v", diags)
+			}
+
+			diags := processor.CheckParameterCollisions(ctx, tfsdk.Config{Schema: resourceSchema, Raw: state.Raw})
+
+			if tt.expectedDetail == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected 1 error, got %v", diags)
+			}
+			if got := diags.Errors()[0].Summary(); got != "Conflicting Reranking Parameter" {
+				t.Errorf("unexpected summary %q", got)
+			}
+			if got := diags.Errors()[0].Detail(); got != tt.expectedDetail {
+				t.Errorf("expected detail %q, got %q", tt.expectedDetail, got)
+			}
+		})
+	}
+}
@@ -4,7 +4,11 @@
 package processor
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -59,8 +63,24 @@ func getCompletionAttributes(includeValidation bool) map[string]schema.Attribute
 			Optional:            true,
 			Computed:            true,
 		},
+		"tool_choice_function": schema.StringAttribute{
+			MarkdownDescription: "Name of a tool the model is forced to call. Conflicts with `tool_choice`",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("tool_choice")),
+			},
+		},
+		"reasoning_effort": schema.StringAttribute{
+			MarkdownDescription: "Reasoning effort for reasoning models: low, medium, or high",
+			Optional:            true,
+			Computed:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("low", "medium", "high"),
+			},
+		},
 		"role_mappings": schema.ListNestedAttribute{
-			MarkdownDescription: "Role mappings for conversation roles",
+			MarkdownDescription: "Role mappings for conversation roles. Order is not significant",
 			Optional:            true,
 			NestedObject: schema.NestedAttributeObject{
 				Attributes: map[string]schema.Attribute{
@@ -76,7 +96,7 @@ func getCompletionAttributes(includeValidation bool) map[string]schema.Attribute
 			},
 		},
 		"parameters": schema.StringAttribute{
-			MarkdownDescription: "Additional parameters as JSON string",
+			MarkdownDescription: "Additional parameters as JSON string. Keys configured through a typed attribute (temperature, tool_choice, reasoning_effort, role_mappings) cannot also be set here. Values set here take precedence over the same keys in the model's parameters. When the processor is created or updated, keys the model's parameters set to a different value are reported",
 			Optional:            true,
 			Computed:            true,
 			PlanModifiers: []planmodifier.String{
@@ -106,7 +126,7 @@ func getCompletionAttributes(includeValidation bool) map[string]schema.Attribute
 
 		if paramsAttr, ok := attributes["parameters"]; ok {
 			if stringAttr, ok := paramsAttr.(schema.StringAttribute); ok {
-				stringAttr.MarkdownDescription = "Additional parameters as JSON string (e.g., '{\"max_tokens\": 1000, \"stop\": [\"\\n\"]}')"
+				stringAttr.MarkdownDescription = "Additional parameters as JSON string (e.g., '{\"max_tokens\": 1000, \"stop\": [\"\\n\"]}'). Keys configured through a typed attribute (temperature, tool_choice, reasoning_effort, role_mappings) cannot also be set here. Values set here take precedence over the same keys in the model's parameters. When the processor is created or updated, keys the model's parameters set to a different value are reported"
 				attributes["parameters"] = stringAttr
 			}
 		}
@@ -123,8 +143,12 @@ func getEmbeddingAttributes() map[string]schema.Attribute {
 			Computed:            true,
 		},
 		"templates": schema.ListNestedAttribute{
-			MarkdownDescription: "Templates for embedding processing",
+			MarkdownDescription: "Templates for embedding processing. Each template type may only be declared once.",
 			Optional:            true,
+			Validators: []validator.List{
+				UniqueTemplateTypes(),
+				listvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("templates_from_class_id")),
+			},
 			NestedObject: schema.NestedAttributeObject{
 				Attributes: map[string]schema.Attribute{
 					"type": schema.StringAttribute{
@@ -138,13 +162,34 @@ func getEmbeddingAttributes() map[string]schema.Attribute {
 				},
 			},
 		},
+		"templates_from_class_id": schema.StringAttribute{
+			MarkdownDescription: "ID of a class whose property names are used to generate a `document` template, one `name: {name}` line per property. The class is read on every create and update; the generated templates are sent to the API but not stored in `templates`. Changing the properties of the class does not refresh the processor: its templates are regenerated the next time the processor is updated. Conflicts with `templates`",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
 	}
 }
 
 func getRerankingAttributes() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
+		"top_n": schema.Int64Attribute{
+			MarkdownDescription: "Number of top ranked documents to return",
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"threshold": schema.Float64Attribute{
+			MarkdownDescription: "Minimum relevance score, between 0 and 1, for a document to be returned",
+			Optional:            true,
+			Validators: []validator.Float64{
+				float64validator.Between(0, 1),
+			},
+		},
 		"parameters": schema.StringAttribute{
-			MarkdownDescription: "Additional parameters as JSON string",
+			MarkdownDescription: "Additional parameters as JSON string. Keys configured through a typed attribute (top_n, threshold) cannot also be set here",
 			Optional:            true,
 			Computed:            true,
 			PlanModifiers: []planmodifier.String{
@@ -168,9 +213,20 @@ func GetBaseAttributes() map[string]schema.Attribute {
 			MarkdownDescription: "ID of the model this processor uses",
 			Required:            true,
 		},
+		"effective_config": schema.StringAttribute{
+			MarkdownDescription: "Complete configuration of the processor as returned by the API, server defaults included, as normalized JSON. Read-only",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
 		"type": schema.StringAttribute{
-			MarkdownDescription: "Type of processor (e.g., 'completion', 'embedding', 'reranking')",
+			MarkdownDescription: "Type of processor: completion, embedding or reranking. Detected from the configured block when omitted; when set, it must match the configured block",
+			Optional:            true,
 			Computed:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("completion", "embedding", "reranking"),
+			},
 		},
 	}
 }
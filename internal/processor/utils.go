@@ -4,11 +4,16 @@
 package processor
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	jsonplanmodifier "github.com/upmaru/terraform-provider-tama/internal/planmodifier"
 )
 
 // ProcessorConfig represents a generic processor configuration interface.
@@ -126,6 +131,18 @@ func buildCompletionConfig(completion *CompletionConfigModel) (map[string]any, e
 		config["tool_choice"] = completion.ToolChoice.ValueString()
 	}
 
+	// A forced tool is sent as a tool_choice object naming the function
+	if !completion.ToolChoiceFunction.IsNull() && !completion.ToolChoiceFunction.IsUnknown() {
+		config["tool_choice"] = map[string]any{
+			"type":     "function",
+			"function": map[string]any{"name": completion.ToolChoiceFunction.ValueString()},
+		}
+	}
+
+	if !completion.ReasoningEffort.IsNull() && !completion.ReasoningEffort.IsUnknown() {
+		config["reasoning_effort"] = completion.ReasoningEffort.ValueString()
+	}
+
 	if len(completion.RoleMappings) > 0 {
 		var roleMappings []map[string]any
 		for _, mapping := range completion.RoleMappings {
@@ -182,18 +199,29 @@ func buildRerankingConfig(reranking *RerankingConfigModel) (map[string]any, erro
 	config := map[string]any{}
 
 	// Parse parameters if provided
+	parametersMap := map[string]any{}
 	if !reranking.Parameters.IsNull() && !reranking.Parameters.IsUnknown() && reranking.Parameters.ValueString() != "" {
-		var parametersMap map[string]any
 		if err := json.Unmarshal([]byte(reranking.Parameters.ValueString()), &parametersMap); err != nil {
 			return nil, fmt.Errorf("unable to parse parameters as JSON: %s", err)
 		}
+	}
+
+	// Typed attributes are sent inside parameters
+	if !reranking.TopN.IsNull() && !reranking.TopN.IsUnknown() {
+		parametersMap["top_n"] = reranking.TopN.ValueInt64()
+	}
+	if !reranking.Threshold.IsNull() && !reranking.Threshold.IsUnknown() {
+		parametersMap["threshold"] = reranking.Threshold.ValueFloat64()
+	}
+
+	if len(parametersMap) > 0 {
 		config["parameters"] = parametersMap
 	}
 
 	return config, nil
 }
 
-func updateCompletionFromResponse(processorConfig map[string]any, config ProcessorConfig) {
+func updateCompletionFromResponse(processorConfig map[string]any, config ProcessorConfig, refresh bool) {
 	// Get existing config or create new one
 	var completionConfig CompletionConfigModel
 	if existingCompletion := config.GetCompletion(); existingCompletion != nil {
@@ -213,10 +241,23 @@ func updateCompletionFromResponse(processorConfig map[string]any, config Process
 	if toolChoice, ok := processorConfig["tool_choice"]; ok {
 		if val, ok := toolChoice.(string); ok {
 			completionConfig.ToolChoice = types.StringValue(val)
+			completionConfig.ToolChoiceFunction = types.StringNull()
+		} else if name, ok := toolChoiceFunction(toolChoice); ok {
+			completionConfig.ToolChoice = types.StringNull()
+			completionConfig.ToolChoiceFunction = types.StringValue(name)
 		}
 	}
 
-	if roleMappings, ok := processorConfig["role_mappings"]; ok {
+	// Reasoning effort is only returned when it has been set
+	if reasoningEffort, ok := processorConfig["reasoning_effort"].(string); ok && reasoningEffort != "" {
+		completionConfig.ReasoningEffort = types.StringValue(reasoningEffort)
+	} else if completionConfig.ReasoningEffort.IsUnknown() {
+		completionConfig.ReasoningEffort = types.StringNull()
+	}
+
+	// Role mappings are not computed, the planned mappings are kept after a
+	// write and only replaced on refresh
+	if roleMappings, ok := processorConfig["role_mappings"]; ok && refresh {
 		if mappings, ok := roleMappings.([]any); ok && len(mappings) > 0 {
 			var roleMappingModels []RoleMappingModel
 			for _, mapping := range mappings {
@@ -231,31 +272,110 @@ func updateCompletionFromResponse(processorConfig map[string]any, config Process
 					}
 				}
 			}
-			completionConfig.RoleMappings = roleMappingModels
-		}
-	}
-
-	// Handle parameters from response
-	if parameters, ok := processorConfig["parameters"]; ok {
-		if paramMap, ok := parameters.(map[string]any); ok && len(paramMap) > 0 {
-			parametersJSON, err := json.Marshal(paramMap)
-			if err == nil {
-				// Only update if user didn't provide parameters or if the values are different
-				if completionConfig.Parameters.IsNull() || completionConfig.Parameters.IsUnknown() {
-					completionConfig.Parameters = types.StringValue(string(parametersJSON))
-				}
+			// Role mappings are order-insensitive, keep the declared order when the
+			// server returns the same mappings in a different order
+			if !sameRoleMappings(completionConfig.RoleMappings, roleMappingModels) {
+				completionConfig.RoleMappings = roleMappingModels
 			}
-		} else if completionConfig.Parameters.IsNull() || completionConfig.Parameters.IsUnknown() {
-			completionConfig.Parameters = types.StringValue("")
+		} else if len(completionConfig.RoleMappings) > 0 {
+			// Mappings removed outside of Terraform
+			completionConfig.RoleMappings = nil
 		}
-	} else if completionConfig.Parameters.IsNull() || completionConfig.Parameters.IsUnknown() {
-		completionConfig.Parameters = types.StringValue("")
+	} else if refresh && len(completionConfig.RoleMappings) > 0 {
+		completionConfig.RoleMappings = nil
 	}
 
+	completionConfig.Parameters = parametersFromResponse(completionConfig.Parameters, processorConfig["parameters"], refresh)
+
 	// Update the config - this needs to be handled by the specific model type
 	updateCompletionInConfig(config, &completionConfig)
 }
 
+// toolChoiceFunction returns the name of the function forced by a
+// tool_choice object, e.g. {"type": "function", "function": {"name": "x"}}.
+func toolChoiceFunction(toolChoice any) (string, bool) {
+	object, ok := toolChoice.(map[string]any)
+	if !ok {
+		return "", false
+	}
+
+	function, ok := object["function"].(map[string]any)
+	if !ok {
+		return "", false
+	}
+
+	name, ok := function["name"].(string)
+	return name, ok && name != ""
+}
+
+// parametersFromResponse returns the parameters to store for the server
+// value. A known current value is kept after a write. On refresh it is kept
+// while it is semantically equal to the server value, so formatting
+// differences are not reported, and replaced otherwise so changes made
+// outside of Terraform show up as drift.
+func parametersFromResponse(current types.String, parameters any, refresh bool) types.String {
+	serverJSON := ""
+	if paramMap, ok := parameters.(map[string]any); ok && len(paramMap) > 0 {
+		parametersJSON, err := json.Marshal(paramMap)
+		if err != nil {
+			return current
+		}
+		serverJSON = string(parametersJSON)
+	}
+
+	if current.IsNull() || current.IsUnknown() {
+		return types.StringValue(serverJSON)
+	}
+
+	if !refresh || sameParameters(current.ValueString(), serverJSON) {
+		return current
+	}
+
+	return types.StringValue(serverJSON)
+}
+
+// sameParameters reports whether two parameters JSON strings are
+// semantically equal, treating an empty string and an empty object alike.
+func sameParameters(a, b string) bool {
+	normalizedA, errA := jsonplanmodifier.NormalizeJSON(a)
+	normalizedB, errB := jsonplanmodifier.NormalizeJSON(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	if normalizedA == "{}" {
+		normalizedA = ""
+	}
+	if normalizedB == "{}" {
+		normalizedB = ""
+	}
+
+	return normalizedA == normalizedB
+}
+
+// sameRoleMappings reports whether a and b contain the same mappings,
+// regardless of order.
+func sameRoleMappings(a, b []RoleMappingModel) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[[2]string]int, len(a))
+	for _, mapping := range a {
+		counts[[2]string{mapping.From.ValueString(), mapping.To.ValueString()}]++
+	}
+
+	for _, mapping := range b {
+		key := [2]string{mapping.From.ValueString(), mapping.To.ValueString()}
+		if counts[key] == 0 {
+			return false
+		}
+		counts[key]--
+	}
+
+	return true
+}
+
 func updateEmbeddingFromResponse(processorConfig map[string]any, config ProcessorConfig) {
 	// Get existing config or create new one
 	var embeddingConfig EmbeddingConfigModel
@@ -273,7 +393,10 @@ func updateEmbeddingFromResponse(processorConfig map[string]any, config Processo
 		}
 	}
 
-	if templates, ok := processorConfig["templates"]; ok {
+	// Templates generated from a class are not part of the configuration
+	if !embeddingConfig.TemplatesFromClassId.IsNull() {
+		embeddingConfig.Templates = nil
+	} else if templates, ok := processorConfig["templates"]; ok {
 		if tmplList, ok := templates.([]any); ok && len(tmplList) > 0 {
 			var templateModels []TemplateModel
 			for _, template := range tmplList {
@@ -295,29 +418,41 @@ func updateEmbeddingFromResponse(processorConfig map[string]any, config Processo
 	updateEmbeddingInConfig(config, &embeddingConfig)
 }
 
-func updateRerankingFromResponse(processorConfig map[string]any, config ProcessorConfig) {
+func updateRerankingFromResponse(processorConfig map[string]any, config ProcessorConfig, refresh bool) {
 	// Get existing config or create new one
 	var rerankingConfig RerankingConfigModel
 	if existingReranking := config.GetReranking(); existingReranking != nil {
 		rerankingConfig = *existingReranking
 	}
 
-	// Handle parameters from response
-	if parameters, ok := processorConfig["parameters"]; ok {
-		if paramMap, ok := parameters.(map[string]any); ok && len(paramMap) > 0 {
-			parametersJSON, err := json.Marshal(paramMap)
-			if err == nil {
-				if rerankingConfig.Parameters.IsNull() || rerankingConfig.Parameters.IsUnknown() {
-					rerankingConfig.Parameters = types.StringValue(string(parametersJSON))
-				}
+	// Map typed keys back to their attributes when they are configured,
+	// leaving parameters as the server returned it otherwise
+	parameters := processorConfig["parameters"]
+	if paramMap, ok := parameters.(map[string]any); ok && (!rerankingConfig.TopN.IsNull() || !rerankingConfig.Threshold.IsNull()) {
+		remaining := make(map[string]any, len(paramMap))
+		for key, value := range paramMap {
+			remaining[key] = value
+		}
+
+		if !rerankingConfig.TopN.IsNull() {
+			if topN, ok := remaining["top_n"].(float64); ok {
+				rerankingConfig.TopN = types.Int64Value(int64(topN))
 			}
-		} else if rerankingConfig.Parameters.IsNull() || rerankingConfig.Parameters.IsUnknown() {
-			rerankingConfig.Parameters = types.StringValue("")
+			delete(remaining, "top_n")
 		}
-	} else if rerankingConfig.Parameters.IsNull() || rerankingConfig.Parameters.IsUnknown() {
-		rerankingConfig.Parameters = types.StringValue("")
+
+		if !rerankingConfig.Threshold.IsNull() {
+			if threshold, ok := remaining["threshold"].(float64); ok {
+				rerankingConfig.Threshold = types.Float64Value(threshold)
+			}
+			delete(remaining, "threshold")
+		}
+
+		parameters = remaining
 	}
 
+	rerankingConfig.Parameters = parametersFromResponse(rerankingConfig.Parameters, parameters, refresh)
+
 	updateRerankingInConfig(config, &rerankingConfig)
 }
 
@@ -417,8 +552,85 @@ func BuildConfiguration(config ProcessorConfig) map[string]any {
 	return configMap
 }
 
-// UpdateConfigurationFromResponse updates config from API response - simplified signature.
+// EffectiveConfig returns the configuration returned by the API, server
+// defaults included, as normalized JSON for the effective_config attribute.
+func EffectiveConfig(processorConfig map[string]any) types.String {
+	if processorConfig == nil {
+		processorConfig = map[string]any{}
+	}
+
+	raw, err := json.Marshal(processorConfig)
+	if err != nil {
+		return types.StringNull()
+	}
+
+	normalized, err := jsonplanmodifier.NormalizeJSON(string(raw))
+	if err != nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(normalized)
+}
+
+// KeepConfiguredParameters plans the completion and reranking parameters
+// exactly as configured, for providers that disable JSON normalization.
+func KeepConfiguredParameters(ctx context.Context, config tfsdk.Config, plan *tfsdk.Plan) diag.Diagnostics {
+	return jsonplanmodifier.KeepConfiguredJSON(ctx, config, plan,
+		path.MatchRoot("completion").AtName("parameters"),
+		path.MatchRoot("reranking").AtName("parameters"),
+	)
+}
+
+// PlanEffectiveConfig marks effective_config unknown in the plan when
+// model_id or a configuration block changes, since the API then returns a
+// new configuration. Otherwise the value kept from state is planned.
+func PlanEffectiveConfig(ctx context.Context, state tfsdk.State, plan *tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Nothing to keep on create or destroy
+	if state.Raw.IsNull() || plan.Raw.IsNull() {
+		return diags
+	}
+
+	var planModelID, stateModelID types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("model_id"), &planModelID)...)
+	diags.Append(state.GetAttribute(ctx, path.Root("model_id"), &stateModelID)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	changed := !planModelID.Equal(stateModelID)
+	for _, block := range []string{"completion", "embedding", "reranking"} {
+		var planBlock, stateBlock types.Object
+		diags.Append(plan.GetAttribute(ctx, path.Root(block), &planBlock)...)
+		diags.Append(state.GetAttribute(ctx, path.Root(block), &stateBlock)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		changed = changed || !planBlock.Equal(stateBlock)
+	}
+
+	if changed {
+		diags.Append(plan.SetAttribute(ctx, path.Root("effective_config"), types.StringUnknown())...)
+	}
+
+	return diags
+}
+
+// UpdateConfigurationFromResponse updates config from API response after a
+// create or update. Planned parameters and role mappings are kept.
 func UpdateConfigurationFromResponse(processorConfig map[string]any, config ProcessorConfig) {
+	updateConfigurationFromResponse(processorConfig, config, false)
+}
+
+// RefreshConfigurationFromResponse updates config from API response on read,
+// replacing parameters and role mappings changed outside of Terraform.
+func RefreshConfigurationFromResponse(processorConfig map[string]any, config ProcessorConfig) {
+	updateConfigurationFromResponse(processorConfig, config, true)
+}
+
+func updateConfigurationFromResponse(processorConfig map[string]any, config ProcessorConfig, refresh bool) {
 	processorType := DetermineProcessorType(config)
 	if processorType == "" {
 		// During import, the config might not have blocks set yet,
@@ -427,6 +639,8 @@ func UpdateConfigurationFromResponse(processorConfig map[string]any, config Proc
 			processorType = "completion"
 		} else if _, hasToolChoice := processorConfig["tool_choice"]; hasToolChoice {
 			processorType = "completion"
+		} else if _, hasReasoningEffort := processorConfig["reasoning_effort"]; hasReasoningEffort {
+			processorType = "completion"
 		} else if _, hasRoleMappings := processorConfig["role_mappings"]; hasRoleMappings {
 			processorType = "completion"
 		} else if _, hasMaxTokens := processorConfig["max_tokens"]; hasMaxTokens {
@@ -440,22 +654,23 @@ func UpdateConfigurationFromResponse(processorConfig map[string]any, config Proc
 
 	switch processorType {
 	case "completion":
-		updateCompletionFromResponse(processorConfig, config)
+		updateCompletionFromResponse(processorConfig, config, refresh)
 	case "embedding":
 		updateEmbeddingFromResponse(processorConfig, config)
 	case "reranking":
-		updateRerankingFromResponse(processorConfig, config)
+		updateRerankingFromResponse(processorConfig, config, refresh)
 	}
 }
 
 // UpdateConfigurationFromResponseWithType updates config from API response with explicit processor type.
+// It is used on import, where every value comes from the server.
 func UpdateConfigurationFromResponseWithType(processorConfig map[string]any, config ProcessorConfig, processorType string) {
 	switch processorType {
 	case "completion":
-		updateCompletionFromResponse(processorConfig, config)
+		updateCompletionFromResponse(processorConfig, config, true)
 	case "embedding":
 		updateEmbeddingFromResponse(processorConfig, config)
 	case "reranking":
-		updateRerankingFromResponse(processorConfig, config)
+		updateRerankingFromResponse(processorConfig, config, true)
 	}
 }
@@ -0,0 +1,437 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package processor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/upmaru/terraform-provider-tama/internal/processor"
+)
+
+func roleMappings(pairs ...[2]string) []processor.RoleMappingModel {
+	mappings := make([]processor.RoleMappingModel, len(pairs))
+	for i, pair := range pairs {
+		mappings[i] = processor.RoleMappingModel{
+			From: types.StringValue(pair[0]),
+			To:   types.StringValue(pair[1]),
+		}
+	}
+	return mappings
+}
+
+func TestRefreshConfigurationFromResponse_RoleMappingsOrder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		declared []processor.RoleMappingModel
+		response []any
+		expected []processor.RoleMappingModel
+	}{
+		{
+			name:     "server returns mappings reordered",
+			declared: roleMappings([2]string{"user", "human"}, [2]string{"assistant", "ai"}),
+			response: []any{
+				map[string]any{"from": "assistant", "to": "ai"},
+				map[string]any{"from": "user", "to": "human"},
+			},
+			expected: roleMappings([2]string{"user", "human"}, [2]string{"assistant", "ai"}),
+		},
+		{
+			name:     "server returns different mappings",
+			declared: roleMappings([2]string{"user", "human"}, [2]string{"assistant", "ai"}),
+			response: []any{
+				map[string]any{"from": "assistant", "to": "bot"},
+				map[string]any{"from": "user", "to": "human"},
+			},
+			expected: roleMappings([2]string{"assistant", "bot"}, [2]string{"user", "human"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			data := &processor.NeuralProcessorModel{
+				Completion: &processor.CompletionConfigModel{
+					Temperature:  types.Float64Value(0.8),
+					ToolChoice:   types.StringValue("required"),
+					RoleMappings: tt.declared,
+					Parameters:   types.StringNull(),
+				},
+			}
+
+			processor.RefreshConfigurationFromResponse(map[string]any{
+				"temperature":   0.8,
+				"tool_choice":   "required",
+				"role_mappings": tt.response,
+			}, data)
+
+			got := data.Completion.RoleMappings
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %d role mappings, got %d", len(tt.expected), len(got))
+			}
+
+			for i := range got {
+				if !got[i].From.Equal(tt.expected[i].From) || !got[i].To.Equal(tt.expected[i].To) {
+					t.Errorf("role mapping %d: expected %s -> %s, got %s -> %s", i,
+						tt.expected[i].From.ValueString(), tt.expected[i].To.ValueString(),
+						got[i].From.ValueString(), got[i].To.ValueString())
+				}
+			}
+		})
+	}
+}
+
+func TestReasoningEffort(t *testing.T) {
+	t.Parallel()
+
+	data := &processor.NeuralProcessorModel{
+		Completion: &processor.CompletionConfigModel{
+			Temperature:     types.Float64Value(0.8),
+			ToolChoice:      types.StringValue("required"),
+			ReasoningEffort: types.StringValue("medium"),
+			Parameters:      types.StringNull(),
+		},
+	}
+
+	config := processor.BuildConfiguration(data)
+	if config["reasoning_effort"] != "medium" {
+		t.Fatalf("expected reasoning_effort to be sent as medium, got %v", config["reasoning_effort"])
+	}
+
+	processor.UpdateConfigurationFromResponse(map[string]any{
+		"temperature":      0.8,
+		"tool_choice":      "required",
+		"reasoning_effort": "high",
+	}, data)
+
+	if got := data.Completion.ReasoningEffort; !got.Equal(types.StringValue("high")) {
+		t.Errorf("expected reasoning_effort to be read back as high, got %s", got)
+	}
+
+	data.Completion.ReasoningEffort = types.StringUnknown()
+	processor.UpdateConfigurationFromResponse(map[string]any{
+		"temperature": 0.8,
+		"tool_choice": "required",
+	}, data)
+
+	if got := data.Completion.ReasoningEffort; !got.IsNull() {
+		t.Errorf("expected unset reasoning_effort to be null, got %s", got)
+	}
+}
+
+func TestToolChoiceFunction(t *testing.T) {
+	t.Parallel()
+
+	data := &processor.NeuralProcessorModel{
+		Completion: &processor.CompletionConfigModel{
+			Temperature:        types.Float64Value(0.8),
+			ToolChoice:         types.StringUnknown(),
+			ToolChoiceFunction: types.StringValue("search"),
+			Parameters:         types.StringNull(),
+		},
+	}
+
+	config := processor.BuildConfiguration(data)
+	toolChoice, ok := config["tool_choice"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected tool_choice to be sent as an object, got %#v", config["tool_choice"])
+	}
+	if toolChoice["type"] != "function" || toolChoice["function"].(map[string]any)["name"] != "search" {
+		t.Errorf("unexpected tool_choice sent: %#v", toolChoice)
+	}
+
+	processor.UpdateConfigurationFromResponse(map[string]any{
+		"temperature": 0.8,
+		"tool_choice": map[string]any{"type": "function", "function": map[string]any{"name": "search"}},
+	}, data)
+
+	if got := data.Completion.ToolChoiceFunction; !got.Equal(types.StringValue("search")) {
+		t.Errorf("expected tool_choice_function to be read back as search, got %s", got)
+	}
+	if got := data.Completion.ToolChoice; !got.IsNull() {
+		t.Errorf("expected tool_choice to be null while a function is forced, got %s", got)
+	}
+
+	// Switched back to a strategy outside of Terraform
+	processor.UpdateConfigurationFromResponse(map[string]any{
+		"temperature": 0.8,
+		"tool_choice": "required",
+	}, data)
+
+	if got := data.Completion.ToolChoice; !got.Equal(types.StringValue("required")) {
+		t.Errorf("expected tool_choice to be read back as required, got %s", got)
+	}
+	if got := data.Completion.ToolChoiceFunction; !got.IsNull() {
+		t.Errorf("expected tool_choice_function to be null, got %s", got)
+	}
+}
+
+func TestRefreshConfigurationFromResponse_Drift(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		parameters         types.String
+		response           map[string]any
+		expectedParameters string
+		expectedMappings   int
+	}{
+		{
+			name:               "parameters formatted differently",
+			parameters:         types.StringValue(`{ "max_tokens": 100 }`),
+			response:           map[string]any{"parameters": map[string]any{"max_tokens": 100}},
+			expectedParameters: `{ "max_tokens": 100 }`,
+			expectedMappings:   1,
+		},
+		{
+			name:               "parameters changed outside of Terraform",
+			parameters:         types.StringValue(`{"max_tokens": 100}`),
+			response:           map[string]any{"parameters": map[string]any{"max_tokens": 512}},
+			expectedParameters: `{"max_tokens":512}`,
+			expectedMappings:   1,
+		},
+		{
+			name:               "parameters removed outside of Terraform",
+			parameters:         types.StringValue(`{"max_tokens": 100}`),
+			response:           map[string]any{},
+			expectedParameters: "",
+			expectedMappings:   1,
+		},
+		{
+			name:               "empty parameters",
+			parameters:         types.StringValue("{}"),
+			response:           map[string]any{},
+			expectedParameters: "{}",
+			expectedMappings:   1,
+		},
+		{
+			name:               "parameters added outside of Terraform",
+			parameters:         types.StringValue(""),
+			response:           map[string]any{"parameters": map[string]any{"top_p": 0.5}},
+			expectedParameters: `{"top_p":0.5}`,
+			expectedMappings:   1,
+		},
+		{
+			name:               "role mappings removed outside of Terraform",
+			parameters:         types.StringValue(""),
+			response:           map[string]any{"role_mappings": []any{}},
+			expectedParameters: "",
+			expectedMappings:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			data := &processor.NeuralProcessorModel{
+				Completion: &processor.CompletionConfigModel{
+					Temperature:  types.Float64Value(0.8),
+					ToolChoice:   types.StringValue("required"),
+					RoleMappings: roleMappings([2]string{"user", "human"}),
+					Parameters:   tt.parameters,
+				},
+			}
+
+			response := map[string]any{
+				"temperature": 0.2,
+				"tool_choice": "auto",
+				"role_mappings": []any{
+					map[string]any{"from": "user", "to": "human"},
+				},
+			}
+			for key, value := range tt.response {
+				response[key] = value
+			}
+
+			processor.RefreshConfigurationFromResponse(response, data)
+
+			if got := data.Completion.Temperature; !got.Equal(types.Float64Value(0.2)) {
+				t.Errorf("expected temperature 0.2, got %s", got)
+			}
+			if got := data.Completion.ToolChoice; !got.Equal(types.StringValue("auto")) {
+				t.Errorf("expected tool_choice auto, got %s", got)
+			}
+			if got := data.Completion.Parameters.ValueString(); got != tt.expectedParameters {
+				t.Errorf("expected parameters %q, got %q", tt.expectedParameters, got)
+			}
+			if got := len(data.Completion.RoleMappings); got != tt.expectedMappings {
+				t.Errorf("expected %d role mappings, got %d", tt.expectedMappings, got)
+			}
+		})
+	}
+}
+
+func TestUpdateConfigurationFromResponse_KeepsPlanned(t *testing.T) {
+	t.Parallel()
+
+	data := &processor.NeuralProcessorModel{
+		Completion: &processor.CompletionConfigModel{
+			Temperature:  types.Float64Value(0.8),
+			ToolChoice:   types.StringValue("required"),
+			RoleMappings: roleMappings([2]string{"user", "human"}),
+			Parameters:   types.StringValue(`{"max_tokens": 100}`),
+		},
+	}
+
+	// The server adds a default and leaves out role_mappings
+	processor.UpdateConfigurationFromResponse(map[string]any{
+		"temperature": 0.8,
+		"tool_choice": "required",
+		"parameters":  map[string]any{"max_tokens": 100, "top_p": 1},
+	}, data)
+
+	if got := data.Completion.Parameters; !got.Equal(types.StringValue(`{"max_tokens": 100}`)) {
+		t.Errorf("expected the planned parameters to be kept, got %s", got)
+	}
+	if got := len(data.Completion.RoleMappings); got != 1 {
+		t.Errorf("expected the planned role mappings to be kept, got %d", got)
+	}
+
+	// Unknown parameters are taken from the server
+	data.Completion.Parameters = types.StringUnknown()
+	processor.UpdateConfigurationFromResponse(map[string]any{
+		"parameters": map[string]any{"top_p": 1},
+	}, data)
+
+	if got := data.Completion.Parameters; !got.Equal(types.StringValue(`{"top_p":1}`)) {
+		t.Errorf("expected server parameters for an unknown value, got %s", got)
+	}
+}
+
+func TestRerankingTypedAttributes(t *testing.T) {
+	t.Parallel()
+
+	data := &processor.NeuralProcessorModel{
+		Reranking: &processor.RerankingConfigModel{
+			TopN:       types.Int64Value(3),
+			Threshold:  types.Float64Value(0.5),
+			Parameters: types.StringValue(`{"return_documents": true}`),
+		},
+	}
+
+	config := processor.BuildConfiguration(data)
+	parameters, ok := config["parameters"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected parameters to be sent, got %v", config["parameters"])
+	}
+	if parameters["top_n"] != int64(3) || parameters["threshold"] != 0.5 || parameters["return_documents"] != true {
+		t.Fatalf("expected typed attributes merged into parameters, got %v", parameters)
+	}
+
+	processor.UpdateConfigurationFromResponse(map[string]any{
+		"parameters": map[string]any{"top_n": 5.0, "threshold": 0.25, "return_documents": true},
+	}, data)
+
+	if got := data.Reranking.TopN; !got.Equal(types.Int64Value(5)) {
+		t.Errorf("expected top_n to be read back as 5, got %s", got)
+	}
+	if got := data.Reranking.Threshold; !got.Equal(types.Float64Value(0.25)) {
+		t.Errorf("expected threshold to be read back as 0.25, got %s", got)
+	}
+	if got := data.Reranking.Parameters; !got.Equal(types.StringValue(`{"return_documents": true}`)) {
+		t.Errorf("expected parameters to keep only untyped keys, got %s", got)
+	}
+
+	// Without typed attributes the server parameters are kept as they are
+	data.Reranking = &processor.RerankingConfigModel{Parameters: types.StringNull()}
+	processor.UpdateConfigurationFromResponse(map[string]any{
+		"parameters": map[string]any{"top_n": 5.0},
+	}, data)
+
+	if got := data.Reranking.TopN; !got.IsNull() {
+		t.Errorf("expected unset top_n to stay null, got %s", got)
+	}
+	if got := data.Reranking.Parameters; !got.Equal(types.StringValue(`{"top_n":5}`)) {
+		t.Errorf("expected parameters to keep top_n, got %s", got)
+	}
+}
+
+func TestEffectiveConfig(t *testing.T) {
+	t.Parallel()
+
+	effective := processor.EffectiveConfig(map[string]any{
+		"tool_choice": "required",
+		"temperature": 0.8,
+		"parameters":  map[string]any{"stop": []any{"\n"}, "max_tokens": float64(1000)},
+	})
+
+	if want := `{"parameters":{"max_tokens":1000,"stop":["\n"]},"temperature":0.8,"tool_choice":"required"}`; effective.ValueString() != want {
+		t.Errorf("expected %s, got %s", want, effective.ValueString())
+	}
+
+	if effective := processor.EffectiveConfig(nil); effective.ValueString() != "{}" {
+		t.Errorf("expected an empty object, got %s", effective)
+	}
+}
+
+func TestPlanEffectiveConfig(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	attributes, blocks := processor.GetNeuralProcessorSchema()
+	resourceSchema := schema.Schema{Attributes: attributes, Blocks: blocks}
+	tfType := resourceSchema.Type().TerraformType(ctx)
+
+	processorData := func(modelID string, temperature float64) processor.NeuralProcessorModel {
+		return processor.NeuralProcessorModel{
+			ProcessorModel: processor.ProcessorModel{
+				Id:              types.StringValue("processor-1"),
+				ModelId:         types.StringValue(modelID),
+				Type:            types.StringValue("completion"),
+				EffectiveConfig: types.StringValue(`{"temperature":0.5}`),
+			},
+			SpaceId: types.StringValue("space-1"),
+			Completion: &processor.CompletionConfigModel{
+				Temperature: types.Float64Value(temperature),
+				Parameters:  types.StringNull(),
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		planned processor.NeuralProcessorModel
+		unknown bool
+	}{
+		{"unchanged keeps state", processorData("model-1", 0.5), false},
+		{"model changed", processorData("model-2", 0.5), true},
+		{"configuration changed", processorData("model-1", 0.9), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			state := tfsdk.State{Schema: resourceSchema, Raw: tftypes.NewValue(tfType, nil)}
+			stateData := processorData("model-1", 0.5)
+			if diags := state.Set(ctx, &stateData); diags.HasError() {
+				t.Fatalf("unable to build state: %v", diags)
+			}
+
+			plan := tfsdk.Plan{Schema: resourceSchema, Raw: tftypes.NewValue(tfType, nil)}
+			if diags := plan.Set(ctx, &tt.planned); diags.HasError() {
+				t.Fatalf("unable to build plan: %v", diags)
+			}
+
+			if diags := processor.PlanEffectiveConfig(ctx, state, &plan); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			var effectiveConfig types.String
+			plan.GetAttribute(ctx, path.Root("effective_config"), &effectiveConfig)
+			if effectiveConfig.IsUnknown() != tt.unknown {
+				t.Errorf("expected effective_config unknown to be %t, got %s", tt.unknown, effectiveConfig)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package processor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ validator.List = uniqueTemplateTypesValidator{}
+var _ resource.ConfigValidator = typeMatchesBlockValidator{}
+
+// ConfigValidators returns the resource level validators shared by the space
+// and thought processor resources. Exactly one of the completion, embedding
+// or reranking blocks must be configured, which is reported at validate time.
+// An explicitly set type must name the configured block.
+func ConfigValidators() []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("completion"),
+			path.MatchRoot("embedding"),
+			path.MatchRoot("reranking"),
+		),
+		typeMatchesBlockValidator{},
+	}
+}
+
+// typeMatchesBlockValidator ensures a configured type names the configured
+// configuration block.
+type typeMatchesBlockValidator struct{}
+
+func (v typeMatchesBlockValidator) Description(ctx context.Context) string {
+	return "type must match the configured completion, embedding or reranking block"
+}
+
+func (v typeMatchesBlockValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v typeMatchesBlockValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var processorType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &processorType)...)
+	if resp.Diagnostics.HasError() || processorType.IsNull() || processorType.IsUnknown() {
+		return
+	}
+
+	var configured []string
+	for _, block := range []string{"completion", "embedding", "reranking"} {
+		var object types.Object
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(block), &object)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !object.IsNull() {
+			configured = append(configured, block)
+		}
+	}
+
+	// A missing or repeated block is reported by ExactlyOneOf
+	if len(configured) != 1 || configured[0] == processorType.ValueString() {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("type"),
+		"Processor Type Mismatch",
+		fmt.Sprintf("The type is set to %q but the %s block is configured. Configure the %s block instead, or set type to %q.",
+			processorType.ValueString(), configured[0], processorType.ValueString(), configured[0]),
+	)
+}
+
+// uniqueTemplateTypesValidator ensures embedding templates do not repeat a type.
+type uniqueTemplateTypesValidator struct{}
+
+// UniqueTemplateTypes returns a validator which ensures that every template in
+// the list has a distinct type.
+func UniqueTemplateTypes() validator.List {
+	return uniqueTemplateTypesValidator{}
+}
+
+func (v uniqueTemplateTypesValidator) Description(ctx context.Context) string {
+	return "template types must be unique"
+}
+
+func (v uniqueTemplateTypesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v uniqueTemplateTypesValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	seen := make(map[string]int)
+
+	for i, element := range req.ConfigValue.Elements() {
+		template, ok := element.(types.Object)
+		if !ok || template.IsNull() || template.IsUnknown() {
+			continue
+		}
+
+		templateType, ok := template.Attributes()["type"].(types.String)
+		if !ok || templateType.IsNull() || templateType.IsUnknown() {
+			continue
+		}
+
+		if first, exists := seen[templateType.ValueString()]; exists {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtListIndex(i).AtName("type"),
+				"Duplicate Template Type",
+				fmt.Sprintf("Template type %q is already used by the template at index %d. Each template type may only be declared once.", templateType.ValueString(), first),
+			)
+			continue
+		}
+
+		seen[templateType.ValueString()] = i
+	}
+}
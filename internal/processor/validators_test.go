@@ -0,0 +1,260 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package processor_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/upmaru/terraform-provider-tama/internal/processor"
+)
+
+var templateAttrTypes = map[string]attr.Type{
+	"type":    types.StringType,
+	"content": types.StringType,
+}
+
+func templateList(t *testing.T, templateTypes ...string) types.List {
+	t.Helper()
+
+	elements := make([]attr.Value, len(templateTypes))
+	for i, templateType := range templateTypes {
+		elements[i] = types.ObjectValueMust(templateAttrTypes, map[string]attr.Value{
+			"type":    types.StringValue(templateType),
+			"content": types.StringValue("content"),
+		})
+	}
+
+	return types.ListValueMust(types.ObjectType{AttrTypes: templateAttrTypes}, elements)
+}
+
+func TestUniqueTemplateTypes(t *testing.T) {
+	t.Parallel()
+
+	templatesPath := path.Root("embedding").AtName("templates")
+
+	tests := []struct {
+		name          string
+		value         types.List
+		expectedPaths []path.Path
+	}{
+		{
+			name:  "query and document",
+			value: templateList(t, "query", "document"),
+		},
+		{
+			name:          "duplicate query",
+			value:         templateList(t, "query", "query"),
+			expectedPaths: []path.Path{templatesPath.AtListIndex(1).AtName("type")},
+		},
+		{
+			name:          "duplicate after distinct",
+			value:         templateList(t, "query", "document", "query", "query"),
+			expectedPaths: []path.Path{templatesPath.AtListIndex(2).AtName("type"), templatesPath.AtListIndex(3).AtName("type")},
+		},
+		{
+			name:  "null list",
+			value: types.ListNull(types.ObjectType{AttrTypes: templateAttrTypes}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.ListRequest{
+				Path:        templatesPath,
+				ConfigValue: tt.value,
+			}
+			resp := &validator.ListResponse{}
+
+			processor.UniqueTemplateTypes().ValidateList(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.ErrorsCount(); got != len(tt.expectedPaths) {
+				t.Fatalf("expected %d errors, got %d: %v", len(tt.expectedPaths), got, resp.Diagnostics)
+			}
+
+			for i, diag := range resp.Diagnostics.Errors() {
+				withPath, ok := diag.(interface{ Path() path.Path })
+				if !ok {
+					t.Fatalf("expected attribute error, got %T", diag)
+				}
+
+				if !withPath.Path().Equal(tt.expectedPaths[i]) {
+					t.Errorf("expected error at %s, got %s", tt.expectedPaths[i], withPath.Path())
+				}
+			}
+		})
+	}
+}
+
+func TestConfigValidators_ExactlyOneConfiguration(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	attributes, blocks := processor.GetNeuralProcessorSchema()
+	resourceSchema := schema.Schema{Attributes: attributes, Blocks: blocks}
+	tfType := resourceSchema.Type().TerraformType(ctx)
+
+	completion := &processor.CompletionConfigModel{
+		Temperature: types.Float64Value(0.8),
+		ToolChoice:  types.StringValue("required"),
+		Parameters:  types.StringNull(),
+	}
+	embedding := &processor.EmbeddingConfigModel{
+		MaxTokens: types.Int64Value(512),
+	}
+
+	tests := []struct {
+		name        string
+		data        processor.NeuralProcessorModel
+		expectError bool
+	}{
+		{
+			name:        "no configuration",
+			data:        processor.NeuralProcessorModel{},
+			expectError: true,
+		},
+		{
+			name:        "multiple configurations",
+			data:        processor.NeuralProcessorModel{Completion: completion, Embedding: embedding},
+			expectError: true,
+		},
+		{
+			name: "single configuration",
+			data: processor.NeuralProcessorModel{Completion: completion},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.data.Id = types.StringNull()
+			tt.data.ModelId = types.StringValue("model-1")
+			tt.data.Type = types.StringNull()
+			tt.data.SpaceId = types.StringValue("space-1")
+
+			state := tfsdk.State{Schema: resourceSchema, Raw: tftypes.NewValue(tfType, nil)}
+			if diags := state.Set(ctx, &tt.data); diags.HasError() {
+				t.Fatalf("unable to build config: %v", diags)
+			}
+
+			req := resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: resourceSchema, Raw: state.Raw},
+			}
+			resp := &resource.ValidateConfigResponse{}
+
+			for _, configValidator := range processor.ConfigValidators() {
+				configValidator.ValidateResource(ctx, req, resp)
+			}
+
+			if !tt.expectError {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected an error")
+			}
+
+			if !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "Exactly one of these attributes must be configured") {
+				t.Errorf("unexpected error: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestConfigValidators_TypeMatchesBlock(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	attributes, blocks := processor.GetNeuralProcessorSchema()
+	resourceSchema := schema.Schema{Attributes: attributes, Blocks: blocks}
+	tfType := resourceSchema.Type().TerraformType(ctx)
+
+	completion := &processor.CompletionConfigModel{
+		Temperature: types.Float64Value(0.8),
+		ToolChoice:  types.StringValue("required"),
+		Parameters:  types.StringNull(),
+	}
+
+	tests := []struct {
+		name           string
+		processorType  types.String
+		expectedDetail string
+	}{
+		{
+			name:          "type omitted",
+			processorType: types.StringNull(),
+		},
+		{
+			name:          "type matches block",
+			processorType: types.StringValue("completion"),
+		},
+		{
+			name:           "type does not match block",
+			processorType:  types.StringValue("embedding"),
+			expectedDetail: `The type is set to "embedding" but the completion block is configured. Configure the embedding block instead, or set type to "completion".`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			data := processor.NeuralProcessorModel{
+				ProcessorModel: processor.ProcessorModel{
+					Id:      types.StringNull(),
+					ModelId: types.StringValue("model-1"),
+					Type:    tt.processorType,
+				},
+				SpaceId:    types.StringValue("space-1"),
+				Completion: completion,
+			}
+
+			state := tfsdk.State{Schema: resourceSchema, Raw: tftypes.NewValue(tfType, nil)}
+			if diags := state.Set(ctx, &data); diags.HasError() {
+				t.Fatalf("unable to build config: %v", diags)
+			}
+
+			req := resource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: resourceSchema, Raw: state.Raw},
+			}
+			resp := &resource.ValidateConfigResponse{}
+
+			for _, configValidator := range processor.ConfigValidators() {
+				configValidator.ValidateResource(ctx, req, resp)
+			}
+
+			if tt.expectedDetail == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if resp.Diagnostics.ErrorsCount() != 1 {
+				t.Fatalf("expected 1 error, got %v", resp.Diagnostics)
+			}
+			if got := resp.Diagnostics.Errors()[0].Summary(); got != "Processor Type Mismatch" {
+				t.Errorf("unexpected summary %q", got)
+			}
+			if got := resp.Diagnostics.Errors()[0].Detail(); got != tt.expectedDetail {
+				t.Errorf("expected detail %q, got %q", tt.expectedDetail, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package protection implements the deletion_protection attribute shared by
+// resources whose loss cannot be recovered from.
+package protection
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Attribute returns the deletion_protection schema attribute. The value only
+// lives in Terraform state, it is never sent to the API.
+func Attribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: "Whether Terraform is prevented from destroying the resource. " +
+			"While enabled, deleting or replacing the resource fails; set it to `false` and apply before destroying. Defaults to `false`.",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// CheckDelete returns an error diagnostic when deletion protection is
+// enabled for the resource about to be deleted.
+func CheckDelete(enabled types.Bool, resourceType, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !enabled.ValueBool() {
+		return diags
+	}
+
+	diags.AddError(
+		"Deletion Protection Enabled",
+		fmt.Sprintf("Cannot delete %s %s while deletion_protection is enabled. "+
+			"Set deletion_protection to false and apply before destroying or replacing it.", resourceType, id),
+	)
+
+	return diags
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package protection_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/upmaru/terraform-provider-tama/internal/protection"
+)
+
+func TestCheckDelete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		enabled     types.Bool
+		expectError bool
+	}{
+		{"enabled", types.BoolValue(true), true},
+		{"disabled", types.BoolValue(false), false},
+		{"null", types.BoolNull(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := protection.CheckDelete(tt.enabled, "tama_space", "space-1")
+			if diags.HasError() != tt.expectError {
+				t.Fatalf("expected error %t, got %v", tt.expectError, diags)
+			}
+
+			if tt.expectError {
+				expected := "Cannot delete tama_space space-1 while deletion_protection is enabled. " +
+					"Set deletion_protection to false and apply before destroying or replacing it."
+				if got := diags.Errors()[0].Detail(); got != expected {
+					t.Errorf("expected detail %q, got %q", expected, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package provision interprets the provision_state the API reports for
+// objects, shared by every resource exposing it.
+package provision
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Provision states of soft deleted objects. Objects in these states are kept
+// in state, with their provision_state, but are not updated.
+const (
+	StateArchived = "archived"
+	StateDeleted  = "deleted"
+)
+
+// IsTerminal reports whether state is the provision state of a soft deleted
+// object.
+func IsTerminal(state string) bool {
+	switch state {
+	case StateArchived, StateDeleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// CheckUpdatable reports an error when the prior state of a resource has a
+// terminal provision_state, so Update fails with a clear message instead of
+// sending a request for a soft deleted object.
+func CheckUpdatable(ctx context.Context, state tfsdk.State) diag.Diagnostics {
+	var provisionState types.String
+
+	diags := state.GetAttribute(ctx, path.Root("provision_state"), &provisionState)
+	if diags.HasError() || !IsTerminal(provisionState.ValueString()) {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		path.Root("provision_state"),
+		"Object Not Updatable",
+		fmt.Sprintf("The object has provision_state %q and can no longer be updated. "+
+			"Remove it from the configuration, or remove it from state with `terraform state rm` to create a new one.",
+			provisionState.ValueString()),
+	)
+
+	return diags
+}
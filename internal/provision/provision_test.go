@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provision_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/upmaru/terraform-provider-tama/internal/provision"
+)
+
+func TestIsTerminal(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"active":                false,
+		"pending":               false,
+		"":                      false,
+		provision.StateArchived: true,
+		provision.StateDeleted:  true,
+	}
+
+	for state, expected := range tests {
+		if got := provision.IsTerminal(state); got != expected {
+			t.Errorf("IsTerminal(%q): expected %t, got %t", state, expected, got)
+		}
+	}
+}
+
+func TestCheckUpdatable(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":              schema.StringAttribute{Computed: true},
+			"provision_state": schema.StringAttribute{Computed: true},
+		},
+	}
+	tfType := testSchema.Type().TerraformType(ctx)
+
+	newState := func(provisionState string) tfsdk.State {
+		return tfsdk.State{
+			Schema: testSchema,
+			Raw: tftypes.NewValue(tfType, map[string]tftypes.Value{
+				"id":              tftypes.NewValue(tftypes.String, "object-1"),
+				"provision_state": tftypes.NewValue(tftypes.String, provisionState),
+			}),
+		}
+	}
+
+	if diags := provision.CheckUpdatable(ctx, newState("active")); diags.HasError() {
+		t.Errorf("expected an active object to be updatable, got %v", diags)
+	}
+
+	diags := provision.CheckUpdatable(ctx, newState(provision.StateArchived))
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Object Not Updatable" {
+		t.Errorf("expected an archived object not to be updatable, got %v", diags)
+	}
+}
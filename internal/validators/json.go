@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = jsonObjectValidator{}
+
+// jsonObjectValidator ensures a string attribute holds a JSON object.
+type jsonObjectValidator struct{}
+
+// JSONObject returns a validator which ensures that a string attribute is a
+// valid JSON object, so malformed JSON is reported at plan time rather than
+// when the request is sent. Empty strings are allowed.
+func JSONObject() validator.String {
+	return jsonObjectValidator{}
+}
+
+func (v jsonObjectValidator) Description(ctx context.Context) string {
+	return "value must be a JSON object"
+}
+
+func (v jsonObjectValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v jsonObjectValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.ConfigValue.ValueString() == "" {
+		return
+	}
+
+	var object map[string]any
+	if err := json.Unmarshal([]byte(req.ConfigValue.ValueString()), &object); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON",
+			fmt.Sprintf("Value must be a JSON object: %s", err),
+		)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/upmaru/terraform-provider-tama/internal/validators"
+)
+
+func TestJSONObject(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value types.String
+		valid bool
+	}{
+		{"null", types.StringNull(), true},
+		{"unknown", types.StringUnknown(), true},
+		{"empty", types.StringValue(""), true},
+		{"object", types.StringValue(`{"relation": "similar", "limit": 10}`), true},
+		{"reformatted object", types.StringValue("{\n  \"limit\" : 10\n}"), true},
+		{"malformed", types.StringValue(`{"relation": }`), false},
+		{"array", types.StringValue(`[1, 2]`), false},
+		{"scalar", types.StringValue(`"similar"`), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("parameters"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.StringResponse{}
+
+			validators.JSONObject().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() == tt.valid {
+				t.Errorf("expected valid=%t, got diagnostics: %v", tt.valid, resp.Diagnostics)
+			}
+		})
+	}
+}
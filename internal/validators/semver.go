@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// IsSemver reports whether version is a valid semantic version as defined by
+// https://semver.org, e.g. "1.2.3", "1.0.0-beta.1" or "1.0.0+build.5".
+// A leading "v" and shorthand forms such as "1.2" are rejected.
+func IsSemver(version string) bool {
+	if version == "" || strings.HasPrefix(version, "v") {
+		return false
+	}
+
+	canonical := "v" + version
+	if !semver.IsValid(canonical) {
+		return false
+	}
+
+	// semver.IsValid accepts shorthand versions like "v1" and "v1.2", so
+	// require the full MAJOR.MINOR.PATCH core.
+	core := strings.TrimPrefix(canonical, "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+
+	return strings.Count(core, ".") == 2
+}
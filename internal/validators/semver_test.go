@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/upmaru/terraform-provider-tama/internal/validators"
+)
+
+func TestIsSemver(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version string
+		valid   bool
+	}{
+		{"1.0.0", true},
+		{"0.1.0", true},
+		{"10.20.30", true},
+		{"1.0.0-alpha", true},
+		{"1.0.0-beta.1", true},
+		{"1.0.0+build.5", true},
+		{"1.0.0-rc.1+build.123", true},
+		{"", false},
+		{"1", false},
+		{"1.2", false},
+		{"v1.2.3", false},
+		{"1.2.3.4", false},
+		{"01.2.3", false},
+		{"1.2.3-", false},
+		{"latest", false},
+		{"1.0.0@special", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			t.Parallel()
+
+			if got := validators.IsSemver(tt.version); got != tt.valid {
+				t.Errorf("IsSemver(%q) = %v, want %v", tt.version, got, tt.valid)
+			}
+		})
+	}
+}
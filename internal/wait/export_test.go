@@ -0,0 +1,7 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package wait
+
+// PollInterval exposes the poll interval so tests can shorten it.
+var PollInterval = &pollInterval
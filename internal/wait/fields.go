@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package wait
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = knownFieldValidator{}
+
+// knownFieldValidator ensures a wait_for field name refers to a field of the
+// object the condition is checked against.
+type knownFieldValidator struct {
+	object reflect.Type
+}
+
+// KnownField returns a validator which ensures that a wait_for field name is
+// a JSON path to a field of object, the API type read while waiting. A
+// misspelled name can never match, so it is reported at plan time instead of
+// once the wait times out.
+func KnownField(object any) validator.String {
+	return knownFieldValidator{object: reflect.TypeOf(object)}
+}
+
+func (v knownFieldValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of the fields %s, or a path within one of them", strings.Join(v.fields(), ", "))
+}
+
+func (v knownFieldValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v knownFieldValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	name := req.ConfigValue.ValueString()
+	if hasField(v.object, strings.Split(name, ".")) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Unknown Wait Field",
+		fmt.Sprintf("The field %q is not returned by the API for this object, so the condition can never be met. "+
+			"Known fields are: %s.", name, strings.Join(v.fields(), ", ")),
+	)
+}
+
+// fields returns the sorted top level field names of the object.
+func (v knownFieldValidator) fields() []string {
+	var names []string
+	if t := indirect(v.object); t != nil && t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			if name := jsonName(t.Field(i)); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// hasField reports whether the path of field names exists in t. Maps and
+// interfaces hold arbitrary JSON, so any path within them is accepted, as is
+// any path within a list since elements are addressed by index.
+func hasField(t reflect.Type, path []string) bool {
+	t = indirect(t)
+	if t == nil || len(path) == 0 {
+		return len(path) == 0
+	}
+
+	switch t.Kind() {
+	case reflect.Map, reflect.Interface, reflect.Slice, reflect.Array:
+		return true
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if jsonName(t.Field(i)) == path[0] {
+				return hasField(t.Field(i).Type, path[1:])
+			}
+		}
+	}
+
+	return false
+}
+
+// indirect returns the type pointed to by t, or t itself.
+func indirect(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// jsonName returns the name a struct field is encoded with, or an empty
+// string when it is not encoded.
+func jsonName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	default:
+		return name
+	}
+}
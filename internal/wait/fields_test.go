@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package wait_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/upmaru/tama-go/sensory"
+	"github.com/upmaru/terraform-provider-tama/internal/wait"
+)
+
+func TestKnownField(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		object      any
+		value       types.String
+		expectError bool
+	}{
+		{"top level field", sensory.Specification{}, types.StringValue("current_state"), false},
+		{"pointer to object", &sensory.Specification{}, types.StringValue("provision_state"), false},
+		{"path within a map", sensory.Specification{}, types.StringValue("schema.info.version"), false},
+		{"nested struct field", sensory.Identity{}, types.StringValue("validation.method"), false},
+		{"path within a pointer to struct", sensory.Source{}, types.StringValue("request.session_affinity"), false},
+		{"misspelled field", sensory.Specification{}, types.StringValue("curent_state"), true},
+		{"go field name", sensory.Specification{}, types.StringValue("CurrentState"), true},
+		{"unknown nested field", sensory.Identity{}, types.StringValue("validation.status"), true},
+		{"path within a scalar", sensory.Specification{}, types.StringValue("current_state.value"), true},
+		{"null", sensory.Specification{}, types.StringNull(), false},
+		{"unknown", sensory.Specification{}, types.StringUnknown(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.StringRequest{
+				Path:        path.Root("wait_for").AtListIndex(0).AtName("field").AtListIndex(0).AtName("name"),
+				ConfigValue: tt.value,
+			}
+			resp := &validator.StringResponse{}
+
+			wait.KnownField(tt.object).ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.expectError {
+				t.Fatalf("expected error %t, got %v", tt.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestKnownField_ListsKnownFields(t *testing.T) {
+	t.Parallel()
+
+	req := validator.StringRequest{
+		Path:        path.Root("name"),
+		ConfigValue: types.StringValue("curent_state"),
+	}
+	resp := &validator.StringResponse{}
+
+	wait.KnownField(sensory.Specification{}).ValidateString(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error")
+	}
+	expected := "Known fields are: current_state, endpoint, id, provision_state, schema, space_id, version."
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, expected) {
+		t.Errorf("expected detail to contain %q, got %q", expected, detail)
+	}
+}
@@ -6,11 +6,16 @@ package wait
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"slices"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/thedevsaddam/gojsonq/v2"
 )
@@ -26,8 +31,39 @@ type WaitFor struct {
 	Field []WaitForField `tfsdk:"field"`
 }
 
-// WaitForBlockSchema returns the common schema block for wait_for functionality.
-func WaitForBlockSchema() map[string]schema.Block {
+// DefaultTimeout is how long conditions are polled when no timeout is set.
+const DefaultTimeout = 10 * time.Minute
+
+// DataSourceWaitFor represents the wait_for configuration of a data source,
+// which can also set how long to wait.
+type DataSourceWaitFor struct {
+	Field   []WaitForField `tfsdk:"field"`
+	Timeout types.String   `tfsdk:"timeout"`
+}
+
+// TimeoutDuration returns the configured timeout, or DefaultTimeout when it
+// is not set.
+func (w DataSourceWaitFor) TimeoutDuration() (time.Duration, error) {
+	if w.Timeout.IsNull() || w.Timeout.IsUnknown() || w.Timeout.ValueString() == "" {
+		return DefaultTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(w.Timeout.ValueString())
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q: %s", w.Timeout.ValueString(), err)
+	}
+
+	if timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q: must be positive", w.Timeout.ValueString())
+	}
+
+	return timeout, nil
+}
+
+// WaitForBlockSchema returns the common schema block for wait_for
+// functionality. Field names are checked against object, the API type read
+// while waiting.
+func WaitForBlockSchema(object any) map[string]schema.Block {
 	return map[string]schema.Block{
 		"wait_for": schema.ListNestedBlock{
 			MarkdownDescription: "If set, will wait until either all of conditions are satisfied, or until timeout is reached",
@@ -40,6 +76,9 @@ func WaitForBlockSchema() map[string]schema.Block {
 								"name": schema.StringAttribute{
 									MarkdownDescription: "Name of the field to check (JSON path)",
 									Required:            true,
+									Validators: []validator.String{
+										KnownField(object),
+									},
 								},
 								"in": schema.ListAttribute{
 									MarkdownDescription: "List of acceptable values for the field",
@@ -55,30 +94,139 @@ func WaitForBlockSchema() map[string]schema.Block {
 	}
 }
 
+// WaitForDataSourceBlockSchema returns the wait_for block for data sources,
+// letting a read block until the object reaches the desired state. Field
+// names are checked against object, the API type read while waiting.
+func WaitForDataSourceBlockSchema(object any) map[string]datasourceschema.Block {
+	return map[string]datasourceschema.Block{
+		"wait_for": datasourceschema.ListNestedBlock{
+			MarkdownDescription: "If set, the read waits until all of the conditions are satisfied, or fails when the timeout is reached",
+			NestedObject: datasourceschema.NestedBlockObject{
+				Attributes: map[string]datasourceschema.Attribute{
+					"timeout": datasourceschema.StringAttribute{
+						MarkdownDescription: "How long to wait for the conditions, as a duration such as `30s` or `5m`. Defaults to `10m`.",
+						Optional:            true,
+					},
+				},
+				Blocks: map[string]datasourceschema.Block{
+					"field": datasourceschema.ListNestedBlock{
+						MarkdownDescription: "Condition criteria for a field",
+						NestedObject: datasourceschema.NestedBlockObject{
+							Attributes: map[string]datasourceschema.Attribute{
+								"name": datasourceschema.StringAttribute{
+									MarkdownDescription: "Name of the field to check (JSON path)",
+									Required:            true,
+									Validators: []validator.String{
+										KnownField(object),
+									},
+								},
+								"in": datasourceschema.ListAttribute{
+									MarkdownDescription: "List of acceptable values for the field",
+									Required:            true,
+									ElementType:         types.StringType,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ForDataSource waits until every wait_for entry of a data source is
+// satisfied, each within its own timeout.
+func ForDataSource(ctx context.Context, getResourceFunc func(string) (any, error), resourceId string, waitFor []DataSourceWaitFor) error {
+	for _, entry := range waitFor {
+		timeout, err := entry.TimeoutDuration()
+		if err != nil {
+			return err
+		}
+
+		if err := ForConditions(ctx, getResourceFunc, resourceId, entry.Field, timeout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NullTimeouts returns an unset timeouts block with create, update and delete
+// timeouts, for state built without a configuration such as on import.
+func NullTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		}),
+	}
+}
+
+// PhaseTimeoutError names the operation phase, such as create or update,
+// when err was caused by ctx exceeding the timeout configured for it.
+func PhaseTimeoutError(ctx context.Context, phase string, timeout time.Duration, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%s timeout of %s exceeded: %w", phase, timeout, err)
+	}
+
+	return err
+}
+
+// pollInterval is how often the resource is read while waiting.
+var pollInterval = 5 * time.Second
+
+// Until waits for every wait_for entry of a resource in turn and returns the
+// resource as last read, once all conditions are met. Resources use it to
+// record the settled state instead of the transient state returned by the
+// create or update call, e.g. while the engine reprovisions after an update.
+// The result is nil when waitFor is empty.
+func Until(ctx context.Context, getResourceFunc func(string) (any, error), resourceId string, waitFor []WaitFor, timeout time.Duration) (any, error) {
+	var resource any
+
+	for _, entry := range waitFor {
+		var err error
+		resource, err = forConditions(ctx, getResourceFunc, resourceId, entry.Field, timeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resource, nil
+}
+
 // ForConditions waits for specified field conditions to be met on a resource.
 // This is a generic function that can be used by any resource that needs wait functionality.
 func ForConditions(ctx context.Context, getResourceFunc func(string) (any, error), resourceId string, conditions []WaitForField, timeout time.Duration) error {
+	_, err := forConditions(ctx, getResourceFunc, resourceId, conditions, timeout)
+	return err
+}
+
+// forConditions polls the resource until the conditions are met and returns
+// the resource read last. Intermediate states, such as a resource going
+// through deprovisioning and provisioning again, keep the wait going.
+func forConditions(ctx context.Context, getResourceFunc func(string) (any, error), resourceId string, conditions []WaitForField, timeout time.Duration) (any, error) {
 	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	ticker := time.NewTicker(5 * time.Second)
+	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-timeoutCtx.Done():
-			return fmt.Errorf("timeout waiting for conditions")
+			return nil, fmt.Errorf("timeout waiting for conditions")
 		case <-ticker.C:
 			// Get current resource state
 			resource, err := getResourceFunc(resourceId)
 			if err != nil {
-				return fmt.Errorf("failed to get resource: %s", err)
+				return nil, fmt.Errorf("failed to get resource: %s", err)
 			}
 
 			// Convert to JSON for querying
 			jsonBytes, err := json.Marshal(resource)
 			if err != nil {
-				return fmt.Errorf("failed to marshal resource to JSON: %s", err)
+				return nil, fmt.Errorf("failed to marshal resource to JSON: %s", err)
 			}
 
 			// Check all conditions
@@ -100,7 +248,7 @@ func ForConditions(ctx context.Context, getResourceFunc func(string) (any, error
 				var acceptableValues []string
 				diags := condition.In.ElementsAs(ctx, &acceptableValues, false)
 				if diags.HasError() {
-					return fmt.Errorf("failed to parse acceptable values for field '%s'", condition.Name.ValueString())
+					return nil, fmt.Errorf("failed to parse acceptable values for field '%s'", condition.Name.ValueString())
 				}
 
 				// Check if the current value is in the list of acceptable values
@@ -111,7 +259,7 @@ func ForConditions(ctx context.Context, getResourceFunc func(string) (any, error
 			}
 
 			if allConditionsMet {
-				return nil
+				return resource, nil
 			}
 		}
 	}
@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package wait_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/upmaru/terraform-provider-tama/internal/wait"
+)
+
+func TestDataSourceWaitFor_TimeoutDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		timeout  types.String
+		expected time.Duration
+		valid    bool
+	}{
+		{"unset", types.StringNull(), wait.DefaultTimeout, true},
+		{"empty", types.StringValue(""), wait.DefaultTimeout, true},
+		{"seconds", types.StringValue("30s"), 30 * time.Second, true},
+		{"minutes", types.StringValue("5m"), 5 * time.Minute, true},
+		{"malformed", types.StringValue("five minutes"), 0, false},
+		{"zero", types.StringValue("0s"), 0, false},
+		{"negative", types.StringValue("-1m"), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			timeout, err := wait.DataSourceWaitFor{Timeout: tt.timeout}.TimeoutDuration()
+			if tt.valid && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !tt.valid && err == nil {
+				t.Fatal("expected an error")
+			}
+			if timeout != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, timeout)
+			}
+		})
+	}
+}
+
+func TestPhaseTimeoutError(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("timeout waiting for conditions")
+
+	expired, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-expired.Done()
+
+	err := wait.PhaseTimeoutError(expired, "create", 2*time.Minute, cause)
+	if err.Error() != "create timeout of 2m0s exceeded: timeout waiting for conditions" {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("expected the cause to be wrapped")
+	}
+
+	if err := wait.PhaseTimeoutError(context.Background(), "create", 2*time.Minute, cause); err != cause {
+		t.Errorf("expected the cause to be returned unchanged, got %s", err)
+	}
+}
+
+func TestUntil_PollsThroughTransientStates(t *testing.T) {
+	restore := *wait.PollInterval
+	*wait.PollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { *wait.PollInterval = restore })
+
+	type resource struct {
+		CurrentState string `json:"current_state"`
+	}
+
+	// An update that deprovisions the resource before provisioning it again.
+	states := []string{"processing", "failed", "processing", "completed"}
+	reads := 0
+	get := func(string) (any, error) {
+		state := states[min(reads, len(states)-1)]
+		reads++
+		return &resource{CurrentState: state}, nil
+	}
+
+	in, diags := types.ListValueFrom(context.Background(), types.StringType, []string{"completed"})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	waitFor := []wait.WaitFor{{Field: []wait.WaitForField{{Name: types.StringValue("current_state"), In: in}}}}
+
+	settled, err := wait.Until(context.Background(), get, "id", waitFor, time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, ok := settled.(*resource)
+	if !ok {
+		t.Fatalf("expected the last read resource, got %T", settled)
+	}
+	if got.CurrentState != "completed" {
+		t.Errorf("expected completed, got %s", got.CurrentState)
+	}
+	if reads != len(states) {
+		t.Errorf("expected %d reads, got %d", len(states), reads)
+	}
+
+	settled, err = wait.Until(context.Background(), get, "id", nil, time.Second)
+	if err != nil || settled != nil {
+		t.Errorf("expected no wait without wait_for, got %v, %v", settled, err)
+	}
+}
+
+func TestNullTimeouts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	value := wait.NullTimeouts()
+	if !value.IsNull() {
+		t.Fatal("expected null timeouts")
+	}
+
+	block := timeouts.Block(ctx, timeouts.Opts{Create: true, Update: true, Delete: true})
+	if !value.Type(ctx).Equal(block.Type()) {
+		t.Errorf("expected type %s, got %s", block.Type(), value.Type(ctx))
+	}
+
+	timeout, diags := value.Create(ctx, wait.DefaultTimeout)
+	if diags.HasError() || timeout != wait.DefaultTimeout {
+		t.Errorf("expected the default timeout, got %s: %v", timeout, diags)
+	}
+}
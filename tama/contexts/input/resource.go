@@ -17,6 +17,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/contexts"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
+	"github.com/upmaru/terraform-provider-tama/internal/provision"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -89,18 +92,18 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerMeta.Client
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -130,7 +133,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	// Create input
 	inputResponse, err := r.client.Contexts.CreateInput(data.ThoughtContextId.ValueString(), createReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create input, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to create input, got error: %s", err)))
 		return
 	}
 
@@ -165,7 +168,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 
 	inputResponse, err := r.client.Contexts.GetInput(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read input, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read input, got error: %s", err)))
 		return
 	}
 
@@ -181,6 +184,11 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(provision.CheckUpdatable(ctx, req.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ResourceModel
 
 	// Read Terraform plan data into the model
@@ -207,7 +215,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	// Update input
 	inputResponse, err := r.client.Contexts.UpdateInput(data.Id.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update input, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to update input, got error: %s", err)))
 		return
 	}
 
@@ -239,7 +247,7 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 
 	err := r.client.Contexts.DeleteInput(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete input, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to delete input, got error: %s", err)))
 		return
 	}
 }
@@ -252,7 +260,7 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 
 	inputResponse, err := r.client.Contexts.GetInput(req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read input for import, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read input for import, got error: %s", err)))
 		return
 	}
 
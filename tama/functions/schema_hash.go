@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/upmaru/terraform-provider-tama/internal/planmodifier"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SchemaHashFunction{}
+
+func NewSchemaHashFunction() function.Function {
+	return &SchemaHashFunction{}
+}
+
+// SchemaHashFunction defines the schema_hash function implementation.
+type SchemaHashFunction struct{}
+
+func (f *SchemaHashFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "schema_hash"
+}
+
+func (f *SchemaHashFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Hash a class schema",
+		MarkdownDescription: "Returns the hex encoded SHA-256 hash of a JSON schema after normalization. Object keys and the entries of `required` are sorted, insignificant whitespace is removed and numbers are written in a canonical form, so schemas that only differ in formatting, key order or the order of `required` hash alike.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "schema_json",
+				MarkdownDescription: "Schema as a JSON string, e.g. from `jsonencode` or `file`",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SchemaHashFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var schemaJSON string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &schemaJSON))
+	if resp.Error != nil {
+		return
+	}
+
+	hash, err := SchemaHash(schemaJSON)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, hash))
+}
+
+// SchemaHash returns the hex encoded SHA-256 hash of the normalized form of
+// schemaJSON, as computed by planmodifier.NormalizeSchemaJSON.
+func SchemaHash(schemaJSON string) (string, error) {
+	if schemaJSON == "" {
+		return "", fmt.Errorf("schema_json must not be empty")
+	}
+
+	normalized, err := planmodifier.NormalizeSchemaJSON(schemaJSON)
+	if err != nil {
+		return "", fmt.Errorf("schema_json is not valid JSON: %s", err)
+	}
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package functions
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSchemaHash(t *testing.T) {
+	t.Parallel()
+
+	hash, err := SchemaHash(`{"title": "article", "type": "object", "required": ["title", "body"], "properties": {"title": {"type": "string"}, "body": {"type": "string"}}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	sum := sha256.Sum256([]byte(`{"properties":{"body":{"type":"string"},"title":{"type":"string"}},"required":["body","title"],"title":"article","type":"object"}`))
+	if want := hex.EncodeToString(sum[:]); hash != want {
+		t.Errorf("expected %s, got %s", want, hash)
+	}
+
+	// Formatting, key order and number notation do not change the hash
+	equivalent, err := SchemaHash(`{
+  "type": "object",
+  "title": "article",
+  "required": ["body", "title"],
+  "properties": {
+    "body": {"type": "string"},
+    "title": {"type": "string"}
+  }
+}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if equivalent != hash {
+		t.Errorf("expected equivalent schemas to hash alike, got %s and %s", hash, equivalent)
+	}
+
+	if a, b := mustSchemaHash(t, `{"maximum": 5}`), mustSchemaHash(t, `{"maximum": 5.0}`); a != b {
+		t.Errorf("expected 5 and 5.0 to hash alike, got %s and %s", a, b)
+	}
+	if a, b := mustSchemaHash(t, `{"title": "a"}`), mustSchemaHash(t, `{"title": "b"}`); a == b {
+		t.Error("expected different schemas to hash differently")
+	}
+
+	for _, invalid := range []string{"", "{", `{"title": "a"} trailing`} {
+		if _, err := SchemaHash(invalid); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestSchemaHashFunction_Run(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	run := func(schemaJSON string) *function.RunResponse {
+		resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+		NewSchemaHashFunction().Run(ctx, function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(schemaJSON)}),
+		}, resp)
+		return resp
+	}
+
+	resp := run(`{"title": "article"}`)
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+	if want := mustSchemaHash(t, `{"title":"article"}`); !resp.Result.Equal(function.NewResultData(types.StringValue(want))) {
+		t.Errorf("expected result %s, got %v", want, resp.Result.Value())
+	}
+
+	resp = run(`{"title": }`)
+	if resp.Error == nil {
+		t.Fatal("expected an error for invalid JSON")
+	}
+	if resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != 0 {
+		t.Errorf("expected the error to point at schema_json, got %v", resp.Error)
+	}
+}
+
+func mustSchemaHash(t *testing.T, schemaJSON string) string {
+	t.Helper()
+
+	hash, err := SchemaHash(schemaJSON)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return hash
+}
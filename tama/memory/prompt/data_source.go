@@ -12,6 +12,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -84,18 +86,18 @@ func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerMeta.Client
 }
 
 func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -115,7 +117,7 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 
 	promptResponse, err := d.client.Memory.GetPrompt(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prompt, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read prompt, got error: %s", err)))
 		return
 	}
 
@@ -15,6 +15,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/memory"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
+	"github.com/upmaru/terraform-provider-tama/internal/provision"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -97,18 +100,18 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerMeta.Client
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -139,7 +142,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	promptResponse, err := r.client.Memory.CreatePrompt(data.SpaceId.ValueString(), createRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create prompt, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to create prompt, got error: %s", err)))
 		return
 	}
 
@@ -171,7 +174,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	// Get prompt from API
 	promptResponse, err := r.client.Memory.GetPrompt(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prompt, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read prompt, got error: %s", err)))
 		return
 	}
 
@@ -187,6 +190,11 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(provision.CheckUpdatable(ctx, req.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ResourceModel
 
 	// Read Terraform plan data into the model
@@ -214,7 +222,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	promptResponse, err := r.client.Memory.UpdatePrompt(data.Id.ValueString(), updateRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update prompt, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to update prompt, got error: %s", err)))
 		return
 	}
 
@@ -246,7 +254,7 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 
 	err := r.client.Memory.DeletePrompt(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete prompt, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to delete prompt, got error: %s", err)))
 		return
 	}
 }
@@ -255,7 +263,7 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 	// Get prompt from API to populate state
 	promptResponse, err := r.client.Memory.GetPrompt(req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import prompt, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to import prompt, got error: %s", err)))
 		return
 	}
 
@@ -15,6 +15,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/memory"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
+	"github.com/upmaru/terraform-provider-tama/internal/provision"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -79,18 +82,18 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerMeta.Client
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -116,7 +119,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	topic, err := r.client.Memory.CreateTopic(data.ListenerId.ValueString(), createRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create topic, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to create topic, got error: %s", err)))
 		return
 	}
 
@@ -140,7 +143,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 
 	topic, err := r.client.Memory.GetTopic(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read topic, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read topic, got error: %s", err)))
 		return
 	}
 
@@ -153,6 +156,11 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(provision.CheckUpdatable(ctx, req.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ResourceModel
 
 	// Read Terraform plan data into the model
@@ -176,7 +184,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	topic, err := r.client.Memory.UpdateTopic(data.Id.ValueString(), updateRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update topic, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to update topic, got error: %s", err)))
 		return
 	}
 
@@ -203,7 +211,7 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 	})
 
 	if err := r.client.Memory.DeleteTopic(data.Id.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete topic, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to delete topic, got error: %s", err)))
 		return
 	}
 }
@@ -211,7 +219,7 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	topic, err := r.client.Memory.GetTopic(req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import topic, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to import topic, got error: %s", err)))
 		return
 	}
 
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -16,15 +17,22 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/motor"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
 	internalplanmodifier "github.com/upmaru/terraform-provider-tama/internal/planmodifier"
+	"github.com/upmaru/terraform-provider-tama/internal/provision"
 )
 
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
+var _ resource.ResourceWithModifyPlan = &Resource{}
 
 func NewResource() resource.Resource { return &Resource{} }
 
-type Resource struct{ client *tama.Client }
+type Resource struct {
+	client               *tama.Client
+	disableNormalization bool
+}
 
 type ResourceModel struct {
 	Id             types.String `tfsdk:"id"`
@@ -73,15 +81,23 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
-	r.client = client
+	r.client = providerMeta.Client
+	r.disableNormalization = providerMeta.DisableJSONNormalization
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Plan formatting changes when the provider disables normalization
+	if r.disableNormalization {
+		resp.Diagnostics.Append(internalplanmodifier.KeepConfiguredJSON(ctx, req.Config, &resp.Plan, path.MatchRoot("schema"))...)
+	}
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -112,7 +128,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	created, err := r.client.Motor.CreateModifier(data.ActionId.ValueString(), createReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create modifier, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to create modifier, got error: %s", err)))
 		return
 	}
 
@@ -123,7 +139,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	// Normalize and set schema from response
 	if b, err := json.Marshal(created.Schema); err == nil {
 		if normalized, nerr := internalplanmodifier.NormalizeJSON(string(b)); nerr == nil {
-			data.Schema = types.StringValue(normalized)
+			data.Schema = internalplanmodifier.KeepEquivalent(data.Schema, normalized, internalplanmodifier.NormalizeJSON)
 		}
 	}
 
@@ -140,7 +156,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 
 	mod, err := r.client.Motor.GetModifier(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read modifier, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read modifier, got error: %s", err)))
 		return
 	}
 
@@ -150,7 +166,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	// Normalize and set schema from response
 	if b, err := json.Marshal(mod.Schema); err == nil {
 		if normalized, nerr := internalplanmodifier.NormalizeJSON(string(b)); nerr == nil {
-			data.Schema = types.StringValue(normalized)
+			data.Schema = internalplanmodifier.KeepEquivalent(data.Schema, normalized, internalplanmodifier.NormalizeJSON)
 		}
 	}
 
@@ -158,6 +174,11 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(provision.CheckUpdatable(ctx, req.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...) // Read plan
 	if resp.Diagnostics.HasError() {
@@ -186,7 +207,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	updated, err := r.client.Motor.UpdateModifier(data.Id.ValueString(), update)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update modifier, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to update modifier, got error: %s", err)))
 		return
 	}
 
@@ -196,7 +217,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	// Normalize and set schema from response
 	if b, err := json.Marshal(updated.Schema); err == nil {
 		if normalized, nerr := internalplanmodifier.NormalizeJSON(string(b)); nerr == nil {
-			data.Schema = types.StringValue(normalized)
+			data.Schema = internalplanmodifier.KeepEquivalent(data.Schema, normalized, internalplanmodifier.NormalizeJSON)
 		}
 	}
 
@@ -211,7 +232,7 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 	}
 
 	if err := r.client.Motor.DeleteModifier(data.Id.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete modifier, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to delete modifier, got error: %s", err)))
 		return
 	}
 	// No state to set; Terraform will remove resource from state after successful delete
@@ -221,7 +242,7 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	mod, err := r.client.Motor.GetModifier(req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read modifier for import, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read modifier for import, got error: %s", err)))
 		return
 	}
 
@@ -232,7 +253,7 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 	data.ProvisionState = types.StringValue(mod.ProvisionState)
 	if b, err := json.Marshal(mod.Schema); err == nil {
 		if normalized, nerr := internalplanmodifier.NormalizeJSON(string(b)); nerr == nil {
-			data.Schema = types.StringValue(normalized)
+			data.Schema = internalplanmodifier.KeepEquivalent(data.Schema, normalized, internalplanmodifier.NormalizeJSON)
 		}
 	}
 
@@ -15,6 +15,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/neural"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
+	"github.com/upmaru/terraform-provider-tama/internal/provision"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -79,18 +82,18 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerMeta.Client
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -117,7 +120,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	bridgeResponse, err := r.client.Neural.CreateBridge(data.SpaceId.ValueString(), createRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create bridge, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to create bridge, got error: %s", err)))
 		return
 	}
 
@@ -147,7 +150,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	// Get bridge from API
 	bridgeResponse, err := r.client.Neural.GetBridge(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read bridge, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read bridge, got error: %s", err)))
 		return
 	}
 
@@ -161,6 +164,11 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(provision.CheckUpdatable(ctx, req.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ResourceModel
 
 	// Read Terraform plan data into the model
@@ -184,7 +192,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	bridgeResponse, err := r.client.Neural.UpdateBridge(data.Id.ValueString(), updateRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update bridge, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to update bridge, got error: %s", err)))
 		return
 	}
 
@@ -214,7 +222,7 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 
 	err := r.client.Neural.DeleteBridge(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete bridge, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to delete bridge, got error: %s", err)))
 		return
 	}
 }
@@ -223,7 +231,7 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 	// Get bridge from API to populate state
 	bridgeResponse, err := r.client.Neural.GetBridge(req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import bridge, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to import bridge, got error: %s", err)))
 		return
 	}
 
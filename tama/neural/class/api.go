@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package class
+
+import (
+	"github.com/upmaru/tama-go/neural"
+)
+
+// ClassAPI describes the class operations used by the class resource.
+// It is satisfied by the neural service of *tama.Client and allows the
+// resource logic to be unit tested against a fake.
+type ClassAPI interface {
+	GetClass(id string) (*neural.Class, error)
+	GetClassBySpecificationAndName(specificationID string, name string) (*neural.Class, error)
+	CreateClass(spaceID string, req neural.CreateClassRequest) (*neural.Class, error)
+	UpdateClass(id string, req neural.UpdateClassRequest) (*neural.Class, error)
+	DeleteClass(id string) error
+}
+
+var _ ClassAPI = (*neural.Service)(nil)
@@ -14,6 +14,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/neural"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/datasource/argvalidate"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -124,18 +127,18 @@ func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerMeta.Client
 }
 
 func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -149,39 +152,19 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 	}
 
 	// Validate the different ways to query for a class
-	hasId := !data.Id.IsNull() && !data.Id.IsUnknown() && data.Id.ValueString() != ""
-	hasSpecificationAndName := !data.SpecificationID.IsNull() && !data.SpecificationID.IsUnknown() && data.SpecificationID.ValueString() != "" &&
-		!data.Name.IsNull() && !data.Name.IsUnknown() && data.Name.ValueString() != ""
-	hasSpaceAndName := !data.SpaceId.IsNull() && !data.SpaceId.IsUnknown() && data.SpaceId.ValueString() != "" &&
-		!data.Name.IsNull() && !data.Name.IsUnknown() && data.Name.ValueString() != ""
-
-	// Count how many valid approaches are provided
-	approachCount := 0
-	if hasId {
-		approachCount++
-	}
-	if hasSpecificationAndName {
-		approachCount++
-	}
-	if hasSpaceAndName {
-		approachCount++
-	}
+	lookup, diags := argvalidate.Select(
+		argvalidate.NewMode([]string{"id"}, data.Id),
+		argvalidate.NewMode([]string{"specification_id", "name"}, data.SpecificationID, data.Name),
+		argvalidate.NewMode([]string{"space_id", "name"}, data.SpaceId, data.Name),
+	)
+	resp.Diagnostics.Append(diags...)
 
-	if approachCount == 0 {
-		resp.Diagnostics.AddError(
-			"Missing Required Arguments",
-			"You must provide one of the following: 'id' alone, 'specification_id' + 'name', or 'space_id' + 'name'.",
-		)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if approachCount > 1 {
-		resp.Diagnostics.AddError(
-			"Conflicting Arguments",
-			"You can only use one approach at a time: 'id' alone, 'specification_id' + 'name', or 'space_id' + 'name'.",
-		)
-		return
-	}
+	hasId := lookup == 0
+	hasSpecificationAndName := lookup == 1
 
 	var classResponse *neural.Class
 	var err error
@@ -194,7 +177,7 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 
 		classResponse, err = d.client.Neural.GetClass(data.Id.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read class by ID, got error: %s", err))
+			resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read class by ID, got error: %s", err)))
 			return
 		}
 	} else if hasSpecificationAndName {
@@ -206,10 +189,10 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 
 		classResponse, err = d.client.Neural.GetClassBySpecificationAndName(data.SpecificationID.ValueString(), data.Name.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read class by specification and name, got error: %s", err))
+			resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read class by specification and name, got error: %s", err)))
 			return
 		}
-	} else if hasSpaceAndName {
+	} else {
 		// Get class by space ID and name
 		tflog.Debug(ctx, "Reading class by space and name", map[string]any{
 			"space_id": data.SpaceId.ValueString(),
@@ -218,7 +201,7 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 
 		classResponse, err = d.client.Neural.GetClassBySpaceAndName(data.SpaceId.ValueString(), data.Name.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read class by space and name, got error: %s", err))
+			resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read class by space and name, got error: %s", err)))
 			return
 		}
 	}
@@ -5,23 +5,39 @@ package class
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/neural"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
 	internalplanmodifier "github.com/upmaru/terraform-provider-tama/internal/planmodifier"
+	"github.com/upmaru/terraform-provider-tama/internal/provision"
+	"github.com/upmaru/terraform-provider-tama/internal/validators"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
+var _ resource.ResourceWithModifyPlan = &Resource{}
+var _ resource.ResourceWithValidateConfig = &Resource{}
 
 func NewResource() resource.Resource {
 	return &Resource{}
@@ -29,7 +45,10 @@ func NewResource() resource.Resource {
 
 // Resource defines the resource implementation.
 type Resource struct {
-	client *tama.Client
+	client              ClassAPI
+	schemaSizeWarnBytes int64
+
+	disableNormalization bool
 }
 
 // SchemaModel describes the schema block data model.
@@ -44,13 +63,19 @@ type SchemaModel struct {
 
 // ResourceModel describes the resource data model.
 type ResourceModel struct {
-	Id             types.String  `tfsdk:"id"`
-	Name           types.String  `tfsdk:"name"`
-	Description    types.String  `tfsdk:"description"`
-	Schema         []SchemaModel `tfsdk:"schema"`
-	SchemaJSON     types.String  `tfsdk:"schema_json"`
-	ProvisionState types.String  `tfsdk:"provision_state"`
-	SpaceId        types.String  `tfsdk:"space_id"`
+	Id                   types.String  `tfsdk:"id"`
+	Name                 types.String  `tfsdk:"name"`
+	Description          types.String  `tfsdk:"description"`
+	Schema               []SchemaModel `tfsdk:"schema"`
+	SchemaJSON           types.String  `tfsdk:"schema_json"`
+	SchemaJSONFile       types.String  `tfsdk:"schema_json_file"`
+	SchemaJSONFileSHA256 types.String  `tfsdk:"schema_json_file_sha256"`
+	PreserveKeyOrder     types.Bool    `tfsdk:"preserve_key_order"`
+	ProvisionState       types.String  `tfsdk:"provision_state"`
+	SpaceId              types.String  `tfsdk:"space_id"`
+	SpecificationId      types.String  `tfsdk:"specification_id"`
+	SourceClassId        types.String  `tfsdk:"source_class_id"`
+	Overrides            types.String  `tfsdk:"overrides"`
 }
 
 func (r *Resource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -80,9 +105,42 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 			"schema_json": schema.StringAttribute{
 				MarkdownDescription: "JSON schema as a string. Mutually exclusive with schema block.",
 				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					internalplanmodifier.JSONSchemaNormalize(),
+				},
+			},
+			"schema_json_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a file containing the JSON schema, read at plan time. Relative paths are resolved from the directory Terraform runs in, use `path.module` to refer to a file next to the module. Mutually exclusive with schema block, schema_json and source_class_id.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("schema_json"), path.MatchRoot("source_class_id")),
+				},
+			},
+			"schema_json_file_sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 of the normalized schema loaded from schema_json_file. Changes when the file contents change; a schema changed on the server is reported as a warning on refresh.",
+				Computed:            true,
+			},
+			"preserve_key_order": schema.BoolAttribute{
+				MarkdownDescription: "Submit the schema with object keys in the order they were written rather than sorted alphabetically, so consumers such as the Tama console show properties in authored order. Applies to schema_json, schema_json_file and the properties of the schema block, the top level keys of the schema are always sorted. Changes are still compared semantically, so reordering keys alone does not cause an update. Defaults to `false`.",
+				Optional:            true,
+			},
+			"source_class_id": schema.StringAttribute{
+				MarkdownDescription: "ID of an existing class whose schema is copied as the base for this class. Mutually exclusive with schema block and schema_json. The schema is copied on create and update, later changes to the source class are not followed.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("schema_json")),
+				},
+			},
+			"overrides": schema.StringAttribute{
+				MarkdownDescription: "JSON object deep merged on top of the schema copied from source_class_id, e.g. to set a distinct title. Requires source_class_id.",
+				Optional:            true,
 				PlanModifiers: []planmodifier.String{
 					internalplanmodifier.JSONNormalize(),
 				},
+				Validators: []validator.String{
+					validators.JSONObject(),
+					stringvalidator.AlsoRequires(path.MatchRoot("source_class_id")),
+				},
 			},
 			"provision_state": schema.StringAttribute{
 				MarkdownDescription: "Current state of the class",
@@ -95,6 +153,13 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"specification_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the specification the class was generated from. Only set when the class is imported as `<specification_id>/<name>`, classes created by this resource do not belong to a specification.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"schema": schema.ListNestedBlock{
@@ -117,7 +182,7 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 							MarkdownDescription: "JSON string defining the properties of the schema",
 							Optional:            true,
 							PlanModifiers: []planmodifier.String{
-								internalplanmodifier.JSONNormalize(),
+								internalplanmodifier.JSONSchemaNormalize(),
 							},
 						},
 						"required": schema.ListAttribute{
@@ -126,8 +191,9 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 							ElementType:         types.StringType,
 						},
 						"strict": schema.BoolAttribute{
-							MarkdownDescription: "Whether the schema should be strictly validated",
+							MarkdownDescription: "Whether the schema should be strictly validated. When omitted, the server default is used and read back.",
 							Optional:            true,
+							Computed:            true,
 						},
 					},
 				},
@@ -142,18 +208,93 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerMeta.Client.Neural
+	r.schemaSizeWarnBytes = providerMeta.SchemaSizeWarnBytes
+	r.disableNormalization = providerMeta.DisableJSONNormalization
+}
+
+// ValidateConfig reports attributes set together with the schema block. A
+// list block is never null, so ConflictsWith cannot be used for it.
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var schemaBlocks types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("schema"), &schemaBlocks)...)
+	if resp.Diagnostics.HasError() || schemaBlocks.IsUnknown() || len(schemaBlocks.Elements()) == 0 {
+		return
+	}
+
+	for _, name := range []string{"schema_json", "schema_json_file", "source_class_id"} {
+		var value types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+
+		if !value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("Attribute %q cannot be specified together with the schema block.", name),
+			)
+		}
+	}
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Plan formatting changes when the provider disables normalization
+	if r.disableNormalization {
+		resp.Diagnostics.Append(internalplanmodifier.KeepConfiguredJSON(ctx, req.Config, &resp.Plan,
+			path.MatchRoot("schema_json"),
+			path.MatchRoot("overrides"),
+			path.MatchRoot("schema").AtAnyListIndex().AtName("properties"),
+		)...)
+	}
+
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkSchemaSize(ctx, req.Plan)...)
+
+	var schemaJSONFile types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("schema_json_file"), &schemaJSONFile)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if schemaJSONFile.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("schema_json_file_sha256"), types.StringUnknown())...)
+		return
+	}
+
+	if schemaJSONFile.IsNull() || schemaJSONFile.ValueString() == "" {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("schema_json_file_sha256"), types.StringNull())...)
+		return
+	}
+
+	// Load the file now so an unreadable or invalid schema fails the plan
+	// and changes to its contents are planned as an update
+	schemaFileJSON, err := readSchemaFile(schemaJSONFile.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("schema_json_file"), "Schema Error", err.Error())
+		return
+	}
+
+	_, diags := parseSchemaJSON(schemaFileJSON)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("schema_json_file_sha256"), types.StringValue(schemaSHA256(schemaFileJSON)))...)
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -166,76 +307,70 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
-	// Validate that exactly one schema method is provided (either block or JSON)
+	// Which schema method is used (block, JSON, file or source class). They
+	// are mutually exclusive, which ValidateConfig and the attribute
+	// validators check before apply.
 	hasSchemaBlock := len(data.Schema) > 0
 	hasSchemaJSON := !data.SchemaJSON.IsNull() && !data.SchemaJSON.IsUnknown() && data.SchemaJSON.ValueString() != ""
+	hasSchemaJSONFile := !data.SchemaJSONFile.IsNull() && !data.SchemaJSONFile.IsUnknown() && data.SchemaJSONFile.ValueString() != ""
+	hasSourceClass := !data.SourceClassId.IsNull() && !data.SourceClassId.IsUnknown() && data.SourceClassId.ValueString() != ""
 
-	if hasSchemaBlock && hasSchemaJSON {
-		resp.Diagnostics.AddError("Schema Error", "Cannot specify both schema block and schema_json attribute. Choose one.")
-		return
-	}
-
-	if !hasSchemaBlock && !hasSchemaJSON {
-		resp.Diagnostics.AddError("Schema Error", "Either schema block or schema_json attribute must be provided")
+	if !hasSchemaBlock && !hasSchemaJSON && !hasSchemaJSONFile && !hasSourceClass {
+		resp.Diagnostics.AddError("Schema Error", "Either schema block or schema_json attribute must be provided, or schema_json_file to load the schema from a file, or source_class_id to copy the schema from another class")
 		return
 	}
 
 	var schemaMap map[string]any
+	var schemaFileJSON string
 
-	if hasSchemaBlock {
+	if hasSourceClass {
+		var err error
+		schemaMap, err = r.sourceClassSchema(data.SourceClassId.ValueString(), data.Overrides)
+		if err != nil {
+			resp.Diagnostics.AddError("Schema Error", err.Error())
+			return
+		}
+	} else if hasSchemaBlock {
 		// Validate that exactly one schema block is provided
 		if len(data.Schema) != 1 {
 			resp.Diagnostics.AddError("Schema Error", "Exactly one schema block must be provided")
 			return
 		}
 
-		schemaBlock := data.Schema[0]
-
-		// Build schema map from block attributes
-		schemaMap = map[string]any{
-			"title":       schemaBlock.Title.ValueString(),
-			"description": schemaBlock.Description.ValueString(),
-			"type":        schemaBlock.Type.ValueString(),
-		}
-
-		// Add properties if provided
-		if !schemaBlock.Properties.IsNull() && !schemaBlock.Properties.IsUnknown() {
-			var propertiesMap map[string]any
-			if err := json.Unmarshal([]byte(schemaBlock.Properties.ValueString()), &propertiesMap); err != nil {
-				resp.Diagnostics.AddError("Schema Error", fmt.Sprintf("Unable to parse properties JSON: %s", err))
-				return
-			}
-			schemaMap["properties"] = propertiesMap
+		var diags diag.Diagnostics
+		schemaMap, diags = schemaBlockMap(ctx, data.Schema[0])
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
-
-		// Add required fields if provided
-		if !schemaBlock.Required.IsNull() && !schemaBlock.Required.IsUnknown() {
-			var requiredList []string
-			resp.Diagnostics.Append(schemaBlock.Required.ElementsAs(ctx, &requiredList, false)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-			schemaMap["required"] = requiredList
+	} else if hasSchemaJSONFile {
+		var err error
+		schemaFileJSON, err = readSchemaFile(data.SchemaJSONFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Schema Error", err.Error())
+			return
 		}
 
-		// Add strict if provided
-		if !schemaBlock.Strict.IsNull() && !schemaBlock.Strict.IsUnknown() {
-			schemaMap["strict"] = schemaBlock.Strict.ValueBool()
+		var diags diag.Diagnostics
+		schemaMap, diags = parseSchemaJSON(schemaFileJSON)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
 	} else {
-		// Parse schema JSON string
-		if err := json.Unmarshal([]byte(data.SchemaJSON.ValueString()), &schemaMap); err != nil {
-			resp.Diagnostics.AddError("Schema Error", fmt.Sprintf("Unable to parse schema JSON: %s", err))
+		var diags diag.Diagnostics
+		schemaMap, diags = parseSchemaJSON(data.SchemaJSON.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
+	}
 
-		// Validate required fields in JSON schema
-		if _, ok := schemaMap["title"]; !ok {
-			resp.Diagnostics.AddError("Schema Error", "JSON schema must include 'title' field")
-			return
-		}
-		if _, ok := schemaMap["description"]; !ok {
-			resp.Diagnostics.AddError("Schema Error", "JSON schema must include 'description' field")
+	if data.PreserveKeyOrder.ValueBool() {
+		var err error
+		schemaMap, err = authoredSchemaMap(data, schemaMap)
+		if err != nil {
+			resp.Diagnostics.AddError("Schema Error", err.Error())
 			return
 		}
 	}
@@ -252,9 +387,9 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		"schema":   schemaMap,
 	})
 
-	classResponse, err := r.client.Neural.CreateClass(data.SpaceId.ValueString(), createRequest)
+	classResponse, err := r.client.CreateClass(data.SpaceId.ValueString(), createRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create class, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to create class, got error: %s", err)))
 		return
 	}
 
@@ -263,32 +398,28 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	data.Name = types.StringValue(classResponse.Name)
 	data.Description = types.StringValue(classResponse.Description)
 	data.ProvisionState = types.StringValue(classResponse.ProvisionState)
+	data.SpecificationId = types.StringNull()
 	data.SpaceId = types.StringValue(classResponse.SpaceID)
 
-	// Update schema based on which method was used
+	// Update schema based on which method was used. A schema copied from a
+	// source class is not tracked in state.
 	if hasSchemaBlock {
 		err = r.updateSchemaFromResponse(ctx, classResponse.Schema, &data)
 		if err != nil {
 			resp.Diagnostics.AddError("Schema Error", fmt.Sprintf("Unable to update schema from response: %s", err))
 			return
 		}
-	} else {
+	} else if hasSchemaJSON {
 		// Update schema_json with response, but normalize to match plan modifier behavior
-		schemaJSON, err := json.Marshal(classResponse.Schema)
-		if err != nil {
-			resp.Diagnostics.AddError("Schema Error", fmt.Sprintf("Unable to marshal schema to JSON: %s", err))
-			return
-		}
-
-		// Normalize the marshaled JSON to ensure consistent formatting
-		normalizedJSON, err := internalplanmodifier.NormalizeJSON(string(schemaJSON))
+		data.SchemaJSON, err = schemaJSONFromResponse(classResponse.Schema, data.SchemaJSON)
 		if err != nil {
-			resp.Diagnostics.AddError("Schema Error", fmt.Sprintf("Unable to normalize schema JSON: %s", err))
+			resp.Diagnostics.AddError("Schema Error", err.Error())
 			return
 		}
-		data.SchemaJSON = types.StringValue(normalizedJSON)
 	}
 
+	data.SchemaJSONFileSHA256 = schemaFileSHA256(hasSchemaJSONFile, schemaFileJSON)
+
 	// Write logs using the tflog package
 	tflog.Trace(ctx, "created a class resource")
 
@@ -307,9 +438,9 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	}
 
 	// Get class from API
-	classResponse, err := r.client.Neural.GetClass(data.Id.ValueString())
+	classResponse, err := r.client.GetClass(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read class, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read class, got error: %s", err)))
 		return
 	}
 
@@ -332,19 +463,17 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		}
 	} else if hasSchemaJSON {
 		// Update schema_json with response, but normalize to match plan modifier behavior
-		schemaJSON, err := json.Marshal(classResponse.Schema)
+		data.SchemaJSON, err = schemaJSONFromResponse(classResponse.Schema, data.SchemaJSON)
 		if err != nil {
-			resp.Diagnostics.AddError("Schema Error", fmt.Sprintf("Unable to marshal schema to JSON: %s", err))
+			resp.Diagnostics.AddError("Schema Error", err.Error())
 			return
 		}
+	}
 
-		// Normalize the marshaled JSON to ensure consistent formatting
-		normalizedJSON, err := internalplanmodifier.NormalizeJSON(string(schemaJSON))
-		if err != nil {
-			resp.Diagnostics.AddError("Schema Error", fmt.Sprintf("Unable to normalize schema JSON: %s", err))
-			return
-		}
-		data.SchemaJSON = types.StringValue(normalizedJSON)
+	// schema_json_file_sha256 tracks the file, so a schema changed outside of
+	// Terraform is reported rather than written to state
+	if !data.SchemaJSONFile.IsNull() && data.SchemaJSONFile.ValueString() != "" {
+		resp.Diagnostics.Append(schemaFileDrift(classResponse.ID, classResponse.Schema, data.SchemaJSONFile.ValueString())...)
 	}
 
 	// Save updated data into Terraform state
@@ -352,6 +481,11 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(provision.CheckUpdatable(ctx, req.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ResourceModel
 
 	// Read Terraform plan data into the model
@@ -361,76 +495,70 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
-	// Validate that exactly one schema method is provided (either block or JSON)
+	// Which schema method is used (block, JSON, file or source class). They
+	// are mutually exclusive, which ValidateConfig and the attribute
+	// validators check before apply.
 	hasSchemaBlock := len(data.Schema) > 0
 	hasSchemaJSON := !data.SchemaJSON.IsNull() && !data.SchemaJSON.IsUnknown() && data.SchemaJSON.ValueString() != ""
+	hasSchemaJSONFile := !data.SchemaJSONFile.IsNull() && !data.SchemaJSONFile.IsUnknown() && data.SchemaJSONFile.ValueString() != ""
+	hasSourceClass := !data.SourceClassId.IsNull() && !data.SourceClassId.IsUnknown() && data.SourceClassId.ValueString() != ""
 
-	if hasSchemaBlock && hasSchemaJSON {
-		resp.Diagnostics.AddError("Schema Error", "Cannot specify both schema block and schema_json attribute. Choose one.")
-		return
-	}
-
-	if !hasSchemaBlock && !hasSchemaJSON {
-		resp.Diagnostics.AddError("Schema Error", "Either schema block or schema_json attribute must be provided")
+	if !hasSchemaBlock && !hasSchemaJSON && !hasSchemaJSONFile && !hasSourceClass {
+		resp.Diagnostics.AddError("Schema Error", "Either schema block or schema_json attribute must be provided, or schema_json_file to load the schema from a file, or source_class_id to copy the schema from another class")
 		return
 	}
 
 	var schemaMap map[string]any
+	var schemaFileJSON string
 
-	if hasSchemaBlock {
+	if hasSourceClass {
+		var err error
+		schemaMap, err = r.sourceClassSchema(data.SourceClassId.ValueString(), data.Overrides)
+		if err != nil {
+			resp.Diagnostics.AddError("Schema Error", err.Error())
+			return
+		}
+	} else if hasSchemaBlock {
 		// Validate that exactly one schema block is provided
 		if len(data.Schema) != 1 {
 			resp.Diagnostics.AddError("Schema Error", "Exactly one schema block must be provided")
 			return
 		}
 
-		schemaBlock := data.Schema[0]
-
-		// Build schema map from block attributes
-		schemaMap = map[string]any{
-			"title":       schemaBlock.Title.ValueString(),
-			"description": schemaBlock.Description.ValueString(),
-			"type":        schemaBlock.Type.ValueString(),
-		}
-
-		// Add properties if provided
-		if !schemaBlock.Properties.IsNull() && !schemaBlock.Properties.IsUnknown() {
-			var propertiesMap map[string]any
-			if err := json.Unmarshal([]byte(schemaBlock.Properties.ValueString()), &propertiesMap); err != nil {
-				resp.Diagnostics.AddError("Schema Error", fmt.Sprintf("Unable to parse properties JSON: %s", err))
-				return
-			}
-			schemaMap["properties"] = propertiesMap
+		var diags diag.Diagnostics
+		schemaMap, diags = schemaBlockMap(ctx, data.Schema[0])
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
-
-		// Add required fields if provided
-		if !schemaBlock.Required.IsNull() && !schemaBlock.Required.IsUnknown() {
-			var requiredList []string
-			resp.Diagnostics.Append(schemaBlock.Required.ElementsAs(ctx, &requiredList, false)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-			schemaMap["required"] = requiredList
+	} else if hasSchemaJSONFile {
+		var err error
+		schemaFileJSON, err = readSchemaFile(data.SchemaJSONFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Schema Error", err.Error())
+			return
 		}
 
-		// Add strict if provided
-		if !schemaBlock.Strict.IsNull() && !schemaBlock.Strict.IsUnknown() {
-			schemaMap["strict"] = schemaBlock.Strict.ValueBool()
+		var diags diag.Diagnostics
+		schemaMap, diags = parseSchemaJSON(schemaFileJSON)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
 	} else {
-		// Parse schema JSON string
-		if err := json.Unmarshal([]byte(data.SchemaJSON.ValueString()), &schemaMap); err != nil {
-			resp.Diagnostics.AddError("Schema Error", fmt.Sprintf("Unable to parse schema JSON: %s", err))
+		var diags diag.Diagnostics
+		schemaMap, diags = parseSchemaJSON(data.SchemaJSON.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
+	}
 
-		// Validate required fields in JSON schema
-		if _, ok := schemaMap["title"]; !ok {
-			resp.Diagnostics.AddError("Schema Error", "JSON schema must include 'title' field")
-			return
-		}
-		if _, ok := schemaMap["description"]; !ok {
-			resp.Diagnostics.AddError("Schema Error", "JSON schema must include 'description' field")
+	if data.PreserveKeyOrder.ValueBool() {
+		var err error
+		schemaMap, err = authoredSchemaMap(data, schemaMap)
+		if err != nil {
+			resp.Diagnostics.AddError("Schema Error", err.Error())
 			return
 		}
 	}
@@ -447,9 +575,9 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		"schema": schemaMap,
 	})
 
-	classResponse, err := r.client.Neural.UpdateClass(data.Id.ValueString(), updateRequest)
+	classResponse, err := r.client.UpdateClass(data.Id.ValueString(), updateRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update class, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to update class, got error: %s", err)))
 		return
 	}
 
@@ -460,30 +588,30 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	data.ProvisionState = types.StringValue(classResponse.ProvisionState)
 	data.SpaceId = types.StringValue(classResponse.SpaceID)
 
-	// Update schema based on which method was used
+	// The specification is only known for imported classes
+	if data.SpecificationId.IsUnknown() {
+		data.SpecificationId = types.StringNull()
+	}
+
+	// Update schema based on which method was used. A schema copied from a
+	// source class is not tracked in state.
 	if hasSchemaBlock {
 		err = r.updateSchemaFromResponse(ctx, classResponse.Schema, &data)
 		if err != nil {
 			resp.Diagnostics.AddError("Schema Error", fmt.Sprintf("Unable to update schema from response: %s", err))
 			return
 		}
-	} else {
+	} else if hasSchemaJSON {
 		// Update schema_json with response, but normalize to match plan modifier behavior
-		schemaJSON, err := json.Marshal(classResponse.Schema)
-		if err != nil {
-			resp.Diagnostics.AddError("Schema Error", fmt.Sprintf("Unable to marshal schema to JSON: %s", err))
-			return
-		}
-
-		// Normalize the marshaled JSON to ensure consistent formatting
-		normalizedJSON, err := internalplanmodifier.NormalizeJSON(string(schemaJSON))
+		data.SchemaJSON, err = schemaJSONFromResponse(classResponse.Schema, data.SchemaJSON)
 		if err != nil {
-			resp.Diagnostics.AddError("Schema Error", fmt.Sprintf("Unable to normalize schema JSON: %s", err))
+			resp.Diagnostics.AddError("Schema Error", err.Error())
 			return
 		}
-		data.SchemaJSON = types.StringValue(normalizedJSON)
 	}
 
+	data.SchemaJSONFileSHA256 = schemaFileSHA256(hasSchemaJSONFile, schemaFileJSON)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -503,48 +631,368 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		"id": data.Id.ValueString(),
 	})
 
-	err := r.client.Neural.DeleteClass(data.Id.ValueString())
+	err := r.client.DeleteClass(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete class, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to delete class, got error: %s", err)))
 		return
 	}
 }
 
 func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Get class from API to populate state
-	classResponse, err := r.client.Neural.GetClass(req.ID)
+	// Classes generated from a specification are imported as
+	// <specification_id>/<name>, as the class response does not say which
+	// specification a class belongs to
+	specificationId := types.StringNull()
+
+	var classResponse *neural.Class
+	var err error
+	if specificationID, name, found := strings.Cut(req.ID, "/"); found {
+		classResponse, err = r.client.GetClassBySpecificationAndName(specificationID, name)
+		specificationId = types.StringValue(specificationID)
+	} else {
+		classResponse, err = r.client.GetClass(req.ID)
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import class, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to import class, got error: %s", err)))
 		return
 	}
 
 	// Create model from API response
 	data := ResourceModel{
-		Id:             types.StringValue(classResponse.ID),
-		Name:           types.StringValue(classResponse.Name),
-		Description:    types.StringValue(classResponse.Description),
-		ProvisionState: types.StringValue(classResponse.ProvisionState),
-		SpaceId:        types.StringValue(classResponse.SpaceID),
+		Id:              types.StringValue(classResponse.ID),
+		Name:            types.StringValue(classResponse.Name),
+		Description:     types.StringValue(classResponse.Description),
+		ProvisionState:  types.StringValue(classResponse.ProvisionState),
+		SpaceId:         types.StringValue(classResponse.SpaceID),
+		SpecificationId: specificationId,
+	}
+
+	// Import into a single schema representation so the imported state
+	// matches the state of a class created with it: the schema block when
+	// it can hold the whole schema, schema_json otherwise
+	if schemaBlockRepresentable(classResponse.Schema) {
+		err = r.updateSchemaFromResponse(ctx, classResponse.Schema, &data)
+		if err != nil {
+			resp.Diagnostics.AddError("Schema Error", fmt.Sprintf("Unable to update schema from response: %s", err))
+			return
+		}
+		data.SchemaJSON = types.StringNull()
+	} else {
+		data.SchemaJSON, err = schemaJSONFromResponse(classResponse.Schema, types.StringNull())
+		if err != nil {
+			resp.Diagnostics.AddError("Schema Error", err.Error())
+			return
+		}
+	}
+
+	// Save imported data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// parseSchemaJSON parses a JSON schema given through schema_json or
+// schema_json_file and checks the fields the API requires.
+func parseSchemaJSON(raw string) (map[string]any, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var schemaMap map[string]any
+	if err := json.Unmarshal([]byte(raw), &schemaMap); err != nil {
+		diags.AddError("Schema Error", fmt.Sprintf("Unable to parse schema JSON: %s", err))
+		return nil, diags
+	}
+
+	// Validate required fields in JSON schema
+	if _, ok := schemaMap["title"]; !ok {
+		diags.AddError("Schema Error", "JSON schema must include 'title' field")
+		return nil, diags
+	}
+	if _, ok := schemaMap["description"]; !ok {
+		diags.AddError("Schema Error", "JSON schema must include 'description' field")
+		return nil, diags
+	}
+
+	return schemaMap, diags
+}
+
+// checkSchemaSize warns when the planned schema, normalized, is larger than
+// the schema_size_warn_bytes provider setting. Schemas which are unknown at
+// plan time, invalid, or copied from a source class are not checked.
+func (r *Resource) checkSchemaSize(ctx context.Context, plan tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if r.schemaSizeWarnBytes <= 0 {
+		return diags
 	}
 
-	// For import, populate both schema formats to maintain compatibility
-	// Update schema block with response data
-	err = r.updateSchemaFromResponse(ctx, classResponse.Schema, &data)
+	var data ResourceModel
+	diags.Append(plan.Get(ctx, &data)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	var normalized string
+	var attribute path.Path
+
+	switch {
+	case !data.SchemaJSON.IsNull() && !data.SchemaJSON.IsUnknown() && data.SchemaJSON.ValueString() != "":
+		schemaJSON, err := internalplanmodifier.NormalizeJSON(data.SchemaJSON.ValueString())
+		if err != nil {
+			return diags
+		}
+		normalized = schemaJSON
+		attribute = path.Root("schema_json")
+	case !data.SchemaJSONFile.IsNull() && !data.SchemaJSONFile.IsUnknown() && data.SchemaJSONFile.ValueString() != "":
+		schemaFileJSON, err := readSchemaFile(data.SchemaJSONFile.ValueString())
+		if err != nil {
+			return diags
+		}
+		normalized = schemaFileJSON
+		attribute = path.Root("schema_json_file")
+	case len(data.Schema) == 1:
+		schemaMap, blockDiags := schemaBlockMap(ctx, data.Schema[0])
+		if blockDiags.HasError() {
+			return diags
+		}
+		schemaJSON, err := json.Marshal(schemaMap)
+		if err != nil {
+			return diags
+		}
+		normalized = string(schemaJSON)
+		attribute = path.Root("schema")
+	default:
+		return diags
+	}
+
+	if size := int64(len(normalized)); size > r.schemaSizeWarnBytes {
+		diags.AddAttributeWarning(
+			attribute,
+			"Large Class Schema",
+			fmt.Sprintf("The normalized schema is %d bytes, above the schema_size_warn_bytes limit of %d bytes. "+
+				"Large schemas bloat the state and slow down plans, consider splitting the class into smaller classes.", size, r.schemaSizeWarnBytes),
+		)
+	}
+
+	return diags
+}
+
+// schemaBlockMap builds the schema sent to the API from a schema block.
+func schemaBlockMap(ctx context.Context, schemaBlock SchemaModel) (map[string]any, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	schemaMap := map[string]any{
+		"title":       schemaBlock.Title.ValueString(),
+		"description": schemaBlock.Description.ValueString(),
+		"type":        schemaBlock.Type.ValueString(),
+	}
+
+	// Add properties if provided
+	if !schemaBlock.Properties.IsNull() && !schemaBlock.Properties.IsUnknown() {
+		var propertiesMap map[string]any
+		if err := json.Unmarshal([]byte(schemaBlock.Properties.ValueString()), &propertiesMap); err != nil {
+			diags.AddError("Schema Error", fmt.Sprintf("Unable to parse properties JSON: %s", err))
+			return nil, diags
+		}
+		schemaMap["properties"] = propertiesMap
+	}
+
+	// Add required fields if provided
+	if !schemaBlock.Required.IsNull() && !schemaBlock.Required.IsUnknown() {
+		var requiredList []string
+		diags.Append(schemaBlock.Required.ElementsAs(ctx, &requiredList, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		schemaMap["required"] = requiredList
+	}
+
+	// Add strict if provided
+	if !schemaBlock.Strict.IsNull() && !schemaBlock.Strict.IsUnknown() {
+		schemaMap["strict"] = schemaBlock.Strict.ValueBool()
+	}
+
+	return schemaMap, diags
+}
+
+// authoredSchemaMap returns schemaMap with the values taken from the
+// configured JSON replaced by their compacted, authored text, so nested object
+// keys are submitted in the order they were written. The client sends the
+// schema as a map, so its top level keys are still sorted. A schema copied
+// from a source class has no authored text and is returned as is.
+func authoredSchemaMap(data ResourceModel, schemaMap map[string]any) (map[string]any, error) {
+	var authored string
+
+	switch {
+	case len(data.Schema) == 1:
+		properties := data.Schema[0].Properties
+		if properties.IsNull() || properties.IsUnknown() {
+			return schemaMap, nil
+		}
+
+		compacted, err := internalplanmodifier.CompactJSON(properties.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse properties JSON: %s", err)
+		}
+
+		ordered := maps.Clone(schemaMap)
+		ordered["properties"] = json.RawMessage(compacted)
+		return ordered, nil
+	case !data.SchemaJSONFile.IsNull() && data.SchemaJSONFile.ValueString() != "":
+		contents, err := os.ReadFile(data.SchemaJSONFile.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("unable to read schema_json_file: %s", err)
+		}
+		authored = string(contents)
+	case !data.SchemaJSON.IsNull() && data.SchemaJSON.ValueString() != "":
+		authored = data.SchemaJSON.ValueString()
+	default:
+		return schemaMap, nil
+	}
+
+	compacted, err := internalplanmodifier.CompactJSON(authored)
 	if err != nil {
-		resp.Diagnostics.AddError("Schema Error", fmt.Sprintf("Unable to update schema from response: %s", err))
-		return
+		return nil, fmt.Errorf("unable to parse schema JSON: %s", err)
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(compacted), &values); err != nil {
+		return nil, fmt.Errorf("unable to parse schema JSON: %s", err)
 	}
 
-	// Also populate schema_json for convenience
-	schemaJSON, err := json.Marshal(classResponse.Schema)
+	ordered := make(map[string]any, len(values))
+	for key, value := range values {
+		ordered[key] = value
+	}
+
+	return ordered, nil
+}
+
+// readSchemaFile returns the normalized contents of a schema_json_file.
+func readSchemaFile(name string) (string, error) {
+	contents, err := os.ReadFile(name)
 	if err != nil {
-		resp.Diagnostics.AddError("Schema Error", fmt.Sprintf("Unable to marshal schema to JSON: %s", err))
-		return
+		return "", fmt.Errorf("unable to read schema_json_file: %s", err)
 	}
-	data.SchemaJSON = types.StringValue(string(schemaJSON))
 
-	// Save imported data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	normalized, err := internalplanmodifier.NormalizeSchemaJSON(string(contents))
+	if err != nil {
+		return "", fmt.Errorf("unable to parse schema_json_file %s: %s", name, err)
+	}
+
+	return normalized, nil
+}
+
+// schemaSHA256 returns the hex encoded SHA-256 of a normalized schema.
+func schemaSHA256(normalized string) string {
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// schemaFileSHA256 returns schema_json_file_sha256 for state, the hash of
+// the file that was submitted.
+func schemaFileSHA256(hasSchemaJSONFile bool, schemaFileJSON string) types.String {
+	if !hasSchemaJSONFile {
+		return types.StringNull()
+	}
+
+	return types.StringValue(schemaSHA256(schemaFileJSON))
+}
+
+// schemaFileDrift warns when the schema on the server no longer matches
+// schema_json_file. Server defaults for keys the file does not set are not
+// drift.
+func schemaFileDrift(id string, responseSchema map[string]any, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	schemaFileJSON, err := readSchemaFile(name)
+	if err != nil {
+		diags.AddAttributeWarning(path.Root("schema_json_file"), "Schema Error",
+			fmt.Sprintf("Unable to compare the schema of class %s with schema_json_file: %s", id, err))
+		return diags
+	}
+
+	schemaJSON, err := schemaJSONFromResponse(responseSchema, types.StringValue(schemaFileJSON))
+	if err != nil {
+		diags.AddAttributeWarning(path.Root("schema_json_file"), "Schema Error",
+			fmt.Sprintf("Unable to compare the schema of class %s with schema_json_file: %s", id, err))
+		return diags
+	}
+
+	if schemaJSON.ValueString() != schemaFileJSON {
+		diags.AddAttributeWarning(path.Root("schema_json_file"), "Schema Drift",
+			fmt.Sprintf("The schema of class %s was changed outside of Terraform and no longer matches %s. The file is submitted again the next time its contents change.", id, name))
+	}
+
+	return diags
+}
+
+// schemaJSONFromResponse returns the schema_json for state. Server defaults
+// for keys the configuration never set, such as strict, are left out so they
+// do not show up as drift. The configured string is kept when the server
+// schema is equivalent, the order of required entries included.
+func schemaJSONFromResponse(responseSchema map[string]any, configured types.String) (types.String, error) {
+	schemaMap := maps.Clone(responseSchema)
+
+	var configuredMap map[string]any
+	if err := json.Unmarshal([]byte(configured.ValueString()), &configuredMap); err == nil {
+		if _, ok := configuredMap["strict"]; !ok {
+			delete(schemaMap, "strict")
+		}
+	}
+
+	schemaJSON, err := json.Marshal(schemaMap)
+	if err != nil {
+		return types.StringNull(), fmt.Errorf("unable to marshal schema to JSON: %s", err)
+	}
+
+	// Normalize the marshaled JSON to ensure consistent formatting
+	normalizedJSON, err := internalplanmodifier.NormalizeJSON(string(schemaJSON))
+	if err != nil {
+		return types.StringNull(), fmt.Errorf("unable to normalize schema JSON: %s", err)
+	}
+
+	return internalplanmodifier.KeepEquivalent(configured, normalizedJSON, internalplanmodifier.NormalizeSchemaJSON), nil
+}
+
+// sourceClassSchema returns the schema of the class identified by
+// sourceClassID with overrides, a JSON object, deep merged on top.
+func (r *Resource) sourceClassSchema(sourceClassID string, overrides types.String) (map[string]any, error) {
+	sourceClass, err := r.client.GetClass(sourceClassID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read source class %q: %s", sourceClassID, err)
+	}
+
+	schemaMap := mergeSchema(map[string]any{}, sourceClass.Schema)
+
+	if !overrides.IsNull() && !overrides.IsUnknown() && overrides.ValueString() != "" {
+		var overridesMap map[string]any
+		if err := json.Unmarshal([]byte(overrides.ValueString()), &overridesMap); err != nil {
+			return nil, fmt.Errorf("unable to parse overrides JSON: %s", err)
+		}
+		schemaMap = mergeSchema(schemaMap, overridesMap)
+	}
+
+	return schemaMap, nil
+}
+
+// mergeSchema returns a copy of base with overrides deep merged on top.
+// Nested objects are merged key by key, any other override value replaces
+// the value in base.
+func mergeSchema(base, overrides map[string]any) map[string]any {
+	merged := make(map[string]any, len(base))
+	maps.Copy(merged, base)
+
+	for key, value := range overrides {
+		overrideMap, overrideIsMap := value.(map[string]any)
+		baseMap, baseIsMap := merged[key].(map[string]any)
+
+		if overrideIsMap && baseIsMap {
+			merged[key] = mergeSchema(baseMap, overrideMap)
+			continue
+		}
+
+		merged[key] = value
+	}
+
+	return merged
 }
 
 // updateSchemaFromResponse updates the schema block in the resource model from the API response.
@@ -573,6 +1021,11 @@ func (r *Resource) updateSchemaFromResponse(ctx context.Context, responseSchema
 			return fmt.Errorf("unable to marshal properties: %s", err)
 		}
 		schemaBlock.Properties = types.StringValue(string(propertiesJSON))
+
+		// Keep the configured properties when the server returns the same
+		if len(data.Schema) == 1 {
+			schemaBlock.Properties = internalplanmodifier.KeepEquivalent(data.Schema[0].Properties, string(propertiesJSON), internalplanmodifier.NormalizeSchemaJSON)
+		}
 	} else {
 		schemaBlock.Properties = types.StringNull()
 	}
@@ -590,6 +1043,11 @@ func (r *Resource) updateSchemaFromResponse(ctx context.Context, responseSchema
 			return fmt.Errorf("unable to create required list")
 		}
 		schemaBlock.Required = requiredList
+
+		// Keep the configured order when only the order differs
+		if len(data.Schema) == 1 && sameRequired(ctx, data.Schema[0].Required, requiredStrings) {
+			schemaBlock.Required = data.Schema[0].Required
+		}
 	} else {
 		schemaBlock.Required = types.ListNull(types.StringType)
 	}
@@ -604,3 +1062,56 @@ func (r *Resource) updateSchemaFromResponse(ctx context.Context, responseSchema
 	data.Schema = []SchemaModel{schemaBlock}
 	return nil
 }
+
+// schemaBlockFields are the schema keys the schema block can hold.
+var schemaBlockFields = []string{"title", "description", "type", "properties", "required", "strict"}
+
+// schemaBlockRepresentable reports whether responseSchema can be held by the
+// schema block without losing any keys.
+func schemaBlockRepresentable(responseSchema map[string]any) bool {
+	for _, field := range []string{"title", "description", "type"} {
+		if _, ok := responseSchema[field].(string); !ok {
+			return false
+		}
+	}
+
+	for key := range responseSchema {
+		if !slices.Contains(schemaBlockFields, key) {
+			return false
+		}
+	}
+
+	if required, ok := responseSchema["required"]; ok {
+		if _, ok := required.([]any); !ok {
+			return false
+		}
+	}
+
+	if strict, ok := responseSchema["strict"]; ok {
+		if _, ok := strict.(bool); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sameRequired reports whether current holds the same required entries as
+// server, regardless of order.
+func sameRequired(ctx context.Context, current types.List, server []string) bool {
+	if current.IsNull() || current.IsUnknown() {
+		return false
+	}
+
+	var currentStrings []string
+	if diags := current.ElementsAs(ctx, &currentStrings, false); diags.HasError() {
+		return false
+	}
+
+	currentSorted := slices.Clone(currentStrings)
+	serverSorted := slices.Clone(server)
+	slices.Sort(currentSorted)
+	slices.Sort(serverSorted)
+
+	return slices.Equal(currentSorted, serverSorted)
+}
@@ -6,7 +6,10 @@ package class_test
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -40,7 +43,7 @@ func TestAccClassResource(t *testing.T) {
 			{
 				ResourceName:      "tama_class.test",
 				ImportState:       true,
-				ImportStateVerify: false, // Skip verification due to schema format differences
+				ImportStateVerify: true,
 			},
 			// Update and Read testing
 			{
@@ -113,7 +116,7 @@ func TestAccClassResource_BothSchemaTypes(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config:      testAccClassResourceConfigBothSchemas(fmt.Sprintf("test-space-%d", time.Now().UnixNano())),
-				ExpectError: regexp.MustCompile("Cannot specify both schema block and schema_json attribute"),
+				ExpectError: regexp.MustCompile(`Attribute "schema_json" cannot be specified together with the schema`),
 			},
 		},
 	})
@@ -203,6 +206,220 @@ func TestAccClassResource_JSONEncoding(t *testing.T) {
 	})
 }
 
+func TestAccClassResource_OmittedStrict(t *testing.T) {
+	spaceName := fmt.Sprintf("test-space-%d", time.Now().UnixNano())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClassResourceConfigWithoutStrict(spaceName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tama_class.block", "id"),
+					resource.TestCheckResourceAttrSet("tama_class.json", "id"),
+					resource.TestCheckNoResourceAttr("tama_class.json", "schema.0.strict"),
+				),
+			},
+			// The server default for strict must not show up as drift
+			{
+				Config:   testAccClassResourceConfigWithoutStrict(spaceName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccClassResource_SchemaJSONFile(t *testing.T) {
+	spaceName := fmt.Sprintf("test-space-%d", time.Now().UnixNano())
+
+	// Copy the schema so the test can change it between steps
+	contents, err := os.ReadFile(filepath.Join("testdata", "collection_schema.json"))
+	if err != nil {
+		t.Fatalf("unable to read testdata schema: %s", err)
+	}
+	schemaFile := filepath.Join(t.TempDir(), "collection_schema.json")
+	if err := os.WriteFile(schemaFile, contents, 0o600); err != nil {
+		t.Fatalf("unable to write schema file: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClassResourceConfigWithJSONFile(spaceName, schemaFile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tama_class.test", "id"),
+					resource.TestCheckResourceAttr("tama_class.test", "name", "collection"),
+					resource.TestCheckResourceAttr("tama_class.test", "description", "A collection is a group of entities that can be queried."),
+					resource.TestCheckResourceAttr("tama_class.test", "schema_json_file", schemaFile),
+					resource.TestCheckResourceAttrSet("tama_class.test", "schema_json_file_sha256"),
+				),
+			},
+			// The schema read back from the server matches the file
+			{
+				Config:   testAccClassResourceConfigWithJSONFile(spaceName, schemaFile),
+				PlanOnly: true,
+			},
+			// Editing the file is planned as an update
+			{
+				PreConfig: func() {
+					updated := strings.Replace(string(contents), "A collection is a group of entities that can be queried.", "A queryable group of entities.", 1)
+					if err := os.WriteFile(schemaFile, []byte(updated), 0o600); err != nil {
+						t.Fatalf("unable to update schema file: %s", err)
+					}
+				},
+				Config: testAccClassResourceConfigWithJSONFile(spaceName, schemaFile),
+				Check:  resource.TestCheckResourceAttr("tama_class.test", "description", "A queryable group of entities."),
+			},
+		},
+	})
+}
+
+func TestAccClassResource_SchemaJSONFileConflict(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: acceptance.ProviderConfig + `
+resource "tama_class" "test" {
+  space_id         = "space-id"
+  schema_json      = jsonencode({ title = "collection", description = "A collection" })
+  schema_json_file = "testdata/collection_schema.json"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func testAccClassResourceConfigWithoutStrict(spaceName string) string {
+	return acceptance.ProviderConfig + fmt.Sprintf(`
+resource "tama_space" "test" {
+  name = %[1]q
+  type = "root"
+}
+
+resource "tama_class" "block" {
+  space_id = tama_space.test.id
+
+  schema {
+    title       = "block-entity"
+    description = "An entity defined with a schema block"
+    type        = "object"
+    properties = jsonencode({
+      name = {
+        type = "string"
+      }
+    })
+  }
+}
+
+resource "tama_class" "json" {
+  space_id = tama_space.test.id
+  schema_json = jsonencode({
+    title       = "json-entity"
+    description = "An entity defined with schema_json"
+    type        = "object"
+  })
+}
+`, spaceName)
+}
+
+func TestAccClassResource_SourceClass(t *testing.T) {
+	spaceName := fmt.Sprintf("test-space-%d", time.Now().UnixNano())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClassResourceConfigWithSourceClass(spaceName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tama_class.derived", "id"),
+					resource.TestCheckResourceAttrPair("tama_class.derived", "source_class_id", "tama_class.base", "id"),
+					resource.TestCheckResourceAttr("tama_class.derived", "name", "derived-collection"),
+					resource.TestCheckResourceAttr("data.tama_class.derived", "schema.0.title", "derived-collection"),
+					resource.TestCheckResourceAttrPair("data.tama_class.derived", "schema.0.description", "data.tama_class.base", "schema.0.description"),
+					resource.TestCheckResourceAttrPair("data.tama_class.derived", "schema.0.type", "data.tama_class.base", "schema.0.type"),
+					resource.TestCheckResourceAttrWith("data.tama_class.derived", "schema.0.properties", func(value string) error {
+						var properties map[string]any
+						if err := json.Unmarshal([]byte(value), &properties); err != nil {
+							return fmt.Errorf("failed to parse properties JSON: %v", err)
+						}
+
+						for _, name := range []string{"space", "name", "tags"} {
+							if _, ok := properties[name]; !ok {
+								return fmt.Errorf("expected property %q in derived schema, got %v", name, properties)
+							}
+						}
+
+						return nil
+					}),
+				),
+			},
+			// Re-applying the same configuration must not produce a diff
+			{
+				Config:   testAccClassResourceConfigWithSourceClass(spaceName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccClassResourceConfigWithSourceClass(spaceName string) string {
+	return acceptance.ProviderConfig + fmt.Sprintf(`
+resource "tama_space" "test" {
+  name = %[1]q
+  type = "root"
+}
+
+resource "tama_class" "base" {
+  space_id = tama_space.test.id
+  schema_json = jsonencode({
+    title       = "base-collection"
+    description = "A collection is a group of entities that can be queried."
+    type        = "object"
+    properties = {
+      space = {
+        type        = "string"
+        description = "Slug of the space"
+      }
+      name = {
+        type        = "string"
+        description = "The name of the collection"
+      }
+    }
+  })
+}
+
+resource "tama_class" "derived" {
+  space_id        = tama_space.test.id
+  source_class_id = tama_class.base.id
+  overrides = jsonencode({
+    title = "derived-collection"
+    properties = {
+      tags = {
+        type        = "array"
+        description = "Tags attached to the collection"
+      }
+    }
+  })
+}
+
+data "tama_class" "base" {
+  id = tama_class.base.id
+}
+
+data "tama_class" "derived" {
+  id = tama_class.derived.id
+}
+`, spaceName)
+}
+
 func testAccClassResourceConfigWithBlock(spaceName string) string {
 	return acceptance.ProviderConfig + fmt.Sprintf(`
 resource "tama_space" "test" {
@@ -286,6 +503,20 @@ resource "tama_class" "test" {
 `, spaceName)
 }
 
+func testAccClassResourceConfigWithJSONFile(spaceName, schemaFile string) string {
+	return acceptance.ProviderConfig + fmt.Sprintf(`
+resource "tama_space" "test" {
+  name = %[1]q
+  type = "root"
+}
+
+resource "tama_class" "test" {
+  space_id         = tama_space.test.id
+  schema_json_file = %[2]q
+}
+`, spaceName, schemaFile)
+}
+
 func testAccClassResourceConfigComplexBlock(spaceName string) string {
 	return acceptance.ProviderConfig + fmt.Sprintf(`
 resource "tama_space" "test" {
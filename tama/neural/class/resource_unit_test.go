@@ -0,0 +1,1044 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package class
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/upmaru/tama-go/neural"
+	"github.com/upmaru/terraform-provider-tama/internal/fake"
+)
+
+func testResourceSchema(t *testing.T, r *Resource) resource.SchemaResponse {
+	t.Helper()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	return schemaResp
+}
+
+func testCreate(t *testing.T, r *Resource, data ResourceModel) (*resource.CreateResponse, ResourceModel) {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := testResourceSchema(t, r)
+	tfType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(tfType, nil)}
+	if diags := plan.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unable to build plan: %v", diags)
+	}
+
+	resp := &resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(tfType, nil)},
+	}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+
+	var result ResourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &result)...)
+	}
+
+	return resp, result
+}
+
+func testRead(t *testing.T, r *Resource, data ResourceModel) (*resource.ReadResponse, ResourceModel) {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := testResourceSchema(t, r)
+	tfType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(tfType, nil)}
+	if diags := state.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unable to build state: %v", diags)
+	}
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+	var result ResourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &result)...)
+	}
+
+	return resp, result
+}
+
+func newTestModel() ResourceModel {
+	return ResourceModel{
+		Id:             types.StringUnknown(),
+		Name:           types.StringUnknown(),
+		Description:    types.StringUnknown(),
+		SchemaJSON:     types.StringNull(),
+		ProvisionState: types.StringUnknown(),
+		SpaceId:        types.StringValue("space-1"),
+	}
+}
+
+func TestResourceCreate_SchemaBlock(t *testing.T) {
+	t.Parallel()
+
+	classes := fake.NewClasses()
+	r := &Resource{client: classes}
+
+	data := newTestModel()
+	data.Schema = []SchemaModel{{
+		Title:       types.StringValue("action-call"),
+		Description: types.StringValue("An action call"),
+		Type:        types.StringValue("object"),
+		Properties:  types.StringValue(`{"tool_id":{"type":"string"}}`),
+		Required:    types.ListValueMust(types.StringType, []attr.Value{types.StringValue("tool_id")}),
+		Strict:      types.BoolValue(true),
+	}}
+
+	resp, state := testCreate(t, r, data)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(classes.CreateRequests) != 1 {
+		t.Fatalf("expected 1 create request, got %d", len(classes.CreateRequests))
+	}
+
+	sent := classes.CreateRequests[0].Class.Schema
+	if sent["title"] != "action-call" || sent["description"] != "An action call" || sent["type"] != "object" {
+		t.Errorf("unexpected schema header sent: %v", sent)
+	}
+	if sent["strict"] != true {
+		t.Errorf("expected strict to be sent, got %v", sent["strict"])
+	}
+	if required, ok := sent["required"].([]string); !ok || len(required) != 1 || required[0] != "tool_id" {
+		t.Errorf("unexpected required sent: %#v", sent["required"])
+	}
+	if _, ok := sent["properties"].(map[string]any)["tool_id"]; !ok {
+		t.Errorf("expected properties to be decoded into the request, got %#v", sent["properties"])
+	}
+
+	if state.Id.ValueString() != "class-1" {
+		t.Errorf("expected id class-1, got %s", state.Id)
+	}
+	if state.Name.ValueString() != "action-call" {
+		t.Errorf("expected name action-call, got %s", state.Name)
+	}
+	if state.ProvisionState.ValueString() != "active" {
+		t.Errorf("expected provision_state active, got %s", state.ProvisionState)
+	}
+	if len(state.Schema) != 1 || state.Schema[0].Properties.ValueString() != `{"tool_id":{"type":"string"}}` {
+		t.Errorf("unexpected schema block in state: %#v", state.Schema)
+	}
+}
+
+func TestResourceCreate_SchemaJSON(t *testing.T) {
+	t.Parallel()
+
+	classes := fake.NewClasses()
+	r := &Resource{client: classes}
+
+	data := newTestModel()
+	data.SchemaJSON = types.StringValue(`{"type":"object","title":"entity","description":"An entity"}`)
+
+	resp, state := testCreate(t, r, data)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	// The server returns the same schema, so the configured string is kept
+	if !state.SchemaJSON.Equal(data.SchemaJSON) {
+		t.Errorf("expected configured schema_json %s, got %s", data.SchemaJSON.ValueString(), state.SchemaJSON.ValueString())
+	}
+	if state.Description.ValueString() != "An entity" {
+		t.Errorf("expected description from response, got %s", state.Description)
+	}
+}
+
+func TestResourceCreate_SchemaJSONFile(t *testing.T) {
+	t.Parallel()
+
+	schemaFile := filepath.Join(t.TempDir(), "schema.json")
+	contents := `{
+  "type": "object",
+  "title": "entity",
+  "description": "An entity"
+}`
+	if err := os.WriteFile(schemaFile, []byte(contents), 0o600); err != nil {
+		t.Fatalf("unable to write schema file: %s", err)
+	}
+
+	classes := fake.NewClasses()
+	r := &Resource{client: classes}
+
+	data := newTestModel()
+	data.SchemaJSONFile = types.StringValue(schemaFile)
+	data.SchemaJSONFileSHA256 = types.StringUnknown()
+
+	resp, state := testCreate(t, r, data)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if title := classes.CreateRequests[0].Class.Schema["title"]; title != "entity" {
+		t.Errorf("expected schema loaded from file, got title %v", title)
+	}
+
+	expected := schemaSHA256(`{"description":"An entity","title":"entity","type":"object"}`)
+	if state.SchemaJSONFileSHA256.ValueString() != expected {
+		t.Errorf("expected schema_json_file_sha256 %s, got %s", expected, state.SchemaJSONFileSHA256)
+	}
+	if !state.SchemaJSON.IsNull() {
+		t.Errorf("expected schema_json to stay null, got %s", state.SchemaJSON)
+	}
+
+	// A server default the file does not set is not drift
+	classes.Classes[state.Id.ValueString()].Schema["strict"] = true
+
+	readResp, refreshed := testRead(t, r, state)
+	if len(readResp.Diagnostics) != 0 {
+		t.Errorf("expected no diagnostics, got %v", readResp.Diagnostics)
+	}
+	if refreshed.SchemaJSONFileSHA256.ValueString() != expected {
+		t.Errorf("expected schema_json_file_sha256 %s after read, got %s", expected, refreshed.SchemaJSONFileSHA256)
+	}
+
+	// A schema changed on the server is reported, the hash keeps tracking the file
+	classes.Classes[state.Id.ValueString()].Schema["description"] = "Changed upstream"
+
+	readResp, refreshed = testRead(t, r, state)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+	}
+	if readResp.Diagnostics.WarningsCount() != 1 || readResp.Diagnostics.Warnings()[0].Summary() != "Schema Drift" {
+		t.Errorf("expected a schema drift warning, got %v", readResp.Diagnostics)
+	}
+	if refreshed.SchemaJSONFileSHA256.ValueString() != expected {
+		t.Errorf("expected schema_json_file_sha256 %s after read, got %s", expected, refreshed.SchemaJSONFileSHA256)
+	}
+
+	// A file that can no longer be read is reported as a warning
+	if err := os.Remove(schemaFile); err != nil {
+		t.Fatalf("unable to remove schema file: %s", err)
+	}
+
+	readResp, _ = testRead(t, r, state)
+	if readResp.Diagnostics.HasError() || readResp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("expected a single warning for a missing file, got %v", readResp.Diagnostics)
+	}
+}
+
+func TestResourceCreate_ServerDefaultStrict(t *testing.T) {
+	t.Parallel()
+
+	t.Run("schema block", func(t *testing.T) {
+		t.Parallel()
+
+		classes := fake.NewClasses()
+		classes.Defaults = map[string]any{"strict": false}
+		r := &Resource{client: classes}
+
+		data := newTestModel()
+		data.Schema = []SchemaModel{{
+			Title:       types.StringValue("entity"),
+			Description: types.StringValue("An entity"),
+			Type:        types.StringValue("object"),
+			Required:    types.ListNull(types.StringType),
+			Strict:      types.BoolUnknown(),
+		}}
+
+		resp, state := testCreate(t, r, data)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		if _, ok := classes.CreateRequests[0].Class.Schema["strict"]; ok {
+			t.Error("expected strict not to be sent when it is not configured")
+		}
+		if len(state.Schema) != 1 || state.Schema[0].Strict.IsNull() || state.Schema[0].Strict.ValueBool() {
+			t.Errorf("expected strict to be read back from the server default, got %#v", state.Schema)
+		}
+	})
+
+	t.Run("schema_json", func(t *testing.T) {
+		t.Parallel()
+
+		classes := fake.NewClasses()
+		classes.Defaults = map[string]any{"strict": false}
+		r := &Resource{client: classes}
+
+		data := newTestModel()
+		data.SchemaJSON = types.StringValue(`{"description":"An entity","title":"entity"}`)
+
+		resp, state := testCreate(t, r, data)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+
+		if state.SchemaJSON.ValueString() != data.SchemaJSON.ValueString() {
+			t.Errorf("expected server default strict to be left out of schema_json, got %s", state.SchemaJSON)
+		}
+	})
+}
+
+func TestResourceCreate_SourceClass(t *testing.T) {
+	t.Parallel()
+
+	classes := fake.NewClasses()
+	classes.Classes["class-base"] = &neural.Class{
+		ID:      "class-base",
+		SpaceID: "space-1",
+		Schema: map[string]any{
+			"title":       "entity",
+			"description": "An entity",
+			"type":        "object",
+			"properties": map[string]any{
+				"name": map[string]any{"type": "string"},
+			},
+		},
+	}
+	r := &Resource{client: classes}
+
+	data := newTestModel()
+	data.SourceClassId = types.StringValue("class-base")
+	data.Overrides = types.StringValue(`{"title":"person","properties":{"age":{"type":"integer"}}}`)
+
+	resp, state := testCreate(t, r, data)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(classes.CreateRequests) != 1 {
+		t.Fatalf("expected 1 create request, got %d", len(classes.CreateRequests))
+	}
+
+	sent := classes.CreateRequests[0].Class.Schema
+	if sent["title"] != "person" || sent["description"] != "An entity" || sent["type"] != "object" {
+		t.Errorf("expected overrides merged on top of the source schema, got %#v", sent)
+	}
+
+	properties, _ := sent["properties"].(map[string]any)
+	if _, ok := properties["name"]; !ok {
+		t.Errorf("expected source properties to be kept, got %#v", properties)
+	}
+	if _, ok := properties["age"]; !ok {
+		t.Errorf("expected override properties to be added, got %#v", properties)
+	}
+
+	if _, ok := classes.Classes["class-base"].Schema["properties"].(map[string]any)["age"]; ok {
+		t.Error("expected the source class schema to be left untouched")
+	}
+
+	if state.Name.ValueString() != "person" {
+		t.Errorf("expected name person, got %s", state.Name)
+	}
+	if !state.SchemaJSON.IsNull() || len(state.Schema) != 0 {
+		t.Errorf("expected copied schema not to be tracked in state, got %#v / %s", state.Schema, state.SchemaJSON)
+	}
+}
+
+func TestResourceCreate_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		modify   func(*ResourceModel)
+		expected string
+	}{
+		{
+			name:     "no schema",
+			modify:   func(m *ResourceModel) {},
+			expected: "Either schema block or schema_json attribute must be provided",
+		},
+		{
+			name: "missing source class",
+			modify: func(m *ResourceModel) {
+				m.SourceClassId = types.StringValue("class-missing")
+			},
+			expected: "unable to read source class",
+		},
+		{
+			name: "missing schema_json_file",
+			modify: func(m *ResourceModel) {
+				m.SchemaJSONFile = types.StringValue(filepath.Join("testdata", "missing.json"))
+			},
+			expected: "unable to read schema_json_file",
+		},
+		{
+			name: "missing description",
+			modify: func(m *ResourceModel) {
+				m.SchemaJSON = types.StringValue(`{"title":"entity"}`)
+			},
+			expected: "JSON schema must include 'description' field",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			classes := fake.NewClasses()
+			r := &Resource{client: classes}
+
+			data := newTestModel()
+			tt.modify(&data)
+
+			resp, _ := testCreate(t, r, data)
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), tt.expected) {
+				t.Errorf("expected error containing %q, got %v", tt.expected, resp.Diagnostics)
+			}
+			if len(classes.CreateRequests) != 0 {
+				t.Errorf("expected no API call, got %d", len(classes.CreateRequests))
+			}
+		})
+	}
+}
+
+func TestResourceCreate_ClientError(t *testing.T) {
+	t.Parallel()
+
+	classes := fake.NewClasses()
+	classes.Err = errors.New("boom")
+	r := &Resource{client: classes}
+
+	data := newTestModel()
+	data.SchemaJSON = types.StringValue(`{"title":"entity","description":"An entity"}`)
+
+	resp, _ := testCreate(t, r, data)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "Unable to create class, got error: boom") {
+		t.Errorf("unexpected error: %v", resp.Diagnostics)
+	}
+}
+
+func TestResourceRead_MapsResponse(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	classes := fake.NewClasses()
+	classes.Classes["class-1"] = &neural.Class{
+		ID:             "class-1",
+		SpaceID:        "space-1",
+		Name:           "entity",
+		Description:    "Updated upstream",
+		ProvisionState: "active",
+		Schema:         map[string]any{"title": "entity", "description": "Updated upstream", "type": "object"},
+	}
+	r := &Resource{client: classes}
+
+	schemaResp := testResourceSchema(t, r)
+	tfType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	data := newTestModel()
+	data.Id = types.StringValue("class-1")
+	data.Name = types.StringValue("entity")
+	data.Description = types.StringValue("An entity")
+	data.ProvisionState = types.StringValue("active")
+	data.SchemaJSON = types.StringValue(`{"description":"An entity","title":"entity","type":"object"}`)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(tfType, nil)}
+	if diags := state.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unable to build state: %v", diags)
+	}
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var result ResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &result)...)
+
+	if result.Description.ValueString() != "Updated upstream" {
+		t.Errorf("expected description to be refreshed, got %s", result.Description)
+	}
+	if result.SchemaJSON.ValueString() != `{"description":"Updated upstream","title":"entity","type":"object"}` {
+		t.Errorf("expected schema_json to be refreshed, got %s", result.SchemaJSON)
+	}
+}
+
+func TestResourceRead_ArchivedClass(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	classes := fake.NewClasses()
+	classes.Classes["class-1"] = &neural.Class{
+		ID:             "class-1",
+		SpaceID:        "space-1",
+		Name:           "entity",
+		ProvisionState: "archived",
+		Schema:         map[string]any{"title": "entity", "description": "An entity", "type": "object"},
+	}
+	r := &Resource{client: classes}
+
+	data := newTestModel()
+	data.Id = types.StringValue("class-1")
+	data.Name = types.StringValue("entity")
+	data.Description = types.StringValue("An entity")
+	data.ProvisionState = types.StringValue("active")
+	data.SchemaJSON = types.StringValue(`{"description":"An entity","title":"entity","type":"object"}`)
+
+	readResp, refreshed := testRead(t, r, data)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+	}
+	if refreshed.ProvisionState.ValueString() != "archived" {
+		t.Errorf("expected the archived class to stay in state, got provision_state %s", refreshed.ProvisionState)
+	}
+
+	// Updating the archived class fails before any request is sent
+	refreshed.Description = types.StringValue("Changed")
+
+	schemaResp := testResourceSchema(t, r)
+	tfType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(tfType, nil)}
+	if diags := plan.Set(ctx, &refreshed); diags.HasError() {
+		t.Fatalf("unable to build plan: %v", diags)
+	}
+
+	updateResp := &resource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, updateResp)
+
+	if updateResp.Diagnostics.ErrorsCount() != 1 || updateResp.Diagnostics.Errors()[0].Summary() != "Object Not Updatable" {
+		t.Errorf("expected the update to be refused, got %v", updateResp.Diagnostics)
+	}
+	if len(classes.UpdateRequests) != 0 {
+		t.Errorf("expected no update request, got %d", len(classes.UpdateRequests))
+	}
+}
+
+func TestResourceModifyPlan_SchemaSizeWarning(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	properties := make([]string, 0, 200)
+	for i := range 200 {
+		properties = append(properties, fmt.Sprintf(`"field_%d":{"type":"string","description":"A field of the large schema"}`, i))
+	}
+	largeSchema := `{"title":"large","description":"A large schema","type":"object","properties":{` + strings.Join(properties, ",") + `}}`
+
+	tests := []struct {
+		name     string
+		limit    int64
+		schema   string
+		warnings int
+	}{
+		{"large schema warns", 1024, largeSchema, 1},
+		{"small schema", 1024, `{"title":"small","description":"A small schema","type":"object"}`, 0},
+		{"warning disabled", 0, largeSchema, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &Resource{client: fake.NewClasses(), schemaSizeWarnBytes: tt.limit}
+
+			schemaResp := testResourceSchema(t, r)
+			tfType := schemaResp.Schema.Type().TerraformType(ctx)
+
+			data := newTestModel()
+			data.SchemaJSON = types.StringValue(tt.schema)
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(tfType, nil)}
+			if diags := plan.Set(ctx, &data); diags.HasError() {
+				t.Fatalf("unable to build plan: %v", diags)
+			}
+
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if got := resp.Diagnostics.WarningsCount(); got != tt.warnings {
+				t.Fatalf("expected %d warnings, got %v", tt.warnings, resp.Diagnostics)
+			}
+			if tt.warnings > 0 && resp.Diagnostics.Warnings()[0].Summary() != "Large Class Schema" {
+				t.Errorf("unexpected warning: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestResourceRequiredOrder(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	required := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("tool_id"), types.StringValue("code")})
+
+	schemaMap, diags := schemaBlockMap(ctx, SchemaModel{
+		Title:       types.StringValue("action-call"),
+		Description: types.StringValue("An action call"),
+		Type:        types.StringValue("object"),
+		Properties:  types.StringNull(),
+		Required:    required,
+		Strict:      types.BoolNull(),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if sent, ok := schemaMap["required"].([]string); !ok || len(sent) != 2 || sent[0] != "tool_id" || sent[1] != "code" {
+		t.Errorf("expected required to be sent in the configured order, got %#v", schemaMap["required"])
+	}
+
+	classes := fake.NewClasses()
+	classes.Classes["class-1"] = &neural.Class{
+		ID:             "class-1",
+		SpaceID:        "space-1",
+		Name:           "action-call",
+		Description:    "An action call",
+		ProvisionState: "active",
+		Schema: map[string]any{
+			"title":       "action-call",
+			"description": "An action call",
+			"type":        "object",
+			"required":    []any{"code", "tool_id"},
+		},
+	}
+	r := &Resource{client: classes}
+
+	schemaResp := testResourceSchema(t, r)
+	tfType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	data := newTestModel()
+	data.Id = types.StringValue("class-1")
+	data.Name = types.StringValue("action-call")
+	data.Description = types.StringValue("An action call")
+	data.ProvisionState = types.StringValue("active")
+	data.Schema = []SchemaModel{{
+		Title:       types.StringValue("action-call"),
+		Description: types.StringValue("An action call"),
+		Type:        types.StringValue("object"),
+		Properties:  types.StringNull(),
+		Required:    required,
+		Strict:      types.BoolNull(),
+	}}
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(tfType, nil)}
+	if diags := state.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unable to build state: %v", diags)
+	}
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var result ResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &result)...)
+
+	if len(result.Schema) != 1 || !result.Schema[0].Required.Equal(required) {
+		t.Errorf("expected the configured required order to be kept, got %#v", result.Schema)
+	}
+}
+
+func TestResourceValidateConfig_SchemaBlockConflicts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	r := &Resource{}
+	schemaResp := testResourceSchema(t, r)
+	tfType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	block := SchemaModel{
+		Title:       types.StringValue("entity"),
+		Description: types.StringValue("An entity"),
+		Type:        types.StringValue("object"),
+		Properties:  types.StringNull(),
+		Required:    types.ListNull(types.StringType),
+		Strict:      types.BoolNull(),
+	}
+
+	tests := []struct {
+		name   string
+		setup  func(data *ResourceModel)
+		errors int
+	}{
+		{"schema block only", func(data *ResourceModel) { data.Schema = []SchemaModel{block} }, 0},
+		{"source class only", func(data *ResourceModel) { data.SourceClassId = types.StringValue("class-1") }, 0},
+		{"source class with schema block", func(data *ResourceModel) {
+			data.Schema = []SchemaModel{block}
+			data.SourceClassId = types.StringValue("class-1")
+		}, 1},
+		{"schema_json with schema block", func(data *ResourceModel) {
+			data.Schema = []SchemaModel{block}
+			data.SchemaJSON = types.StringValue(`{"title":"entity","description":"An entity"}`)
+		}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			data := newTestModel()
+			data.Id = types.StringNull()
+			tt.setup(&data)
+
+			// tfsdk.Config cannot be set from a model, build it through a state
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(tfType, nil)}
+			if diags := state.Set(ctx, &data); diags.HasError() {
+				t.Fatalf("unable to build config: %v", diags)
+			}
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}
+
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, resp)
+
+			if resp.Diagnostics.ErrorsCount() != tt.errors {
+				t.Errorf("expected %d errors, got %v", tt.errors, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestResourceCreate_NormalizationDisabled(t *testing.T) {
+	t.Parallel()
+
+	classes := fake.NewClasses()
+	r := &Resource{client: classes, disableNormalization: true}
+
+	configured := `{
+  "type": "object",
+  "title": "entity",
+  "description": "An entity"
+}`
+
+	data := newTestModel()
+	data.SchemaJSON = types.StringValue(configured)
+
+	resp, created := testCreate(t, r, data)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if created.SchemaJSON.ValueString() != configured {
+		t.Errorf("expected the configured schema_json after create, got %s", created.SchemaJSON.ValueString())
+	}
+
+	readResp, refreshed := testRead(t, r, created)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+	}
+	if refreshed.SchemaJSON.ValueString() != configured {
+		t.Errorf("expected the configured schema_json after read, got %s", refreshed.SchemaJSON.ValueString())
+	}
+}
+
+func TestResourceSchemaJSONRequiredOrder(t *testing.T) {
+	t.Parallel()
+
+	classes := fake.NewClasses()
+	r := &Resource{client: classes}
+
+	configured := `{"description":"A collection","required":["items","space","name","created_at"],"title":"collection","type":"object"}`
+
+	data := newTestModel()
+	data.SchemaJSON = types.StringValue(configured)
+
+	resp, created := testCreate(t, r, data)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if created.SchemaJSON.ValueString() != configured {
+		t.Errorf("expected the configured schema_json after create, got %s", created.SchemaJSON.ValueString())
+	}
+
+	// The server returning the entries in another order is not drift
+	classes.Classes[created.Id.ValueString()].Schema["required"] = []any{"created_at", "items", "name", "space"}
+
+	readResp, result := testRead(t, r, created)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", readResp.Diagnostics)
+	}
+
+	if result.SchemaJSON.ValueString() != configured {
+		t.Errorf("expected the configured schema_json after read, got %s", result.SchemaJSON.ValueString())
+	}
+}
+
+func TestResourceCreate_PreserveKeyOrder(t *testing.T) {
+	t.Parallel()
+
+	schemaJSON := `{
+  "title": "entity",
+  "description": "An entity",
+  "type": "object",
+  "properties": {"zeta": {"type": "string"}, "alpha": {"type": "integer", "minimum": 1.50}}
+}`
+	authoredProperties := `"properties":{"zeta":{"type":"string"},"alpha":{"type":"integer","minimum":1.50}}`
+
+	schemaFile := filepath.Join(t.TempDir(), "schema.json")
+	if err := os.WriteFile(schemaFile, []byte(schemaJSON), 0o600); err != nil {
+		t.Fatalf("unable to write schema file: %s", err)
+	}
+
+	tests := []struct {
+		name     string
+		preserve types.Bool
+		setup    func(data *ResourceModel)
+		ordered  bool
+	}{
+		{
+			name:     "schema_json",
+			preserve: types.BoolValue(true),
+			setup:    func(data *ResourceModel) { data.SchemaJSON = types.StringValue(schemaJSON) },
+			ordered:  true,
+		},
+		{
+			name:     "schema_json_file",
+			preserve: types.BoolValue(true),
+			setup: func(data *ResourceModel) {
+				data.SchemaJSONFile = types.StringValue(schemaFile)
+				data.SchemaJSONFileSHA256 = types.StringUnknown()
+			},
+			ordered: true,
+		},
+		{
+			name:     "schema block",
+			preserve: types.BoolValue(true),
+			setup: func(data *ResourceModel) {
+				data.Schema = []SchemaModel{{
+					Title:       types.StringValue("entity"),
+					Description: types.StringValue("An entity"),
+					Type:        types.StringValue("object"),
+					Properties:  types.StringValue(`{"zeta": {"type": "string"}, "alpha": {"type": "integer", "minimum": 1.50}}`),
+					Required:    types.ListNull(types.StringType),
+					Strict:      types.BoolNull(),
+				}}
+			},
+			ordered: true,
+		},
+		{
+			name:     "disabled by default",
+			preserve: types.BoolNull(),
+			setup:    func(data *ResourceModel) { data.SchemaJSON = types.StringValue(schemaJSON) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			classes := fake.NewClasses()
+			r := &Resource{client: classes}
+
+			data := newTestModel()
+			data.PreserveKeyOrder = tt.preserve
+			tt.setup(&data)
+
+			resp, state := testCreate(t, r, data)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			sent, err := json.Marshal(classes.CreateRequests[0].Class.Schema)
+			if err != nil {
+				t.Fatalf("unable to marshal sent schema: %s", err)
+			}
+
+			if got := strings.Contains(string(sent), authoredProperties); got != tt.ordered {
+				t.Errorf("expected authored property order to be sent: %t, got %s", tt.ordered, sent)
+			}
+
+			// The response is still mapped back semantically
+			if state.Name.ValueString() != "entity" {
+				t.Errorf("expected name entity, got %s", state.Name)
+			}
+			if !state.SchemaJSON.IsNull() && state.SchemaJSON.ValueString() != schemaJSON {
+				t.Errorf("expected configured schema_json %s, got %s", schemaJSON, state.SchemaJSON.ValueString())
+			}
+		})
+	}
+}
+
+func TestResourceImportState_SpecificationAndName(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	classes := fake.NewClasses()
+	classes.Classes["class-1"] = &neural.Class{
+		ID:             "class-1",
+		SpaceID:        "space-1",
+		Name:           "list-users",
+		Description:    "List users",
+		ProvisionState: "active",
+		Schema:         map[string]any{"title": "list-users", "description": "List users", "type": "object"},
+	}
+	classes.Specifications["class-1"] = "spec-1"
+	r := &Resource{client: classes}
+
+	schemaResp := testResourceSchema(t, r)
+	tfType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	tests := []struct {
+		name                    string
+		id                      string
+		expectedSpecificationId types.String
+	}{
+		{"class id", "class-1", types.StringNull()},
+		{"specification and name", "spec-1/list-users", types.StringValue("spec-1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &resource.ImportStateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(tfType, nil)},
+			}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tt.id}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var result ResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &result)...)
+
+			if result.Id.ValueString() != "class-1" {
+				t.Errorf("expected id class-1, got %s", result.Id)
+			}
+			if !result.SpecificationId.Equal(tt.expectedSpecificationId) {
+				t.Errorf("expected specification_id %s, got %s", tt.expectedSpecificationId, result.SpecificationId)
+			}
+			if len(result.Schema) != 1 || !result.SchemaJSON.IsNull() {
+				t.Errorf("expected the schema block only, got %#v and %s", result.Schema, result.SchemaJSON)
+			}
+		})
+	}
+}
+
+func TestResourceImportState_UnknownSpecificationClass(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	classes := fake.NewClasses()
+	r := &Resource{client: classes}
+
+	schemaResp := testResourceSchema(t, r)
+	tfType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(tfType, nil)},
+	}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "spec-1/missing"}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error importing a missing class")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Not Found Error" {
+		t.Errorf("expected a not found error, got %q", summary)
+	}
+}
+
+func TestResourceImportState_SingleRepresentation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tests := []struct {
+		name               string
+		schema             map[string]any
+		expectBlock        bool
+		expectedSchemaJSON string
+	}{
+		{
+			name: "block fields",
+			schema: map[string]any{
+				"title":       "action-call",
+				"description": "An action call",
+				"type":        "object",
+				"properties":  map[string]any{"tool_id": map[string]any{"type": "string"}},
+				"required":    []any{"tool_id"},
+				"strict":      false,
+			},
+			expectBlock: true,
+		},
+		{
+			name: "keys the block cannot hold",
+			schema: map[string]any{
+				"title":       "entity",
+				"description": "An entity",
+				"type":        "object",
+				"$defs":       map[string]any{"id": map[string]any{"type": "string"}},
+			},
+			expectedSchemaJSON: `{"$defs":{"id":{"type":"string"}},"description":"An entity","title":"entity","type":"object"}`,
+		},
+		{
+			name: "missing type",
+			schema: map[string]any{
+				"title":       "entity",
+				"description": "An entity",
+			},
+			expectedSchemaJSON: `{"description":"An entity","title":"entity"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			classes := fake.NewClasses()
+			classes.Classes["class-1"] = &neural.Class{
+				ID:             "class-1",
+				SpaceID:        "space-1",
+				Name:           tt.schema["title"].(string),
+				Description:    tt.schema["description"].(string),
+				ProvisionState: "active",
+				Schema:         tt.schema,
+			}
+			r := &Resource{client: classes}
+
+			schemaResp := testResourceSchema(t, r)
+			tfType := schemaResp.Schema.Type().TerraformType(ctx)
+
+			resp := &resource.ImportStateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(tfType, nil)},
+			}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: "class-1"}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var result ResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &result)...)
+
+			if tt.expectBlock {
+				if len(result.Schema) != 1 || !result.SchemaJSON.IsNull() {
+					t.Fatalf("expected the schema block only, got %#v and %s", result.Schema, result.SchemaJSON)
+				}
+				if result.Schema[0].Strict.IsNull() || result.Schema[0].Strict.ValueBool() {
+					t.Errorf("expected strict false to be imported, got %s", result.Schema[0].Strict)
+				}
+				return
+			}
+
+			if len(result.Schema) != 0 {
+				t.Errorf("expected no schema block, got %#v", result.Schema)
+			}
+			if result.SchemaJSON.ValueString() != tt.expectedSchemaJSON {
+				t.Errorf("expected schema_json %s, got %s", tt.expectedSchemaJSON, result.SchemaJSON)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package space_processor
+
+import (
+	"github.com/upmaru/tama-go/neural"
+)
+
+// ProcessorAPI describes the processor operations used by the space
+// processor resource. It is satisfied by the neural service of *tama.Client
+// and allows the resource logic to be unit tested against a fake.
+type ProcessorAPI interface {
+	GetProcessor(spaceID, processorType string) (*neural.Processor, error)
+	CreateProcessor(spaceID, processorType string, req neural.CreateProcessorRequest) (*neural.Processor, error)
+	UpdateProcessor(spaceID, processorType string, req neural.UpdateProcessorRequest) (*neural.Processor, error)
+	DeleteProcessor(spaceID, processorType string) error
+}
+
+var _ ProcessorAPI = (*neural.Service)(nil)
@@ -6,16 +6,17 @@ package space_processor
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/neural"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/debug"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
 	"github.com/upmaru/terraform-provider-tama/internal/processor"
 )
 
@@ -23,6 +24,10 @@ import (
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
 var _ resource.ResourceWithConfigValidators = &Resource{}
+var _ resource.ResourceWithModifyPlan = &Resource{}
+
+// processorTypes lists the processor types a space can have.
+var processorTypes = []string{"completion", "embedding", "reranking"}
 
 func NewResource() resource.Resource {
 	return &Resource{}
@@ -30,7 +35,13 @@ func NewResource() resource.Resource {
 
 // Resource defines the resource implementation.
 type Resource struct {
-	client *tama.Client
+	client         ProcessorAPI
+	models         processor.ModelGetter
+	classes        processor.ClassGetter
+	strictModality bool
+	strictParams   bool
+
+	disableNormalization bool
 }
 
 func (r *Resource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -47,13 +58,7 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 }
 
 func (r *Resource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
-	return []resource.ConfigValidator{
-		resourcevalidator.ExactlyOneOf(
-			path.MatchRoot("completion"),
-			path.MatchRoot("embedding"),
-			path.MatchRoot("reranking"),
-		),
-	}
+	return processor.ConfigValidators()
 }
 
 func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -62,18 +67,33 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerMeta.Client.Neural
+	r.models = providerMeta.Client.Sensory
+	r.classes = providerMeta.Client.Neural
+	r.strictModality = providerMeta.StrictModelModality
+	r.strictParams = providerMeta.StrictParameterConflicts
+	r.disableNormalization = providerMeta.DisableJSONNormalization
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Plan formatting changes when the provider disables normalization
+	if r.disableNormalization {
+		resp.Diagnostics.Append(processor.KeepConfiguredParameters(ctx, req.Config, &resp.Plan)...)
+	}
+	resp.Diagnostics.Append(processor.CheckParameterCollisions(ctx, req.Config)...)
+	resp.Diagnostics.Append(processor.CheckPlannedModelModality(ctx, req.Plan, r.models, r.strictModality)...)
+	resp.Diagnostics.Append(processor.PlanEffectiveConfig(ctx, req.State, &resp.Plan)...)
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -96,6 +116,18 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	// Set the type in the data model
 	data.Type = types.StringValue(processorType)
 
+	// Generate the embedding templates of a referenced class
+	resp.Diagnostics.Append(processor.ResolveClassTemplates(data.Embedding, r.classes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Compare with the parameters of the model, applied before the processor
+	resp.Diagnostics.Append(processor.CheckModelParameterConflicts(ctx, data.ModelId.ValueString(), data.Completion, r.models, r.strictParams)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Build configuration based on type
 	config := processor.BuildConfiguration(&data)
 
@@ -107,16 +139,16 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		},
 	}
 
-	tflog.Debug(ctx, "Creating processor", map[string]any{
+	tflog.Debug(ctx, "Creating processor", debug.LogFields(map[string]any{
 		"space_id": data.SpaceId.ValueString(),
 		"model_id": data.ModelId.ValueString(),
 		"type":     processorType,
 		"config":   config,
-	})
+	}))
 
-	processorResponse, err := r.client.Neural.CreateProcessor(data.SpaceId.ValueString(), processorType, createRequest)
+	processorResponse, err := r.client.CreateProcessor(data.SpaceId.ValueString(), processorType, createRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create processor, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to create processor, got error: %s", err)))
 		return
 	}
 
@@ -130,6 +162,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	// Update configuration blocks based on the type and API response
 	processor.UpdateConfigurationFromResponse(processorResponse.Configuration, &data)
+	data.EffectiveConfig = processor.EffectiveConfig(processorResponse.Configuration)
 
 	// Write logs using the tflog package
 	tflog.Trace(ctx, "created a processor resource")
@@ -149,9 +182,9 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	}
 
 	// Get processor from API
-	processorResponse, err := r.client.Neural.GetProcessor(data.SpaceId.ValueString(), data.Type.ValueString())
+	processorResponse, err := r.client.GetProcessor(data.SpaceId.ValueString(), data.Type.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read processor, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read processor, got error: %s", err)))
 		return
 	}
 
@@ -160,7 +193,8 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	data.Type = types.StringValue(processorResponse.Type)
 
 	// Update configuration blocks based on the type and API response
-	processor.UpdateConfigurationFromResponse(processorResponse.Configuration, &data)
+	processor.RefreshConfigurationFromResponse(processorResponse.Configuration, &data)
+	data.EffectiveConfig = processor.EffectiveConfig(processorResponse.Configuration)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -189,6 +223,18 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	// Ensure parameters are initialized to avoid unknown state
 	processor.EnsureParametersInitialized(&data)
 
+	// Generate the embedding templates of a referenced class
+	resp.Diagnostics.Append(processor.ResolveClassTemplates(data.Embedding, r.classes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Compare with the parameters of the model, applied before the processor
+	resp.Diagnostics.Append(processor.CheckModelParameterConflicts(ctx, data.ModelId.ValueString(), data.Completion, r.models, r.strictParams)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Build configuration based on type
 	config := processor.BuildConfiguration(&data)
 
@@ -200,16 +246,16 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		},
 	}
 
-	tflog.Debug(ctx, "Updating processor", map[string]any{
+	tflog.Debug(ctx, "Updating processor", debug.LogFields(map[string]any{
 		"id":       data.Id.ValueString(),
 		"model_id": data.ModelId.ValueString(),
 		"type":     processorType,
 		"config":   config,
-	})
+	}))
 
-	processorResponse, err := r.client.Neural.UpdateProcessor(data.SpaceId.ValueString(), processorType, updateRequest)
+	processorResponse, err := r.client.UpdateProcessor(data.SpaceId.ValueString(), processorType, updateRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update processor, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to update processor, got error: %s", err)))
 		return
 	}
 
@@ -219,6 +265,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	// Update configuration blocks based on the type and API response
 	processor.UpdateConfigurationFromResponse(processorResponse.Configuration, &data)
+	data.EffectiveConfig = processor.EffectiveConfig(processorResponse.Configuration)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -239,51 +286,58 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		"id": data.Id.ValueString(),
 	})
 
-	err := r.client.Neural.DeleteProcessor(data.SpaceId.ValueString(), data.Type.ValueString())
+	err := r.client.DeleteProcessor(data.SpaceId.ValueString(), data.Type.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete processor, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to delete processor, got error: %s", err)))
 		return
 	}
 }
 
 func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Parse the compound ID to extract space_id and type
-	// The import ID should be in the format "space_id/type"
+	// Parse the compound ID to extract space_id and either the type or the model_id
+	// The import ID should be in the format "space_id/type" or "space_id/model_id"
 	parts := strings.Split(req.ID, "/")
-	if len(parts) != 2 {
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
 		resp.Diagnostics.AddError(
 			"Invalid Import ID",
-			"Import ID must be in the format 'space_id/type'",
+			"Import ID must be in the format 'space_id/type' or 'space_id/model_id'",
 		)
 		return
 	}
 
 	spaceID := parts[0]
-	processorType := parts[1]
-
-	// Validate processor type
-	validTypes := []string{"completion", "embedding", "reranking"}
-	isValidType := false
-	for _, validType := range validTypes {
-		if processorType == validType {
-			isValidType = true
-			break
-		}
-	}
 
-	if !isValidType {
-		resp.Diagnostics.AddError(
-			"Invalid Processor Type",
-			fmt.Sprintf("Processor type must be one of: %v", validTypes),
-		)
-		return
-	}
+	var processorResponse *neural.Processor
+	var processorType string
 
-	// Get processor from API to populate state
-	processorResponse, err := r.client.Neural.GetProcessor(spaceID, processorType)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import processor, got error: %s", err))
-		return
+	if slices.Contains(processorTypes, parts[1]) {
+		processorType = parts[1]
+
+		// Get processor from API to populate state
+		response, err := r.client.GetProcessor(spaceID, processorType)
+		if err != nil {
+			resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to import processor, got error: %s", err)))
+			return
+		}
+		processorResponse = response
+	} else {
+		// The type may have been auto-detected, so resolve the processor by model
+		response, err := r.findProcessorByModel(spaceID, parts[1])
+		if client.StatusCode(err) != 0 {
+			resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to import processor, got error: %s", err)))
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Processor Not Found",
+				fmt.Sprintf("Unable to import processor for model %q in space %q: %s. "+
+					"The second part of the import ID must be a processor type (one of: %v) or a model ID.",
+					parts[1], spaceID, err, processorTypes),
+			)
+			return
+		}
+		processorResponse = response
+		processorType = response.Type
 	}
 
 	// Create model from API response using shared model
@@ -298,7 +352,45 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 
 	// Update configuration blocks based on the type and API response
 	processor.UpdateConfigurationFromResponseWithType(processorResponse.Configuration, &data, processorType)
+	data.EffectiveConfig = processor.EffectiveConfig(processorResponse.Configuration)
 
 	// Save imported data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// findProcessorByModel looks up the processor in a space that uses the given
+// model, regardless of its type.
+func (r *Resource) findProcessorByModel(spaceID, modelID string) (*neural.Processor, error) {
+	var matches []*neural.Processor
+
+	for _, processorType := range processorTypes {
+		response, err := r.client.GetProcessor(spaceID, processorType)
+		if client.IsNotFound(err) {
+			// A space only has processors for some of the types
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s processor: %w", processorType, err)
+		}
+
+		if response.ModelID == modelID {
+			if response.Type == "" {
+				response.Type = processorType
+			}
+			matches = append(matches, response)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no processor uses this model")
+	case 1:
+		return matches[0], nil
+	default:
+		var found []string
+		for _, match := range matches {
+			found = append(found, match.Type)
+		}
+		return nil, fmt.Errorf("the model is used by processors of types %v, import with 'space_id/type' instead", found)
+	}
+}
@@ -12,7 +12,9 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/upmaru/tama-go/neural"
 	"github.com/upmaru/terraform-provider-tama/internal/acceptance"
 	"github.com/upmaru/terraform-provider-tama/internal/processor"
 )
@@ -235,8 +237,24 @@ func TestAccSpaceProcessorResource_CompletionWithDefaults(t *testing.T) {
 					resource.TestCheckResourceAttr("tama_space_processor.test", "type", "completion"),
 					// Verify server default for tool_choice is reflected in state
 					resource.TestCheckResourceAttr("tama_space_processor.test", "completion.tool_choice", "required"),
+					// The server defaults are part of the effective configuration
+					resource.TestCheckResourceAttrWith("tama_space_processor.test", "effective_config", func(value string) error {
+						var effective map[string]any
+						if err := json.Unmarshal([]byte(value), &effective); err != nil {
+							return fmt.Errorf("effective_config is not valid JSON: %v", err)
+						}
+						if effective["tool_choice"] != "required" {
+							return fmt.Errorf("expected tool_choice required in effective_config, got %v", effective["tool_choice"])
+						}
+						return nil
+					}),
 				),
 			},
+			// effective_config does not cause a diff on its own
+			{
+				Config:   testAccSpaceProcessorResourceConfig_CompletionWithDefaults(),
+				PlanOnly: true,
+			},
 		},
 	})
 }
@@ -455,6 +473,13 @@ func TestAccSpaceProcessorResource_AutoTypeDetection(t *testing.T) {
 					resource.TestCheckResourceAttr("tama_space_processor.test", "type", "embedding"),
 				),
 			},
+			// ImportState testing by model id, without knowing the detected type
+			{
+				ResourceName:      "tama_space_processor.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccSpaceProcessorImportStateIdByModelFunc,
+			},
 		},
 	})
 }
@@ -472,6 +497,187 @@ func TestAccSpaceProcessorResource_InvalidToolChoice(t *testing.T) {
 	})
 }
 
+func TestAccSpaceProcessorResource_ReasoningEffort(t *testing.T) {
+	for _, effort := range []string{"low", "medium", "high"} {
+		t.Run(effort, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccSpaceProcessorResourceConfig_ReasoningEffort(fmt.Sprintf("reasoning_effort = %q", effort)),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("tama_space_processor.test", "type", "completion"),
+							resource.TestCheckResourceAttr("tama_space_processor.test", "completion.reasoning_effort", effort),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccSpaceProcessorResource_InvalidReasoningEffort(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSpaceProcessorResourceConfig_ReasoningEffort(`reasoning_effort = "extreme"`),
+				ExpectError: regexp.MustCompile(`Attribute completion\.reasoning_effort value must be one of`),
+			},
+		},
+	})
+}
+
+func TestAccSpaceProcessorResource_ReasoningEffortInParameters(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSpaceProcessorResourceConfig_ReasoningEffort("reasoning_effort = \"low\"\n    parameters       = jsonencode({ reasoning_effort = \"high\" })"),
+				ExpectError: regexp.MustCompile(`Conflicting Completion Parameter`),
+			},
+		},
+	})
+}
+
+func TestAccSpaceProcessorResource_ToolChoiceFunction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpaceProcessorResourceConfig_ReasoningEffort(`tool_choice_function = "search"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tama_space_processor.test", "completion.tool_choice_function", "search"),
+					resource.TestCheckNoResourceAttr("tama_space_processor.test", "completion.tool_choice"),
+				),
+			},
+			// The forced tool is read back as is, not as a strategy
+			{
+				Config:   testAccSpaceProcessorResourceConfig_ReasoningEffort(`tool_choice_function = "search"`),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccSpaceProcessorResourceConfig_ReasoningEffort(`tool_choice = "auto"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tama_space_processor.test", "completion.tool_choice", "auto"),
+					resource.TestCheckNoResourceAttr("tama_space_processor.test", "completion.tool_choice_function"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSpaceProcessorResource_ToolChoiceConflict(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpaceProcessorResourceConfig_ReasoningEffort(`tool_choice = "required"
+    tool_choice_function = "search"`),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccSpaceProcessorResource_EmbeddingTemplatesFromClass(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpaceProcessorResourceConfig_EmbeddingTemplatesFromClass(`templates_from_class_id = tama_class.test.id`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tama_space_processor.test", "type", "embedding"),
+					resource.TestCheckResourceAttrPair("tama_space_processor.test", "embedding.templates_from_class_id", "tama_class.test", "id"),
+					resource.TestCheckNoResourceAttr("tama_space_processor.test", "embedding.templates.#"),
+					testAccCheckSpaceProcessorSentTemplates(t, []map[string]any{
+						{"type": "document", "content": "body: {body}\ntitle: {title}"},
+					}),
+				),
+			},
+			// The generated templates are not part of the configuration
+			{
+				Config:   testAccSpaceProcessorResourceConfig_EmbeddingTemplatesFromClass(`templates_from_class_id = tama_class.test.id`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccSpaceProcessorResource_EmbeddingTemplatesFromClassConflict(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpaceProcessorResourceConfig_EmbeddingTemplatesFromClass(`templates_from_class_id = tama_class.test.id
+    templates = [
+      {
+        type    = "document"
+        content = "Document: {text}"
+      }
+    ]`),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccSpaceProcessorResource_CompletionUpdateInPlace(t *testing.T) {
+	var processorID string
+	timestamp := time.Now().UnixNano()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpaceProcessorResourceConfig_CompletionFields(timestamp, `temperature = 0.7
+    tool_choice = "auto"
+    parameters  = jsonencode({ max_tokens = 500 })`),
+				Check: testAccCaptureSpaceProcessorID(&processorID),
+			},
+			// Only parameters change
+			{
+				Config: testAccSpaceProcessorResourceConfig_CompletionFields(timestamp, `temperature = 0.7
+    tool_choice = "auto"
+    parameters  = jsonencode({ max_tokens = 1000 })`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("tama_space_processor.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckSpaceProcessorID(&processorID),
+					resource.TestCheckResourceAttr("tama_space_processor.test", "completion.parameters", `{"max_tokens":1000}`),
+				),
+			},
+			// Temperature and tool_choice change
+			{
+				Config: testAccSpaceProcessorResourceConfig_CompletionFields(timestamp, `temperature = 0.2
+    tool_choice = "required"
+    parameters  = jsonencode({ max_tokens = 1000 })`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("tama_space_processor.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckSpaceProcessorID(&processorID),
+					resource.TestCheckResourceAttr("tama_space_processor.test", "completion.temperature", "0.2"),
+					resource.TestCheckResourceAttr("tama_space_processor.test", "completion.tool_choice", "required"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccSpaceProcessorResource_Multiple(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
@@ -571,6 +777,56 @@ func TestAccSpaceProcessorResource_CompletionWithParameters(t *testing.T) {
 	})
 }
 
+func TestAccSpaceProcessorResource_Drift(t *testing.T) {
+	var spaceID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpaceProcessorResourceConfig_Completion(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCaptureSpaceProcessorSpaceID(&spaceID),
+					resource.TestCheckResourceAttr("tama_space_processor.test", "completion.temperature", "0.7"),
+				),
+			},
+			// Change the processor outside of Terraform, the plan must revert it
+			{
+				PreConfig: func() {
+					_, err := acceptance.Client(t).Neural.UpdateProcessor(spaceID, "completion", neural.UpdateProcessorRequest{
+						Processor: neural.UpdateProcessorData{
+							Configuration: map[string]any{
+								"temperature": 0.2,
+								"tool_choice": "required",
+								"role_mappings": []map[string]any{
+									{"from": "user", "to": "customer"},
+								},
+								"parameters": map[string]any{"max_tokens": 512},
+							},
+						},
+					})
+					if err != nil {
+						t.Fatalf("unable to update processor outside of Terraform: %s", err)
+					}
+				},
+				Config:             testAccSpaceProcessorResourceConfig_Completion(),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccSpaceProcessorResourceConfig_Completion(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tama_space_processor.test", "completion.temperature", "0.7"),
+					resource.TestCheckResourceAttr("tama_space_processor.test", "completion.tool_choice", "auto"),
+					resource.TestCheckResourceAttr("tama_space_processor.test", "completion.role_mappings.#", "2"),
+					resource.TestCheckResourceAttr("tama_space_processor.test", "completion.parameters", ""),
+				),
+			},
+		},
+	})
+}
+
 // Helper function for import state ID.
 func testAccSpaceProcessorImportStateIdFunc(s *terraform.State) (string, error) {
 	rs, ok := s.RootModule().Resources["tama_space_processor.test"]
@@ -584,6 +840,84 @@ func testAccSpaceProcessorImportStateIdFunc(s *terraform.State) (string, error)
 	return fmt.Sprintf("%s/%s", spaceId, processorType), nil
 }
 
+func testAccCaptureSpaceProcessorSpaceID(spaceID *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["tama_space_processor.test"]
+		if !ok {
+			return fmt.Errorf("not found: %s", "tama_space_processor.test")
+		}
+
+		*spaceID = rs.Primary.Attributes["space_id"]
+		return nil
+	}
+}
+
+func testAccCaptureSpaceProcessorID(id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["tama_space_processor.test"]
+		if !ok {
+			return fmt.Errorf("resource not found: tama_space_processor.test")
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+func testAccCheckSpaceProcessorID(id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["tama_space_processor.test"]
+		if !ok {
+			return fmt.Errorf("resource not found: tama_space_processor.test")
+		}
+		if rs.Primary.ID != *id {
+			return fmt.Errorf("expected processor %s to be updated in place, got %s", *id, rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+// testAccCheckSpaceProcessorSentTemplates reads the processor back from the
+// API and compares its embedding templates with expected.
+func testAccCheckSpaceProcessorSentTemplates(t *testing.T, expected []map[string]any) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["tama_space_processor.test"]
+		if !ok {
+			return fmt.Errorf("resource not found: tama_space_processor.test")
+		}
+
+		processorResponse, err := acceptance.Client(t).Neural.GetProcessor(rs.Primary.Attributes["space_id"], "embedding")
+		if err != nil {
+			return fmt.Errorf("unable to read processor: %s", err)
+		}
+
+		actual, err := json.Marshal(processorResponse.Configuration["templates"])
+		if err != nil {
+			return err
+		}
+		want, err := json.Marshal(expected)
+		if err != nil {
+			return err
+		}
+
+		if string(actual) != string(want) {
+			return fmt.Errorf("expected templates %s, got %s", want, actual)
+		}
+		return nil
+	}
+}
+
+func testAccSpaceProcessorImportStateIdByModelFunc(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["tama_space_processor.test"]
+	if !ok {
+		return "", fmt.Errorf("not found: %s", "tama_space_processor.test")
+	}
+
+	spaceId := rs.Primary.Attributes["space_id"]
+	modelId := rs.Primary.Attributes["model_id"]
+
+	return fmt.Sprintf("%s/%s", spaceId, modelId), nil
+}
+
 // Test configuration functions.
 func testAccSpaceProcessorResourceConfig_Completion() string {
 	timestamp := time.Now().UnixNano()
@@ -786,6 +1120,53 @@ resource "tama_space_processor" "test" {
 `, timestamp, timestamp)
 }
 
+func testAccSpaceProcessorResourceConfig_EmbeddingTemplatesFromClass(embedding string) string {
+	timestamp := time.Now().UnixNano()
+	return acceptance.ProviderConfig + fmt.Sprintf(`
+resource "tama_space" "test" {
+  name = "test-space-%[1]d"
+  type = "root"
+}
+
+resource "tama_source" "test" {
+  space_id = tama_space.test.id
+  name     = "test-source-%[1]d"
+  type     = "model"
+  endpoint = "https://api.openai.com/v1"
+  api_key  = "test-key"
+}
+
+resource "tama_model" "test" {
+  source_id  = tama_source.test.id
+  identifier = "text-embedding-ada-002"
+  path       = "/embeddings"
+}
+
+resource "tama_class" "test" {
+  space_id = tama_space.test.id
+  schema_json = jsonencode({
+    title       = "article"
+    description = "An article"
+    type        = "object"
+    properties = {
+      title = { type = "string" }
+      body  = { type = "string" }
+    }
+  })
+}
+
+resource "tama_space_processor" "test" {
+  space_id = tama_space.test.id
+  model_id = tama_model.test.id
+
+  embedding {
+    max_tokens = 512
+    %[2]s
+  }
+}
+`, timestamp, embedding)
+}
+
 func testAccSpaceProcessorResourceConfig_EmbeddingUpdated() string {
 	timestamp := time.Now().UnixNano()
 	return acceptance.ProviderConfig + fmt.Sprintf(`
@@ -1057,6 +1438,71 @@ resource "tama_space_processor" "test" {
 `, timestamp, timestamp)
 }
 
+func testAccSpaceProcessorResourceConfig_ReasoningEffort(completion string) string {
+	timestamp := time.Now().UnixNano()
+	return acceptance.ProviderConfig + fmt.Sprintf(`
+resource "tama_space" "test" {
+  name = "test-space-%[1]d"
+  type = "root"
+}
+
+resource "tama_source" "test" {
+  space_id = tama_space.test.id
+  name     = "test-source-%[1]d"
+  type     = "model"
+  endpoint = "https://api.openai.com/v1"
+  api_key  = "test-key"
+}
+
+resource "tama_model" "test" {
+  source_id  = tama_source.test.id
+  identifier = "o3-mini"
+  path       = "/chat/completions"
+}
+
+resource "tama_space_processor" "test" {
+  space_id = tama_space.test.id
+  model_id = tama_model.test.id
+
+  completion {
+    %[2]s
+  }
+}
+`, timestamp, completion)
+}
+
+func testAccSpaceProcessorResourceConfig_CompletionFields(timestamp int64, completion string) string {
+	return acceptance.ProviderConfig + fmt.Sprintf(`
+resource "tama_space" "test" {
+  name = "test-space-%[1]d"
+  type = "root"
+}
+
+resource "tama_source" "test" {
+  space_id = tama_space.test.id
+  name     = "test-source-%[1]d"
+  type     = "model"
+  endpoint = "https://api.openai.com/v1"
+  api_key  = "test-key"
+}
+
+resource "tama_model" "test" {
+  source_id  = tama_source.test.id
+  identifier = "gpt-4"
+  path       = "/chat/completions"
+}
+
+resource "tama_space_processor" "test" {
+  space_id = tama_space.test.id
+  model_id = tama_model.test.id
+
+  completion {
+    %[2]s
+  }
+}
+`, timestamp, completion)
+}
+
 func testAccSpaceProcessorResourceConfig_CompletionWithParameters() string {
 	timestamp := time.Now().UnixNano()
 	return acceptance.ProviderConfig + fmt.Sprintf(`
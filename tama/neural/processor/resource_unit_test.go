@@ -0,0 +1,428 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package space_processor
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/upmaru/tama-go/neural"
+	"github.com/upmaru/tama-go/sensory"
+	"github.com/upmaru/terraform-provider-tama/internal/fake"
+	"github.com/upmaru/terraform-provider-tama/internal/processor"
+)
+
+func testPlan(t *testing.T, r *Resource, data processor.NeuralProcessorModel) (tfsdk.Plan, tfsdk.State) {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	tfType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(tfType, nil)}
+	if diags := plan.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unable to build plan: %v", diags)
+	}
+
+	return plan, tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(tfType, nil)}
+}
+
+func newCompletionModel() processor.NeuralProcessorModel {
+	return processor.NeuralProcessorModel{
+		ProcessorModel: processor.ProcessorModel{
+			Id:      types.StringUnknown(),
+			ModelId: types.StringValue("model-1"),
+			Type:    types.StringUnknown(),
+		},
+		SpaceId: types.StringValue("space-1"),
+		Completion: &processor.CompletionConfigModel{
+			Temperature: types.Float64Value(0.5),
+			ToolChoice:  types.StringValue("auto"),
+			RoleMappings: []processor.RoleMappingModel{
+				{From: types.StringValue("user"), To: types.StringValue("human")},
+			},
+			Parameters: types.StringUnknown(),
+		},
+	}
+}
+
+func TestResourceCreate_Completion(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	processors := fake.NewProcessors()
+	processors.Defaults = map[string]any{
+		"parameters": map[string]any{"max_tokens": float64(1000)},
+	}
+	r := &Resource{client: processors}
+
+	plan, state := testPlan(t, r, newCompletionModel())
+	resp := &resource.CreateResponse{State: state}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if len(processors.CreateRequests) != 1 {
+		t.Fatalf("expected 1 create request, got %d", len(processors.CreateRequests))
+	}
+
+	sent := processors.CreateRequests[0].Processor
+	if sent.ModelID != "model-1" {
+		t.Errorf("expected model_id model-1, got %s", sent.ModelID)
+	}
+	if sent.Configuration["temperature"] != 0.5 || sent.Configuration["tool_choice"] != "auto" {
+		t.Errorf("unexpected configuration sent: %v", sent.Configuration)
+	}
+	if _, ok := sent.Configuration["role_mappings"]; !ok {
+		t.Errorf("expected role_mappings to be sent, got %v", sent.Configuration)
+	}
+
+	var result processor.NeuralProcessorModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &result)...)
+
+	if result.Type.ValueString() != "completion" {
+		t.Errorf("expected type completion, got %s", result.Type)
+	}
+	if result.Id.ValueString() != "processor-1" {
+		t.Errorf("expected id processor-1, got %s", result.Id)
+	}
+	if result.Completion.Parameters.ValueString() != `{"max_tokens":1000}` {
+		t.Errorf("expected server default parameters in state, got %s", result.Completion.Parameters)
+	}
+
+	var effective map[string]any
+	if err := json.Unmarshal([]byte(result.EffectiveConfig.ValueString()), &effective); err != nil {
+		t.Fatalf("expected effective_config to be JSON, got %s: %s", result.EffectiveConfig, err)
+	}
+	if effective["tool_choice"] != "auto" || effective["parameters"] == nil {
+		t.Errorf("expected the configuration and server defaults in effective_config, got %s", result.EffectiveConfig)
+	}
+}
+
+func TestResourceModifyPlan_NormalizationDisabled(t *testing.T) {
+	t.Parallel()
+
+	configured := `{ "max_tokens": 1000 }`
+
+	tests := []struct {
+		name     string
+		disabled bool
+		expected string
+	}{
+		{name: "normalized", disabled: false, expected: `{"max_tokens":1000}`},
+		{name: "disabled", disabled: true, expected: configured},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			// Two resources configured by different provider blocks
+			r := &Resource{client: fake.NewProcessors(), disableNormalization: tt.disabled}
+
+			data := newCompletionModel()
+			data.Completion.Parameters = types.StringValue(configured)
+			configPlan, _ := testPlan(t, r, data)
+			config := tfsdk.Config{Schema: configPlan.Schema, Raw: configPlan.Raw}
+
+			// The attribute plan modifier has already normalized the value
+			data.Completion.Parameters = types.StringValue(`{"max_tokens":1000}`)
+			plan, _ := testPlan(t, r, data)
+
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: config, Plan: plan}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var result processor.NeuralProcessorModel
+			resp.Diagnostics.Append(resp.Plan.Get(ctx, &result)...)
+
+			if result.Completion.Parameters.ValueString() != tt.expected {
+				t.Errorf("expected %s in the plan, got %s", tt.expected, result.Completion.Parameters)
+			}
+		})
+	}
+}
+
+func TestResourceCreate_ConfiguredParameters(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	processors := fake.NewProcessors()
+	r := &Resource{client: processors, disableNormalization: true}
+
+	configured := `{ "max_tokens": 1000 }`
+
+	data := newCompletionModel()
+	data.Completion.Parameters = types.StringValue(configured)
+
+	plan, state := testPlan(t, r, data)
+	resp := &resource.CreateResponse{State: state}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var result processor.NeuralProcessorModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &result)...)
+
+	if result.Completion.Parameters.ValueString() != configured {
+		t.Errorf("expected the configured parameters in state, got %s", result.Completion.Parameters)
+	}
+}
+
+func TestResourceCreate_NoConfiguration(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	processors := fake.NewProcessors()
+	r := &Resource{client: processors}
+
+	data := newCompletionModel()
+	data.Completion = nil
+
+	plan, state := testPlan(t, r, data)
+	resp := &resource.CreateResponse{State: state}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error when no configuration block is set")
+	}
+	if len(processors.CreateRequests) != 0 {
+		t.Errorf("expected no API call, got %d", len(processors.CreateRequests))
+	}
+}
+
+func TestResourceUpdate_Embedding(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	processors := fake.NewProcessors()
+	r := &Resource{client: processors}
+
+	// Create the processor that will be updated
+	data := newCompletionModel()
+	data.Completion = nil
+	data.Embedding = &processor.EmbeddingConfigModel{
+		MaxTokens: types.Int64Value(512),
+		Templates: []processor.TemplateModel{
+			{Type: types.StringValue("query"), Content: types.StringValue("Query: {{ text }}")},
+		},
+	}
+
+	plan, state := testPlan(t, r, data)
+	createResp := &resource.CreateResponse{State: state}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", createResp.Diagnostics)
+	}
+
+	data.Embedding.MaxTokens = types.Int64Value(1024)
+	plan, state = testPlan(t, r, data)
+	updateResp := &resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	if len(processors.UpdateRequests) != 1 {
+		t.Fatalf("expected 1 update request, got %d", len(processors.UpdateRequests))
+	}
+	if got := processors.UpdateRequests[0].Processor.Configuration["max_tokens"]; got != int64(1024) {
+		t.Errorf("expected max_tokens 1024 to be sent, got %#v", got)
+	}
+
+	var result processor.NeuralProcessorModel
+	updateResp.Diagnostics.Append(updateResp.State.Get(ctx, &result)...)
+
+	if result.Type.ValueString() != "embedding" {
+		t.Errorf("expected type embedding, got %s", result.Type)
+	}
+	if result.Embedding.MaxTokens.ValueInt64() != 1024 {
+		t.Errorf("expected max_tokens 1024 in state, got %s", result.Embedding.MaxTokens)
+	}
+}
+
+func TestResourceCreate_EmbeddingTemplatesFromClass(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	processors := fake.NewProcessors()
+	classes := fake.NewClasses()
+	classes.Classes["class-1"] = &neural.Class{
+		ID: "class-1",
+		Schema: map[string]any{
+			"properties": map[string]any{
+				"title": map[string]any{"type": "string"},
+				"body":  map[string]any{"type": "string"},
+			},
+		},
+	}
+	r := &Resource{client: processors, classes: classes}
+
+	data := newCompletionModel()
+	data.Completion = nil
+	data.Embedding = &processor.EmbeddingConfigModel{
+		MaxTokens:            types.Int64Value(512),
+		TemplatesFromClassId: types.StringValue("class-1"),
+	}
+
+	plan, state := testPlan(t, r, data)
+	resp := &resource.CreateResponse{State: state}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	templates, ok := processors.CreateRequests[0].Processor.Configuration["templates"].([]map[string]any)
+	if !ok || len(templates) != 1 {
+		t.Fatalf("expected 1 generated template to be sent, got %#v", processors.CreateRequests[0].Processor.Configuration["templates"])
+	}
+	if templates[0]["type"] != "document" || templates[0]["content"] != "body: {body}\ntitle: {title}" {
+		t.Errorf("unexpected generated template %#v", templates[0])
+	}
+
+	var result processor.NeuralProcessorModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &result)...)
+
+	// The generated templates stay out of state, as they are not configured
+	if result.Embedding.Templates != nil {
+		t.Errorf("expected no templates in state, got %#v", result.Embedding.Templates)
+	}
+	if result.Embedding.TemplatesFromClassId.ValueString() != "class-1" {
+		t.Errorf("expected templates_from_class_id class-1 in state, got %s", result.Embedding.TemplatesFromClassId)
+	}
+}
+
+func TestResourceImportState_ByModelID(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	processors := fake.NewProcessors()
+	processors.Processors["space-1/embedding"] = &neural.Processor{
+		ID:            "processor-1",
+		SpaceID:       "space-1",
+		ModelID:       "model-embed",
+		Type:          "embedding",
+		Configuration: map[string]any{"max_tokens": float64(512)},
+	}
+	processors.Processors["space-1/completion"] = &neural.Processor{
+		ID:            "processor-2",
+		SpaceID:       "space-1",
+		ModelID:       "model-chat",
+		Type:          "completion",
+		Configuration: map[string]any{"temperature": 0.8},
+	}
+	r := &Resource{client: processors}
+
+	_, state := testPlan(t, r, newCompletionModel())
+
+	tests := []struct {
+		name         string
+		importID     string
+		expectedID   string
+		expectedType string
+		expectError  bool
+	}{
+		{"by type", "space-1/completion", "processor-2", "completion", false},
+		{"by model id", "space-1/model-embed", "processor-1", "embedding", false},
+		{"unknown model id", "space-1/model-missing", "", "", true},
+		{"malformed id", "space-1", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &resource.ImportStateResponse{State: state}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tt.importID}, resp)
+
+			if tt.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var result processor.NeuralProcessorModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &result)...)
+
+			if result.Id.ValueString() != tt.expectedID {
+				t.Errorf("expected id %s, got %s", tt.expectedID, result.Id)
+			}
+			if result.Type.ValueString() != tt.expectedType {
+				t.Errorf("expected type %s, got %s", tt.expectedType, result.Type)
+			}
+			if tt.expectedType == "embedding" && (result.Embedding == nil || result.Embedding.MaxTokens.ValueInt64() != 512) {
+				t.Errorf("expected embedding block to be reconstructed, got %#v", result.Embedding)
+			}
+		})
+	}
+
+	// Errors other than a missing processor are reported, not skipped
+	processors.Err = &neural.Error{StatusCode: 500}
+
+	resp := &resource.ImportStateResponse{State: state}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "space-1/model-embed"}, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Server Error" {
+		t.Errorf("expected a server error, got %v", resp.Diagnostics)
+	}
+}
+
+func TestResourceModifyPlan_ModelModality(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// No tama_model is planned here: the processor is planned first and the
+	// path is read from the API
+	models := fake.NewModels(
+		&sensory.Model{ID: "model-chat", Path: "/chat/completions"},
+		&sensory.Model{ID: "model-embed", Path: "/v1/embeddings"},
+	)
+
+	tests := []struct {
+		name     string
+		modelID  types.String
+		strict   bool
+		warnings int
+		errors   int
+	}{
+		{"matching model", types.StringValue("model-chat"), false, 0, 0},
+		{"embedding model warns", types.StringValue("model-embed"), false, 1, 0},
+		{"embedding model errors when strict", types.StringValue("model-embed"), true, 0, 1},
+		{"unknown model skipped", types.StringUnknown(), true, 0, 0},
+		{"missing model skipped", types.StringValue("model-missing"), true, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &Resource{client: fake.NewProcessors(), models: models, strictModality: tt.strict}
+
+			data := newCompletionModel()
+			data.ModelId = tt.modelID
+
+			plan, _ := testPlan(t, r, data)
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan}, resp)
+
+			if resp.Diagnostics.WarningsCount() != tt.warnings || resp.Diagnostics.ErrorsCount() != tt.errors {
+				t.Errorf("expected %d warnings and %d errors, got %v", tt.warnings, tt.errors, resp.Diagnostics)
+			}
+		})
+	}
+}
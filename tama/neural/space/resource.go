@@ -15,6 +15,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/neural"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/debug"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
+	"github.com/upmaru/terraform-provider-tama/internal/protection"
+	"github.com/upmaru/terraform-provider-tama/internal/provision"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -27,16 +32,19 @@ func NewResource() resource.Resource {
 
 // Resource defines the resource implementation.
 type Resource struct {
-	client *tama.Client
+	client    *tama.Client
+	responses *client.Responses
 }
 
 // ResourceModel describes the resource data model.
 type ResourceModel struct {
-	Id             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	Type           types.String `tfsdk:"type"`
-	Slug           types.String `tfsdk:"slug"`
-	ProvisionState types.String `tfsdk:"provision_state"`
+	Id                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Type               types.String `tfsdk:"type"`
+	Slug               types.String `tfsdk:"slug"`
+	ProvisionState     types.String `tfsdk:"provision_state"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	RawResponseJSON    types.String `tfsdk:"raw_response_json"`
 }
 
 func (r *Resource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -71,6 +79,8 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				MarkdownDescription: "Current state of the space",
 				Computed:            true,
 			},
+			"deletion_protection": protection.Attribute(),
+			"raw_response_json":   debug.RawResponseAttribute(),
 		},
 	}
 }
@@ -81,18 +91,19 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerMeta.Client
+	r.responses = providerMeta.Responses
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -120,7 +131,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	spaceResponse, err := r.client.Neural.CreateSpace(createRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create space, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to create space, got error: %s", err)))
 		return
 	}
 
@@ -134,6 +145,9 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	// Write logs using the tflog package
 	tflog.Trace(ctx, "created a space resource")
 
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, spaceResponse.ID)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -151,7 +165,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	// Get space from API
 	spaceResponse, err := r.client.Neural.GetSpace(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read space, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read space, got error: %s", err)))
 		return
 	}
 
@@ -161,11 +175,19 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	data.Slug = types.StringValue(spaceResponse.Slug)
 	data.ProvisionState = types.StringValue(spaceResponse.ProvisionState)
 
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, spaceResponse.ID)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(provision.CheckUpdatable(ctx, req.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ResourceModel
 
 	// Read Terraform plan data into the model
@@ -191,7 +213,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	spaceResponse, err := r.client.Neural.UpdateSpace(data.Id.ValueString(), updateRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update space, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to update space, got error: %s", err)))
 		return
 	}
 
@@ -201,6 +223,9 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	data.Slug = types.StringValue(spaceResponse.Slug)
 	data.ProvisionState = types.StringValue(spaceResponse.ProvisionState)
 
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, spaceResponse.ID)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -215,6 +240,12 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
+	// Refuse to delete while deletion protection is enabled
+	resp.Diagnostics.Append(protection.CheckDelete(data.DeletionProtection, "tama_space", data.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete space using the Tama client
 	tflog.Debug(ctx, "Deleting space", map[string]any{
 		"id": data.Id.ValueString(),
@@ -222,7 +253,7 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 
 	err := r.client.Neural.DeleteSpace(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete space, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to delete space, got error: %s", err)))
 		return
 	}
 }
@@ -231,19 +262,23 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 	// Get space from API to populate state
 	spaceResponse, err := r.client.Neural.GetSpace(req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import space, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to import space, got error: %s", err)))
 		return
 	}
 
 	// Create model from API response
 	data := ResourceModel{
-		Id:             types.StringValue(spaceResponse.ID),
-		Name:           types.StringValue(spaceResponse.Name),
-		Type:           types.StringValue(spaceResponse.Type),
-		Slug:           types.StringValue(spaceResponse.Slug),
-		ProvisionState: types.StringValue(spaceResponse.ProvisionState),
+		Id:                 types.StringValue(spaceResponse.ID),
+		Name:               types.StringValue(spaceResponse.Name),
+		Type:               types.StringValue(spaceResponse.Type),
+		Slug:               types.StringValue(spaceResponse.Slug),
+		ProvisionState:     types.StringValue(spaceResponse.ProvisionState),
+		DeletionProtection: types.BoolValue(false),
 	}
 
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, spaceResponse.ID)
+
 	// Save imported data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
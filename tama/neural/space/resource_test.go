@@ -168,6 +168,32 @@ func TestAccSpaceResource_DisappearResource(t *testing.T) {
 	})
 }
 
+func TestAccSpaceResource_DeletionProtection(t *testing.T) {
+	name := fmt.Sprintf("test-protected-space-%d", time.Now().UnixNano())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpaceResourceConfigDeletionProtection(name, true),
+				Check:  resource.TestCheckResourceAttr("tama_space.test", "deletion_protection", "true"),
+			},
+			// Destroying a protected space fails
+			{
+				Config:      testAccSpaceResourceConfigDeletionProtection(name, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Deletion Protection Enabled`),
+			},
+			// Disabling protection allows the space to be destroyed
+			{
+				Config: testAccSpaceResourceConfigDeletionProtection(name, false),
+				Check:  resource.TestCheckResourceAttr("tama_space.test", "deletion_protection", "false"),
+			},
+		},
+	})
+}
+
 func testAccSpaceResourceConfig(name, spaceType string) string {
 	return acceptance.ProviderConfig + fmt.Sprintf(`
 resource "tama_space" "test" {
@@ -177,6 +203,16 @@ resource "tama_space" "test" {
 `, name, spaceType)
 }
 
+func testAccSpaceResourceConfigDeletionProtection(name string, enabled bool) string {
+	return acceptance.ProviderConfig + fmt.Sprintf(`
+resource "tama_space" "test" {
+  name                = %[1]q
+  type                = "root"
+  deletion_protection = %[2]t
+}
+`, name, enabled)
+}
+
 func testAccSpaceResourceConfigMultiple() string {
 	timestamp := time.Now().UnixNano()
 	return acceptance.ProviderConfig + fmt.Sprintf(`
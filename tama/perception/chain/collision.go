@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package chain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/upmaru/tama-go/perception"
+)
+
+// slugCollisionFields are the attributes the API reports a uniqueness
+// violation on when a chain name is already used in the space.
+var slugCollisionFields = []string{"slug", "name"}
+
+// slugify returns the slug expected for a chain name: lower case letters and
+// digits, with every other run of characters replaced by a single hyphen. It
+// is only used to describe a collision, the slug in state is always the one
+// returned by the API.
+func slugify(name string) string {
+	var slug strings.Builder
+	pendingHyphen := false
+
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pendingHyphen && slug.Len() > 0 {
+				slug.WriteByte('-')
+			}
+			slug.WriteRune(r)
+			pendingHyphen = false
+			continue
+		}
+		pendingHyphen = true
+	}
+
+	return slug.String()
+}
+
+// isSlugCollision reports whether err is the validation error returned when
+// another chain in the space already uses the slug of the name.
+func isSlugCollision(err error) bool {
+	var apiErr *perception.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 422 {
+		return false
+	}
+
+	for _, field := range slugCollisionFields {
+		for _, message := range apiErr.Errors[field] {
+			if strings.Contains(message, "already been taken") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// slugCollisionDiagnostic reports a chain name whose slug is already used in
+// the space, including the slug so the conflicting chain can be found.
+func slugCollisionDiagnostic(spaceID, name string, err error) diag.Diagnostic {
+	return diag.NewAttributeErrorDiagnostic(
+		path.Root("name"),
+		"Chain Name Already In Use",
+		fmt.Sprintf("Another chain in space %q already uses the slug %q derived from the name %q. "+
+			"Chain names must have unique slugs within a space, choose a different name or import the existing chain. API error: %s", spaceID, slugify(name), name, err),
+	)
+}
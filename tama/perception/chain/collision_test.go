@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package chain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/upmaru/tama-go/perception"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Identity Validation":      "identity-validation",
+		"  Leading and trailing  ": "leading-and-trailing",
+		"Mixed_Case--Name 2":       "mixed-case-name-2",
+		"already-a-slug":           "already-a-slug",
+		"!!!":                      "",
+	}
+
+	for name, want := range tests {
+		if got := slugify(name); got != want {
+			t.Errorf("slugify(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestIsSlugCollision(t *testing.T) {
+	taken := &perception.Error{StatusCode: 422, Errors: map[string][]string{"slug": {"has already been taken"}}}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"slug taken", taken, true},
+		{"name taken", &perception.Error{StatusCode: 422, Errors: map[string][]string{"name": {"has already been taken"}}}, true},
+		{"wrapped", fmt.Errorf("create failed: %w", taken), true},
+		{"other validation error", &perception.Error{StatusCode: 422, Errors: map[string][]string{"name": {"can't be blank"}}}, false},
+		{"other status", &perception.Error{StatusCode: 500, Errors: map[string][]string{"slug": {"has already been taken"}}}, false},
+		{"not an API error", errors.New("connection refused"), false},
+		{"no error", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSlugCollision(tt.err); got != tt.want {
+				t.Errorf("expected %t, got %t", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSlugCollisionDiagnostic(t *testing.T) {
+	err := &perception.Error{StatusCode: 422, Errors: map[string][]string{"slug": {"has already been taken"}}}
+
+	diagnostic := slugCollisionDiagnostic("space-1", "Identity Validation", err)
+	if diagnostic.Summary() != "Chain Name Already In Use" {
+		t.Errorf("unexpected summary %q", diagnostic.Summary())
+	}
+
+	for _, want := range []string{`"space-1"`, `"identity-validation"`, `"Identity Validation"`, "has already been taken"} {
+		if !strings.Contains(diagnostic.Detail(), want) {
+			t.Errorf("expected detail to contain %s, got %q", want, diagnostic.Detail())
+		}
+	}
+}
@@ -5,6 +5,7 @@ package chain_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -77,3 +78,37 @@ resource "tama_chain" "test" {
 }
 `, spaceName)
 }
+
+func TestAccChainResource_DuplicateName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccChainResourceConfigDuplicate(fmt.Sprintf("test-space-%d", time.Now().UnixNano())),
+				ExpectError: regexp.MustCompile(`(?s)Chain Name Already In Use.*"identity-validation"`),
+			},
+		},
+	})
+}
+
+func testAccChainResourceConfigDuplicate(spaceName string) string {
+	return fmt.Sprintf(`
+resource "tama_space" "test" {
+  name = "%s"
+  type = "root"
+}
+
+resource "tama_chain" "first" {
+  space_id = tama_space.test.id
+  name     = "Identity Validation"
+}
+
+resource "tama_chain" "second" {
+  space_id = tama_space.test.id
+  name     = "Identity Validation"
+
+  depends_on = [tama_chain.first]
+}
+`, spaceName)
+}
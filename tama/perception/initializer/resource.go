@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
@@ -17,12 +18,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/perception"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
 	internalplanmodifier "github.com/upmaru/terraform-provider-tama/internal/planmodifier"
+	"github.com/upmaru/terraform-provider-tama/internal/provision"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
+var _ resource.ResourceWithModifyPlan = &Resource{}
 
 func NewResource() resource.Resource {
 	return &Resource{}
@@ -30,7 +35,8 @@ func NewResource() resource.Resource {
 
 // Resource defines the resource implementation.
 type Resource struct {
-	client *tama.Client
+	client               *tama.Client
+	disableNormalization bool
 }
 
 // ResourceModel describes the resource data model.
@@ -104,18 +110,26 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerMeta.Client
+	r.disableNormalization = providerMeta.DisableJSONNormalization
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Plan formatting changes when the provider disables normalization
+	if r.disableNormalization {
+		resp.Diagnostics.Append(internalplanmodifier.KeepConfiguredJSON(ctx, req.Config, &resp.Plan, path.MatchRoot("parameters"))...)
+	}
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -161,7 +175,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	// Create thought initializer
 	initializerResponse, err := r.client.Perception.CreateInitializer(data.ThoughtId.ValueString(), createReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create thought initializer, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to create thought initializer, got error: %s", err)))
 		return
 	}
 
@@ -209,7 +223,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 
 	initializerResponse, err := r.client.Perception.GetInitializer(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read thought initializer, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read thought initializer, got error: %s", err)))
 		return
 	}
 
@@ -238,6 +252,11 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(provision.CheckUpdatable(ctx, req.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ResourceModel
 
 	// Read Terraform plan data into the model
@@ -280,7 +299,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	// Update thought initializer
 	initializerResponse, err := r.client.Perception.UpdateInitializer(data.Id.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update thought initializer, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to update thought initializer, got error: %s", err)))
 		return
 	}
 
@@ -325,7 +344,7 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 
 	err := r.client.Perception.DeleteInitializer(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete thought initializer, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to delete thought initializer, got error: %s", err)))
 		return
 	}
 }
@@ -338,7 +357,7 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 
 	initializerResponse, err := r.client.Perception.GetInitializer(req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read thought initializer for import, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read thought initializer for import, got error: %s", err)))
 		return
 	}
 
@@ -369,7 +388,8 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 
 // updateParametersFromResponse updates the parameters field in the resource model from the API response.
 func (r *Resource) updateParametersFromResponse(responseParameters map[string]any, data *ResourceModel) error {
-	// Handle parameters - always use the server response as the source of truth
+	// Handle parameters - the server response is the source of truth, the
+	// configured string is only kept while it is equivalent
 	if responseParameters != nil {
 		// Use server response as-is since it includes defaults and server-side processing
 		parametersJSON, err := json.Marshal(responseParameters)
@@ -382,7 +402,7 @@ func (r *Resource) updateParametersFromResponse(responseParameters map[string]an
 		if err != nil {
 			return fmt.Errorf("unable to normalize parameters JSON: %s", err)
 		}
-		data.Parameters = types.StringValue(normalizedJSON)
+		data.Parameters = internalplanmodifier.KeepEquivalent(data.Parameters, normalizedJSON, internalplanmodifier.NormalizeJSON)
 	} else {
 		data.Parameters = types.StringNull()
 	}
@@ -8,32 +8,48 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/perception"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
 	internalplanmodifier "github.com/upmaru/terraform-provider-tama/internal/planmodifier"
+	"github.com/upmaru/terraform-provider-tama/internal/provision"
+	"github.com/upmaru/terraform-provider-tama/internal/validators"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
+var _ resource.ResourceWithModifyPlan = &Resource{}
 var _ resource.ResourceWithConfigValidators = &Resource{}
 
+// relationPattern matches the slug form of a relation: lowercase letters and
+// digits, with single hyphens or underscores between them.
+var relationPattern = regexp.MustCompile(`^[a-z0-9]+([_-][a-z0-9]+)*$`)
+
+// maxRelationLength bounds the length of a relation.
+const maxRelationLength = 64
+
 func NewResource() resource.Resource {
 	return &Resource{}
 }
 
 // Resource defines the resource implementation.
 type Resource struct {
-	client *tama.Client
+	client               *tama.Client
+	disableNormalization bool
 }
 
 // ModuleModel describes the module block data model.
@@ -93,8 +109,12 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				Computed:            true,
 			},
 			"relation": schema.StringAttribute{
-				MarkdownDescription: "Relation type for the modular thought (e.g., 'description', 'analysis')",
+				MarkdownDescription: "Relation type for the modular thought (e.g., 'description', 'analysis'). Lowercase letters and digits, optionally separated by single hyphens or underscores, at most 64 characters",
 				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, maxRelationLength),
+					stringvalidator.RegexMatches(relationPattern, "must be lowercase letters and digits, optionally separated by single hyphens or underscores, e.g. description or entity-extraction"),
+				},
 			},
 			"index": schema.Int64Attribute{
 				MarkdownDescription: "Index position of the modular thought in the chain",
@@ -111,12 +131,15 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 						Required:            true,
 					},
 					"parameters": schema.StringAttribute{
-						MarkdownDescription: "Module parameters as JSON string",
+						MarkdownDescription: "Module parameters as JSON object string. Formatting differences are ignored.",
 						Optional:            true,
 						Computed:            true,
 						PlanModifiers: []planmodifier.String{
 							internalplanmodifier.JSONNormalize(),
 						},
+						Validators: []validator.String{
+							validators.JSONObject(),
+						},
 					},
 				},
 			},
@@ -153,18 +176,26 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerMeta.Client
+	r.disableNormalization = providerMeta.DisableJSONNormalization
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Plan formatting changes when the provider disables normalization
+	if r.disableNormalization {
+		resp.Diagnostics.Append(internalplanmodifier.KeepConfiguredJSON(ctx, req.Config, &resp.Plan, path.MatchRoot("module").AtName("parameters"))...)
+	}
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -227,7 +258,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	// Create modular thought
 	thoughtResponse, err := r.client.Perception.CreateThought(data.ChainId.ValueString(), createReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create modular thought, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to create modular thought, got error: %s", err)))
 		return
 	}
 
@@ -278,7 +309,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 
 	thoughtResponse, err := r.client.Perception.GetThought(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read modular thought for import, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read modular thought for import, got error: %s", err)))
 		return
 	}
 
@@ -302,6 +333,11 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(provision.CheckUpdatable(ctx, req.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ResourceModel
 
 	// Read Terraform plan data into the model
@@ -361,7 +397,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	// Update modular thought
 	thoughtResponse, err := r.client.Perception.UpdateThought(data.Id.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update modular thought, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to update modular thought, got error: %s", err)))
 		return
 	}
 
@@ -401,7 +437,7 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 
 	err := r.client.Perception.DeleteThought(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete modular thought, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to delete modular thought, got error: %s", err)))
 		return
 	}
 }
@@ -414,7 +450,7 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 
 	thoughtResponse, err := r.client.Perception.GetThought(req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read thought for import, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read thought for import, got error: %s", err)))
 		return
 	}
 
@@ -468,7 +504,7 @@ func (r *Resource) updateModuleFromResponse(responseModule perception.Module, da
 					if err != nil {
 						return fmt.Errorf("unable to normalize merged module parameters JSON: %s", err)
 					}
-					moduleModel.Parameters = types.StringValue(normalizedJSON)
+					moduleModel.Parameters = internalplanmodifier.KeepEquivalent(data.Module.Parameters, normalizedJSON, internalplanmodifier.NormalizeJSON)
 
 					data.Module = moduleModel
 					return nil
@@ -487,7 +523,7 @@ func (r *Resource) updateModuleFromResponse(responseModule perception.Module, da
 		if err != nil {
 			return fmt.Errorf("unable to normalize module parameters JSON: %s", err)
 		}
-		moduleModel.Parameters = types.StringValue(normalizedJSON)
+		moduleModel.Parameters = internalplanmodifier.KeepEquivalent(data.Module.Parameters, normalizedJSON, internalplanmodifier.NormalizeJSON)
 	} else {
 		moduleModel.Parameters = types.StringNull()
 	}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -582,3 +583,96 @@ resource "tama_modular_thought" "test" {
 }
 `, spaceName)
 }
+
+func TestAccModularThoughtResource_ReformattedParameters(t *testing.T) {
+	spaceName := fmt.Sprintf("test-space-%d", time.Now().UnixNano())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccModularThoughtResourceConfigWithRawParameters(spaceName, `jsonencode({
+      relation = "description"
+      limit    = 10
+    })`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tama_modular_thought.test", "id"),
+					resource.TestCheckResourceAttrSet("tama_modular_thought.test", "module.parameters"),
+				),
+			},
+			// Same parameters with different key order and whitespace must not produce a diff
+			{
+				Config: testAccModularThoughtResourceConfigWithRawParameters(spaceName, `<<-EOT
+      {
+        "limit":    10,
+        "relation": "description"
+      }
+    EOT`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccModularThoughtResource_InvalidParameters(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccModularThoughtResourceConfigWithRawParameters(fmt.Sprintf("test-space-%d", time.Now().UnixNano()), `"{\"relation\": }"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid JSON"),
+			},
+		},
+	})
+}
+
+func TestAccModularThoughtResource_InvalidRelation(t *testing.T) {
+	for name, relation := range map[string]string{
+		"empty":     "",
+		"uppercase": "Description",
+		"spaces":    "entity extraction",
+		"separator": "entity--extraction",
+		"too long":  strings.Repeat("a", 65),
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      strings.Replace(testAccModularThoughtResourceConfig(fmt.Sprintf("test-space-%d", time.Now().UnixNano())), `relation = "description"`, fmt.Sprintf("relation = %q", relation), 1),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(`Invalid Attribute Value`),
+					},
+				},
+			})
+		})
+	}
+}
+
+func testAccModularThoughtResourceConfigWithRawParameters(spaceName, parameters string) string {
+	return fmt.Sprintf(`
+resource "tama_space" "test" {
+  name = "%s"
+  type = "root"
+}
+
+resource "tama_chain" "test" {
+  space_id = tama_space.test.id
+  name     = "Test Processing Chain"
+}
+
+resource "tama_modular_thought" "test" {
+  chain_id = tama_chain.test.id
+  relation = "description"
+
+  module {
+    reference  = "tama/agentic/generate"
+    parameters = %s
+  }
+}
+`, spaceName, parameters)
+}
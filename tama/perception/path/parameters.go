@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package path
+
+import (
+	"sort"
+)
+
+// propertyReference is a class property named by the path parameters.
+type propertyReference struct {
+	// location is the position of the reference within the parameters,
+	// such as "similarity.key" or "filters.category".
+	location string
+
+	// property is the name of the referenced class property.
+	property string
+}
+
+// propertyReferences returns the class properties referenced by parameters in
+// well-known positions: the similarity key and the keys of filters. They are
+// ordered by location so diagnostics are stable.
+func propertyReferences(parameters map[string]any) []propertyReference {
+	var references []propertyReference
+
+	if similarity, ok := parameters["similarity"].(map[string]any); ok {
+		if key, ok := similarity["key"].(string); ok && key != "" {
+			references = append(references, propertyReference{location: "similarity.key", property: key})
+		}
+	}
+
+	if filters, ok := parameters["filters"].(map[string]any); ok {
+		for key := range filters {
+			references = append(references, propertyReference{location: "filters." + key, property: key})
+		}
+	}
+
+	sort.Slice(references, func(i, j int) bool {
+		return references[i].location < references[j].location
+	})
+
+	return references
+}
+
+// unknownPropertyReferences returns the references to properties missing
+// from a class schema. A schema without a properties object describes a
+// dynamic class, so nothing is reported for it.
+func unknownPropertyReferences(references []propertyReference, classSchema map[string]any) []propertyReference {
+	properties, ok := classSchema["properties"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	var unknown []propertyReference
+	for _, reference := range references {
+		if _, ok := properties[reference.property]; !ok {
+			unknown = append(unknown, reference)
+		}
+	}
+
+	return unknown
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package path
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPropertyReferences(t *testing.T) {
+	parameters := map[string]any{
+		"relation": "similarity",
+		"similarity": map[string]any{
+			"key":       "embedding",
+			"threshold": 0.8,
+		},
+		"filters": map[string]any{
+			"published_after": "2023-01-01",
+			"category":        []any{"technology", "ai"},
+		},
+		"sort_by": "relevance",
+	}
+
+	want := []propertyReference{
+		{location: "filters.category", property: "category"},
+		{location: "filters.published_after", property: "published_after"},
+		{location: "similarity.key", property: "embedding"},
+	}
+
+	if got := propertyReferences(parameters); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got := propertyReferences(map[string]any{"relation": "similarity", "similarity": map[string]any{"threshold": 0.9}}); len(got) != 0 {
+		t.Errorf("expected no references without a key or filters, got %v", got)
+	}
+}
+
+func TestUnknownPropertyReferences(t *testing.T) {
+	references := []propertyReference{
+		{location: "filters.category", property: "category"},
+		{location: "similarity.key", property: "embeding"},
+	}
+
+	classSchema := map[string]any{
+		"title": "similar-content",
+		"type":  "object",
+		"properties": map[string]any{
+			"category":  map[string]any{"type": "string"},
+			"embedding": map[string]any{"type": "array"},
+		},
+	}
+
+	want := []propertyReference{{location: "similarity.key", property: "embeding"}}
+	if got := unknownPropertyReferences(references, classSchema); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	dynamicSchema := map[string]any{"title": "dynamic", "type": "object"}
+	if got := unknownPropertyReferences(references, dynamicSchema); len(got) != 0 {
+		t.Errorf("expected a class without properties to be skipped, got %v", got)
+	}
+}
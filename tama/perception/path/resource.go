@@ -8,6 +8,8 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -15,13 +17,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
+	"github.com/upmaru/tama-go/neural"
 	"github.com/upmaru/tama-go/perception"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
 	internalplanmodifier "github.com/upmaru/terraform-provider-tama/internal/planmodifier"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
+var _ resource.ResourceWithConfigValidators = &Resource{}
+var _ resource.ResourceWithModifyPlan = &Resource{}
 
 func NewResource() resource.Resource {
 	return &Resource{}
@@ -29,15 +36,19 @@ func NewResource() resource.Resource {
 
 // Resource defines the resource implementation.
 type Resource struct {
-	client *tama.Client
+	client               *tama.Client
+	disableNormalization bool
 }
 
 // ResourceModel describes the resource data model.
 type ResourceModel struct {
-	Id            types.String `tfsdk:"id"`
-	ThoughtId     types.String `tfsdk:"thought_id"`
-	TargetClassId types.String `tfsdk:"target_class_id"`
-	Parameters    types.String `tfsdk:"parameters"`
+	Id                      types.String `tfsdk:"id"`
+	ThoughtId               types.String `tfsdk:"thought_id"`
+	TargetClassId           types.String `tfsdk:"target_class_id"`
+	TargetClassName         types.String `tfsdk:"target_class_name"`
+	TargetClassSpaceId      types.String `tfsdk:"target_class_space_id"`
+	Parameters              types.String `tfsdk:"parameters"`
+	SkipParameterValidation types.Bool   `tfsdk:"skip_parameter_validation"`
 }
 
 func (r *Resource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -64,8 +75,26 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				},
 			},
 			"target_class_id": schema.StringAttribute{
-				MarkdownDescription: "ID of the target class for this path",
-				Required:            true,
+				MarkdownDescription: "ID of the target class for this path. Exactly one of `target_class_id` or `target_class_name` must be set",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"target_class_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the target class for this path, resolved to `target_class_id` in `target_class_space_id` on create",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_class_space_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the space holding the class named by `target_class_name`",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"parameters": schema.StringAttribute{
 				MarkdownDescription: "Path parameters as JSON string (e.g., '{\"similarity\": {\"threshold\": 0.9}}')",
@@ -75,28 +104,112 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 					internalplanmodifier.JSONNormalize(),
 				},
 			},
+			"skip_parameter_validation": schema.BoolAttribute{
+				MarkdownDescription: "Skip the plan time check that class properties referenced by `parameters`, the `similarity.key` value and the keys of `filters`, exist in the target class schema. Set it for generated or dynamic classes. The check is best-effort and only warns.",
+				Optional:            true,
+			},
 		},
 	}
 }
 
+// ConfigValidators implements the resource.ResourceWithConfigValidators interface.
+func (r *Resource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		// The target class is given either by id or by name
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("target_class_id"),
+			path.MatchRoot("target_class_name"),
+		),
+		// A class name is only unique within its space
+		resourcevalidator.RequiredTogether(
+			path.MatchRoot("target_class_name"),
+			path.MatchRoot("target_class_space_id"),
+		),
+	}
+}
+
 func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerMeta.Client
+	r.disableNormalization = providerMeta.DisableJSONNormalization
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Plan formatting changes when the provider disables normalization
+	if r.disableNormalization {
+		resp.Diagnostics.Append(internalplanmodifier.KeepConfiguredJSON(ctx, req.Config, &resp.Plan, path.MatchRoot("parameters"))...)
+	}
+
+	// Nothing to check on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var data ResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.SkipParameterValidation.ValueBool() {
+		return
+	}
+
+	if data.Parameters.IsNull() || data.Parameters.IsUnknown() || data.Parameters.ValueString() == "" {
+		return
+	}
+
+	// Invalid parameters are reported on create and update
+	var parameters map[string]any
+	if err := json.Unmarshal([]byte(data.Parameters.ValueString()), &parameters); err != nil {
+		return
+	}
+
+	references := propertyReferences(parameters)
+	if len(references) == 0 {
+		return
+	}
+
+	// The target class may not exist yet, in which case it is not checked
+	var targetClass *neural.Class
+	var err error
+	switch {
+	case !data.TargetClassId.IsUnknown() && data.TargetClassId.ValueString() != "":
+		targetClass, err = r.client.Neural.GetClass(data.TargetClassId.ValueString())
+	case !data.TargetClassName.IsUnknown() && !data.TargetClassSpaceId.IsUnknown() && data.TargetClassName.ValueString() != "":
+		targetClass, err = r.client.Neural.GetClassBySpaceAndName(data.TargetClassSpaceId.ValueString(), data.TargetClassName.ValueString())
+	default:
+		return
+	}
+	if err != nil {
+		tflog.Debug(ctx, "Unable to read target class during plan", map[string]any{
+			"target_class_id":   data.TargetClassId.ValueString(),
+			"target_class_name": data.TargetClassName.ValueString(),
+			"error":             err.Error(),
+		})
+		return
+	}
+
+	for _, reference := range unknownPropertyReferences(references, targetClass.Schema) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("parameters"),
+			"Unknown Class Property",
+			fmt.Sprintf("The parameters reference %q at %s, but the target class %q has no such property. "+
+				"Set skip_parameter_validation to true if the class is generated or its properties are dynamic.", reference.property, reference.location, targetClass.Name),
+		)
+	}
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -118,6 +231,16 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		}
 	}
 
+	// Resolve the target class by name
+	if !data.TargetClassName.IsNull() {
+		classResponse, err := r.client.Neural.GetClassBySpaceAndName(data.TargetClassSpaceId.ValueString(), data.TargetClassName.ValueString())
+		if err != nil {
+			resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to find target class %q in space %s, got error: %s", data.TargetClassName.ValueString(), data.TargetClassSpaceId.ValueString(), err)))
+			return
+		}
+		data.TargetClassId = types.StringValue(classResponse.ID)
+	}
+
 	// Create path using the Tama client
 	createRequest := perception.CreatePathRequest{
 		Path: perception.PathRequestData{
@@ -134,7 +257,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	pathResponse, err := r.client.Perception.CreatePath(data.ThoughtId.ValueString(), createRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create path, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to create path, got error: %s", err)))
 		return
 	}
 
@@ -177,7 +300,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	// Get path from API
 	pathResponse, err := r.client.Perception.GetPath(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read path, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read path, got error: %s", err)))
 		return
 	}
 
@@ -238,7 +361,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	pathResponse, err := r.client.Perception.UpdatePath(data.Id.ValueString(), updateRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update path, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to update path, got error: %s", err)))
 		return
 	}
 
@@ -281,7 +404,7 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 
 	err := r.client.Perception.DeletePath(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete path, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to delete path, got error: %s", err)))
 		return
 	}
 }
@@ -290,7 +413,7 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 	// Get path from API to populate state
 	pathResponse, err := r.client.Perception.GetPath(req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import path, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to import path, got error: %s", err)))
 		return
 	}
 
@@ -309,9 +432,11 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 
 	// Create model from API response
 	data := ResourceModel{
-		Id:            types.StringValue(pathResponse.ID),
-		TargetClassId: types.StringValue(pathResponse.TargetClassID),
-		Parameters:    parametersValue,
+		Id:                 types.StringValue(pathResponse.ID),
+		TargetClassId:      types.StringValue(pathResponse.TargetClassID),
+		TargetClassName:    types.StringNull(),
+		TargetClassSpaceId: types.StringNull(),
+		Parameters:         parametersValue,
 		// ThoughtId cannot be retrieved from API response
 		// This will need to be manually set after import
 		ThoughtId: types.StringValue(pathResponse.ThoughtID),
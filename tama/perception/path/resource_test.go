@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -117,6 +118,34 @@ func TestAccThoughtPathResource_WithParameters(t *testing.T) {
 	})
 }
 
+func TestAccThoughtPathResource_SkipParameterValidation(t *testing.T) {
+	parameters := `{"relation": "similarity", "similarity": {"key": "missing"}, "filters": {"content": "news"}}`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Unknown class properties only warn
+			{
+				Config: testAccThoughtPathResourceConfigWithParameters(parameters),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tama_thought_path.test", "id"),
+					resource.TestCheckNoResourceAttr("tama_thought_path.test", "skip_parameter_validation"),
+				),
+			},
+			// The check can be turned off
+			{
+				Config: strings.TrimSuffix(testAccThoughtPathResourceConfigWithParameters(parameters), "}") + `  skip_parameter_validation = true
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("tama_thought_path.test", "id"),
+					resource.TestCheckResourceAttr("tama_thought_path.test", "skip_parameter_validation", "true"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccThoughtPathResource_ComplexParameters(t *testing.T) {
 	complexParams := `{
 		"relation": "similarity",
@@ -240,6 +269,44 @@ func TestAccThoughtPathResource_InvalidTargetClassId(t *testing.T) {
 	})
 }
 
+func TestAccThoughtPathResource_TargetClassName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccThoughtPathResourceConfigTargetClassName(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("tama_thought_path.test", "target_class_id", "tama_class.test_class", "id"),
+					resource.TestCheckResourceAttrPair("tama_thought_path.test", "target_class_name", "tama_class.test_class", "name"),
+					resource.TestCheckResourceAttrPair("tama_thought_path.test", "target_class_space_id", "tama_space.test_space", "id"),
+					resource.TestCheckResourceAttrSet("tama_thought_path.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "tama_thought_path.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"target_class_name", "target_class_space_id"},
+			},
+		},
+	})
+}
+
+func TestAccThoughtPathResource_TargetClassIdAndName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccThoughtPathResourceConfigTargetClassIdAndName(),
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
+}
+
 func TestAccThoughtPathResource_Multiple(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
@@ -591,6 +658,70 @@ resource "tama_thought_path" "test" {
 `, timestamp)
 }
 
+func testAccThoughtPathResourceConfigTargetClassName() string {
+	timestamp := time.Now().UnixNano()
+	return acceptance.ProviderConfig + fmt.Sprintf(`
+resource "tama_space" "test_space" {
+  name = "test-space-for-path-%d"
+  type = "root"
+}
+
+resource "tama_class" "test_class" {
+  space_id = tama_space.test_space.id
+  schema_json = jsonencode({
+    title       = "Test Path Target Schema"
+    description = "Schema for path target"
+    type        = "object"
+    properties = {
+      content = {
+        type        = "string"
+        description = "Content field"
+      }
+    }
+    required = ["content"]
+  })
+}
+
+resource "tama_chain" "test_chain" {
+  space_id = tama_space.test_space.id
+  name     = "test-chain-for-path"
+}
+
+resource "tama_modular_thought" "test_thought" {
+  chain_id = tama_chain.test_chain.id
+  relation = "description"
+
+  module {
+    reference = "tama/agentic/generate"
+    parameters = jsonencode({
+      relation = "description"
+    })
+  }
+}
+
+resource "tama_thought_path" "test" {
+  thought_id            = tama_modular_thought.test_thought.id
+  target_class_name     = tama_class.test_class.name
+  target_class_space_id = tama_space.test_space.id
+
+  parameters = jsonencode({
+    relation = "similarity"
+  })
+}
+`, timestamp)
+}
+
+func testAccThoughtPathResourceConfigTargetClassIdAndName() string {
+	return acceptance.ProviderConfig + `
+resource "tama_thought_path" "test" {
+  thought_id            = "thought-id"
+  target_class_id       = "class-id"
+  target_class_name     = "class-name"
+  target_class_space_id = "space-id"
+}
+`
+}
+
 func testAccThoughtPathResourceConfigWithParameters(parameters string) string {
 	timestamp := time.Now().UnixNano()
 	config := acceptance.ProviderConfig + fmt.Sprintf(`
@@ -8,14 +8,15 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/perception"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/debug"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
 	"github.com/upmaru/terraform-provider-tama/internal/processor"
 )
 
@@ -23,6 +24,7 @@ import (
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
 var _ resource.ResourceWithConfigValidators = &Resource{}
+var _ resource.ResourceWithModifyPlan = &Resource{}
 
 func NewResource() resource.Resource {
 	return &Resource{}
@@ -30,7 +32,13 @@ func NewResource() resource.Resource {
 
 // Resource defines the resource implementation.
 type Resource struct {
-	client *tama.Client
+	client         *tama.Client
+	models         processor.ModelGetter
+	classes        processor.ClassGetter
+	strictModality bool
+	strictParams   bool
+
+	disableNormalization bool
 }
 
 func (r *Resource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -47,13 +55,7 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 }
 
 func (r *Resource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
-	return []resource.ConfigValidator{
-		resourcevalidator.ExactlyOneOf(
-			path.MatchRoot("completion"),
-			path.MatchRoot("embedding"),
-			path.MatchRoot("reranking"),
-		),
-	}
+	return processor.ConfigValidators()
 }
 
 func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -62,18 +64,33 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerMeta.Client
+	r.models = providerMeta.Client.Sensory
+	r.classes = providerMeta.Client.Neural
+	r.strictModality = providerMeta.StrictModelModality
+	r.strictParams = providerMeta.StrictParameterConflicts
+	r.disableNormalization = providerMeta.DisableJSONNormalization
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Plan formatting changes when the provider disables normalization
+	if r.disableNormalization {
+		resp.Diagnostics.Append(processor.KeepConfiguredParameters(ctx, req.Config, &resp.Plan)...)
+	}
+	resp.Diagnostics.Append(processor.CheckParameterCollisions(ctx, req.Config)...)
+	resp.Diagnostics.Append(processor.CheckPlannedModelModality(ctx, req.Plan, r.models, r.strictModality)...)
+	resp.Diagnostics.Append(processor.PlanEffectiveConfig(ctx, req.State, &resp.Plan)...)
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -96,6 +113,18 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	// Set the type in the data model
 	data.Type = types.StringValue(processorType)
 
+	// Generate the embedding templates of a referenced class
+	resp.Diagnostics.Append(processor.ResolveClassTemplates(data.Embedding, r.classes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Compare with the parameters of the model, applied before the processor
+	resp.Diagnostics.Append(processor.CheckModelParameterConflicts(ctx, data.ModelId.ValueString(), data.Completion, r.models, r.strictParams)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Build configuration based on type
 	config := processor.BuildConfiguration(&data)
 
@@ -107,16 +136,16 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		},
 	}
 
-	tflog.Debug(ctx, "Creating processor", map[string]any{
+	tflog.Debug(ctx, "Creating processor", debug.LogFields(map[string]any{
 		"thought_id": data.ThoughtId.ValueString(),
 		"model_id":   data.ModelId.ValueString(),
 		"type":       processorType,
 		"config":     config,
-	})
+	}))
 
 	processorResponse, err := r.client.Perception.CreateProcessor(data.ThoughtId.ValueString(), processorType, createRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create processor, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to create processor, got error: %s", err)))
 		return
 	}
 
@@ -130,6 +159,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	// Update configuration blocks based on the type and API response
 	processor.UpdateConfigurationFromResponse(processorResponse.Configuration, &data)
+	data.EffectiveConfig = processor.EffectiveConfig(processorResponse.Configuration)
 
 	// Write logs using the tflog package
 	tflog.Trace(ctx, "created a processor resource")
@@ -151,7 +181,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	// Get processor from API
 	processorResponse, err := r.client.Perception.GetProcessor(data.ThoughtId.ValueString(), data.Type.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read processor, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read processor, got error: %s", err)))
 		return
 	}
 
@@ -160,7 +190,8 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	data.Type = types.StringValue(processorResponse.Type)
 
 	// Update configuration blocks based on the type and API response
-	processor.UpdateConfigurationFromResponse(processorResponse.Configuration, &data)
+	processor.RefreshConfigurationFromResponse(processorResponse.Configuration, &data)
+	data.EffectiveConfig = processor.EffectiveConfig(processorResponse.Configuration)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -189,6 +220,18 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	// Ensure parameters are initialized to avoid unknown state
 	processor.EnsureParametersInitialized(&data)
 
+	// Generate the embedding templates of a referenced class
+	resp.Diagnostics.Append(processor.ResolveClassTemplates(data.Embedding, r.classes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Compare with the parameters of the model, applied before the processor
+	resp.Diagnostics.Append(processor.CheckModelParameterConflicts(ctx, data.ModelId.ValueString(), data.Completion, r.models, r.strictParams)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Build configuration based on type
 	config := processor.BuildConfiguration(&data)
 
@@ -200,16 +243,16 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		},
 	}
 
-	tflog.Debug(ctx, "Updating processor", map[string]any{
+	tflog.Debug(ctx, "Updating processor", debug.LogFields(map[string]any{
 		"id":       data.Id.ValueString(),
 		"model_id": data.ModelId.ValueString(),
 		"type":     processorType,
 		"config":   config,
-	})
+	}))
 
 	processorResponse, err := r.client.Perception.UpdateProcessor(data.ThoughtId.ValueString(), processorType, updateRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update processor, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to update processor, got error: %s", err)))
 		return
 	}
 
@@ -219,6 +262,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	// Update configuration blocks based on the type and API response
 	processor.UpdateConfigurationFromResponse(processorResponse.Configuration, &data)
+	data.EffectiveConfig = processor.EffectiveConfig(processorResponse.Configuration)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -241,7 +285,7 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 
 	err := r.client.Perception.DeleteProcessor(data.ThoughtId.ValueString(), data.Type.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete processor, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to delete processor, got error: %s", err)))
 		return
 	}
 }
@@ -282,7 +326,7 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 	// Get processor from API to populate state
 	processorResponse, err := r.client.Perception.GetProcessor(thoughtID, processorType)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import processor, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to import processor, got error: %s", err)))
 		return
 	}
 
@@ -298,6 +342,7 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 
 	// Update configuration blocks based on the type and API response
 	processor.UpdateConfigurationFromResponseWithType(processorResponse.Configuration, &data, processorType)
+	data.EffectiveConfig = processor.EffectiveConfig(processorResponse.Configuration)
 
 	// Save imported data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -5,18 +5,28 @@ package tama
 
 import (
 	"context"
-	"os"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
+	"github.com/upmaru/terraform-provider-tama/tama/functions"
 	"github.com/upmaru/terraform-provider-tama/tama/neural/filter"
 
 	"github.com/upmaru/terraform-provider-tama/tama/contexts/input"
@@ -61,6 +71,9 @@ var _ provider.Provider = &TamaProvider{}
 var _ provider.ProviderWithFunctions = &TamaProvider{}
 var _ provider.ProviderWithEphemeralResources = &TamaProvider{}
 
+// apiVersionPattern matches the supported form of api_version.
+var apiVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
 // TamaProvider defines the provider implementation.
 type TamaProvider struct {
 	// version is set to the provider version on release, "dev" when the
@@ -71,11 +84,24 @@ type TamaProvider struct {
 
 // TamaProviderModel describes the provider data model.
 type TamaProviderModel struct {
-	BaseURL      types.String `tfsdk:"base_url"`
-	ClientID     types.String `tfsdk:"client_id"`
-	ClientSecret types.String `tfsdk:"client_secret"`
-	Scopes       types.List   `tfsdk:"scopes"`
-	Timeout      types.Int64  `tfsdk:"timeout"`
+	BaseURL              types.String  `tfsdk:"base_url"`
+	ClientID             types.String  `tfsdk:"client_id"`
+	ClientSecret         types.String  `tfsdk:"client_secret"`
+	Scopes               types.List    `tfsdk:"scopes"`
+	Timeout              types.Int64   `tfsdk:"timeout"`
+	RequestsPerSecond    types.Float64 `tfsdk:"requests_per_second"`
+	InsecureSkipVerify   types.Bool    `tfsdk:"insecure_skip_verify"`
+	TLSMinVersion        types.String  `tfsdk:"tls_min_version"`
+	APIVersion           types.String  `tfsdk:"api_version"`
+	RequireSemver        types.Bool    `tfsdk:"require_semver"`
+	DebugExposeRaw       types.Bool    `tfsdk:"debug_expose_raw"`
+	DisableNormalization types.Bool    `tfsdk:"disable_json_normalization"`
+	StrictModelModality  types.Bool    `tfsdk:"strict_model_modality"`
+	StrictParameters     types.Bool    `tfsdk:"strict_parameter_conflicts"`
+	SchemaSizeWarnBytes  types.Int64   `tfsdk:"schema_size_warn_bytes"`
+	MaxIdleConns         types.Int64   `tfsdk:"max_idle_conns"`
+	MaxConnsPerHost      types.Int64   `tfsdk:"max_conns_per_host"`
+	IdleConnTimeout      types.Int64   `tfsdk:"idle_conn_timeout"`
 }
 
 func (p *TamaProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -87,27 +113,100 @@ func (p *TamaProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Terraform provider for Tama API resources",
 		Attributes: map[string]schema.Attribute{
+			"api_version": schema.StringAttribute{
+				MarkdownDescription: "Tama API version to request, sent as `Accept: application/vnd.tama.<api_version>+json` on every request. When unset, requests accept `application/json` and the engine picks the version." + envDescription(envAPIVersion),
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(apiVersionPattern, "must be in the form v<number>, e.g. v1"),
+				},
+			},
 			"base_url": schema.StringAttribute{
-				MarkdownDescription: "The base URL for the Tama API. Can also be set via the TAMA_BASE_URL environment variable.",
+				MarkdownDescription: "The base URL for the Tama API." + envDescription(envBaseURL),
 				Optional:            true,
 			},
 			"client_id": schema.StringAttribute{
-				MarkdownDescription: "The OAuth2 Client ID for authenticating with the Tama API. Can also be set via the TAMA_CLIENT_ID environment variable.",
+				MarkdownDescription: "The OAuth2 Client ID for authenticating with the Tama API." + envDescription(envClientID),
 				Optional:            true,
 			},
 			"client_secret": schema.StringAttribute{
-				MarkdownDescription: "The OAuth2 Client Secret for authenticating with the Tama API. Can also be set via the TAMA_CLIENT_SECRET environment variable.",
+				MarkdownDescription: "The OAuth2 Client Secret for authenticating with the Tama API." + envDescription(envClientSecret),
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "**Unsafe.** Disables verification of the Tama API TLS certificate, leaving connections open to interception. Only use this against test engines with self-signed certificates, never in production. A warning is emitted whenever it is enabled. Defaults to false." + envDescription(envInsecureSkipVerify),
+				Optional:            true,
+			},
+			"tls_min_version": schema.StringAttribute{
+				MarkdownDescription: "Minimum TLS version accepted when connecting to the Tama API. One of " + strings.Join(client.TLSVersions(), ", ") + ". Defaults to 1.2." + envDescription(envTLSMinVersion),
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.TLSVersions()...),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum number of API requests per second across all resources and data sources, to avoid rate limit errors during large applies. Defaults to unlimited." + envDescription(envRequestsPerSecond),
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of idle connections to the Tama API kept open for reuse. Defaults to " + strconv.Itoa(client.DefaultMaxIdleConns) + "." + envDescription(envMaxIdleConns),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_conns_per_host": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of connections to the Tama API, including those in use. Requests wait for a connection once the limit is reached. Defaults to 0, no limit." + envDescription(envMaxConnsPerHost),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"idle_conn_timeout": schema.Int64Attribute{
+				MarkdownDescription: "How long an idle connection to the Tama API is kept open, in seconds. Defaults to " + strconv.Itoa(int(client.DefaultIdleConnTimeout/time.Second)) + "." + envDescription(envIdleConnTimeout),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 			"scopes": schema.ListAttribute{
-				MarkdownDescription: "OAuth2 scopes to request for the Tama API. Defaults to [\"provision.all\"].",
+				MarkdownDescription: "OAuth2 scopes to request for the Tama API. Defaults to [\"provision.all\"]." + envDescription(envScopes),
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
 			"timeout": schema.Int64Attribute{
-				MarkdownDescription: "Timeout for API requests in seconds. Defaults to 30.",
+				MarkdownDescription: "Timeout for API requests in seconds. Defaults to 30." + envDescription(envTimeout),
+				Optional:            true,
+			},
+			"debug_expose_raw": schema.BoolAttribute{
+				MarkdownDescription: "When enabled, supported resources store the body of the last API response for their object, as sent by the engine and with sensitive values redacted, in a computed `raw_response_json` attribute. Intended for troubleshooting. Defaults to false." + envDescription(envDebugExposeRaw),
+				Optional:            true,
+			},
+			"disable_json_normalization": schema.BoolAttribute{
+				MarkdownDescription: "When enabled, JSON attributes such as `schema_json`, specification schemas and `parameters` are no longer normalized at plan time: any difference from the prior value, formatting and key order included, is planned as a change. The JSON syntax of planned values is still checked. Applies only to resources managed by this provider block. Defaults to false." + envDescription(envDisableJSONNormalization),
+				Optional:            true,
+			},
+			"require_semver": schema.BoolAttribute{
+				MarkdownDescription: "When enabled, specification versions must be valid semantic versions (e.g. `1.2.3`). Defaults to false." + envDescription(envRequireSemver),
+				Optional:            true,
+			},
+			"strict_model_modality": schema.BoolAttribute{
+				MarkdownDescription: "When enabled, using a model whose path does not match the processor type (e.g. an embeddings model in a completion processor) is an error instead of a warning. The referenced model is read during plan, and the check is skipped when the API does not report its path or the model does not exist yet. Defaults to false." + envDescription(envStrictModelModality),
+				Optional:            true,
+			},
+			"strict_parameter_conflicts": schema.BoolAttribute{
+				MarkdownDescription: "When enabled, a key set to different values in a model's parameters and in the completion parameters of a processor using it is an error instead of a warning. The check runs when the processor is created or updated, after the model it references has been applied. Defaults to false." + envDescription(envStrictParameterConflicts),
+				Optional:            true,
+			},
+			"schema_size_warn_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Size in bytes of a normalized `tama_class` schema above which a warning is emitted at plan time. Set to 0 to disable the warning. Defaults to 262144 (256 KiB)." + envDescription(envSchemaSizeWarnBytes),
 				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
 		},
 	}
@@ -122,49 +221,88 @@ func (p *TamaProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
-	// Default values
-	baseURL := "https://api.tama.io"
-	clientID := ""
-	clientSecret := ""
-	scopes := []string{"provision.all"}
-	timeout := int64(30)
+	// Each setting is taken from the configuration, then from its
+	// environment variable, then from its default.
+	baseURL := stringSetting(data.BaseURL, envBaseURL, "https://api.tama.io")
+	clientID := stringSetting(data.ClientID, envClientID, "")
+	clientSecret := stringSetting(data.ClientSecret, envClientSecret, "")
+	apiVersion := stringSetting(data.APIVersion, envAPIVersion, "")
 
-	// Override with configuration values
-	if !data.BaseURL.IsNull() {
-		baseURL = data.BaseURL.ValueString()
+	scopes, diags := listSetting(ctx, data.Scopes, envScopes, []string{"provision.all"})
+	resp.Diagnostics.Append(diags...)
+
+	timeout, err := int64Setting(data.Timeout, envTimeout, 30)
+	addEnvError(&resp.Diagnostics, err)
+
+	requestsPerSecond, err := float64Setting(data.RequestsPerSecond, envRequestsPerSecond, 0)
+	addEnvError(&resp.Diagnostics, err)
+
+	insecureSkipVerify, err := boolSetting(data.InsecureSkipVerify, envInsecureSkipVerify, false)
+	addEnvError(&resp.Diagnostics, err)
+
+	requireSemver, err := boolSetting(data.RequireSemver, envRequireSemver, false)
+	addEnvError(&resp.Diagnostics, err)
+
+	debugExposeRaw, err := boolSetting(data.DebugExposeRaw, envDebugExposeRaw, false)
+	addEnvError(&resp.Diagnostics, err)
+
+	disableNormalization, err := boolSetting(data.DisableNormalization, envDisableJSONNormalization, false)
+	addEnvError(&resp.Diagnostics, err)
+
+	strictModality, err := boolSetting(data.StrictModelModality, envStrictModelModality, false)
+	addEnvError(&resp.Diagnostics, err)
+
+	strictParameters, err := boolSetting(data.StrictParameters, envStrictParameterConflicts, false)
+	addEnvError(&resp.Diagnostics, err)
+
+	schemaSizeWarnBytes, err := int64Setting(data.SchemaSizeWarnBytes, envSchemaSizeWarnBytes, 256*1024)
+	addEnvError(&resp.Diagnostics, err)
+
+	maxIdleConns, err := int64Setting(data.MaxIdleConns, envMaxIdleConns, client.DefaultMaxIdleConns)
+	addEnvError(&resp.Diagnostics, err)
+
+	maxConnsPerHost, err := int64Setting(data.MaxConnsPerHost, envMaxConnsPerHost, 0)
+	addEnvError(&resp.Diagnostics, err)
+
+	idleConnTimeout, err := int64Setting(data.IdleConnTimeout, envIdleConnTimeout, int64(client.DefaultIdleConnTimeout/time.Second))
+	addEnvError(&resp.Diagnostics, err)
+
+	tlsMinVersion := uint16(0)
+	if name := stringSetting(data.TLSMinVersion, envTLSMinVersion, ""); name != "" {
+		version, err := client.TLSVersion(name)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid TLS Version", err.Error())
+		}
+		tlsMinVersion = version
 	}
 
-	if !data.ClientID.IsNull() {
-		clientID = data.ClientID.ValueString()
+	// Values from the environment are not checked by the schema validators
+	if apiVersion != "" && !apiVersionPattern.MatchString(apiVersion) {
+		resp.Diagnostics.AddError("Invalid API Version", fmt.Sprintf("api_version %q must be in the form v<number>, e.g. v1", apiVersion))
 	}
 
-	if !data.ClientSecret.IsNull() {
-		clientSecret = data.ClientSecret.ValueString()
+	if requestsPerSecond < 0 {
+		resp.Diagnostics.AddError("Invalid Requests Per Second", fmt.Sprintf("requests_per_second must be at least 0, got %g", requestsPerSecond))
 	}
 
-	if !data.Timeout.IsNull() {
-		timeout = data.Timeout.ValueInt64()
+	if schemaSizeWarnBytes < 0 {
+		resp.Diagnostics.AddError("Invalid Schema Size Warning", fmt.Sprintf("schema_size_warn_bytes must be at least 0, got %d", schemaSizeWarnBytes))
 	}
 
-	if !data.Scopes.IsNull() && !data.Scopes.IsUnknown() {
-		var providedScopes []string
-		resp.Diagnostics.Append(data.Scopes.ElementsAs(ctx, &providedScopes, false)...)
-		if !resp.Diagnostics.HasError() {
-			scopes = providedScopes
-		}
+	if maxIdleConns < 1 {
+		resp.Diagnostics.AddError("Invalid Connection Pool Size", fmt.Sprintf("max_idle_conns must be at least 1, got %d", maxIdleConns))
 	}
 
-	// Override with environment variables
-	if envBaseURL := os.Getenv("TAMA_BASE_URL"); envBaseURL != "" {
-		baseURL = envBaseURL
+	if maxConnsPerHost < 0 {
+		resp.Diagnostics.AddError("Invalid Connection Limit", fmt.Sprintf("max_conns_per_host must be at least 0, got %d", maxConnsPerHost))
 	}
 
-	if envClientID := os.Getenv("TAMA_CLIENT_ID"); envClientID != "" {
-		clientID = envClientID
+	if idleConnTimeout < 1 {
+		resp.Diagnostics.AddError("Invalid Idle Connection Timeout", fmt.Sprintf("idle_conn_timeout must be at least 1, got %d", idleConnTimeout))
 	}
 
-	if envClientSecret := os.Getenv("TAMA_CLIENT_SECRET"); envClientSecret != "" {
-		clientSecret = envClientSecret
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Validate required configuration
@@ -201,21 +339,48 @@ func (p *TamaProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	ctx = tflog.SetField(ctx, "tama_base_url", baseURL)
 	ctx = tflog.SetField(ctx, "tama_timeout", timeout)
 	ctx = tflog.SetField(ctx, "tama_scopes", scopes)
+	ctx = tflog.SetField(ctx, "tama_api_version", apiVersion)
+	ctx = tflog.SetField(ctx, "tama_requests_per_second", requestsPerSecond)
+	ctx = tflog.SetField(ctx, "tama_insecure_skip_verify", insecureSkipVerify)
+	ctx = tflog.SetField(ctx, "tama_max_idle_conns", maxIdleConns)
+	ctx = tflog.SetField(ctx, "tama_max_conns_per_host", maxConnsPerHost)
+	ctx = tflog.SetField(ctx, "tama_idle_conn_timeout", idleConnTimeout)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "tama_client_secret")
 
 	tflog.Debug(ctx, "Creating Tama API client")
 
 	// Create Tama client configuration
-	config := tama.Config{
-		BaseURL:      baseURL,
-		ClientID:     clientID,
-		ClientSecret: clientSecret,
-		Timeout:      time.Duration(timeout) * time.Second,
-		Scopes:       scopes,
+	config := client.Config{
+		Config: tama.Config{
+			BaseURL:      baseURL,
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Timeout:      time.Duration(timeout) * time.Second,
+			Scopes:       scopes,
+		},
+		APIVersion:         apiVersion,
+		RequestsPerSecond:  requestsPerSecond,
+		InsecureSkipVerify: insecureSkipVerify,
+		TLSMinVersion:      tlsMinVersion,
+		MaxIdleConns:       int(maxIdleConns),
+		MaxConnsPerHost:    int(maxConnsPerHost),
+		IdleConnTimeout:    time.Duration(idleConnTimeout) * time.Second,
+	}
+
+	if debugExposeRaw {
+		config.Responses = client.NewResponses()
+	}
+
+	if insecureSkipVerify {
+		resp.Diagnostics.AddWarning(
+			"TLS Certificate Verification Disabled",
+			"insecure_skip_verify is enabled, so the Tama API certificate is not verified and connections can be intercepted. "+
+				"Only use this against test engines with self-signed certificates, never in production.",
+		)
 	}
 
 	// Create Tama client
-	client, err := tama.NewClient(config)
+	tamaClient, err := client.New(config)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to create Tama API client",
@@ -224,9 +389,19 @@ func (p *TamaProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
+	providerMeta := &meta.ProviderMeta{
+		Client:                   tamaClient,
+		RequireSemver:            requireSemver,
+		Responses:                config.Responses,
+		DisableJSONNormalization: disableNormalization,
+		StrictModelModality:      strictModality,
+		StrictParameterConflicts: strictParameters,
+		SchemaSizeWarnBytes:      schemaSizeWarnBytes,
+	}
+
 	// Make the client available during DataSource and Resource type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.DataSourceData = providerMeta
+	resp.ResourceData = providerMeta
 
 	tflog.Info(ctx, "Configured Tama API client", map[string]any{"success": true})
 }
@@ -285,6 +460,7 @@ func (p *TamaProvider) DataSources(ctx context.Context) []func() datasource.Data
 		source_identity.NewDataSource,
 		model.NewDataSource,
 		specification.NewDataSource,
+		specification.NewValidateDataSource,
 		prompt.NewDataSource,
 		chain.NewDataSource,
 		modular_thought.NewDataSource,
@@ -295,7 +471,9 @@ func (p *TamaProvider) DataSources(ctx context.Context) []func() datasource.Data
 }
 
 func (p *TamaProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		functions.NewSchemaHashFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
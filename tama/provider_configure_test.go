@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tama
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	tama "github.com/upmaru/tama-go"
+)
+
+// Resources and data sources are configured with *meta.ProviderMeta, anything
+// else, such as the bare client passed before ProviderMeta existed, must be
+// rejected instead of leaving the client unset.
+func TestProvider_ConfigureRejectsUnexpectedProviderData(t *testing.T) {
+	ctx := context.Background()
+	p := &TamaProvider{}
+	providerData := &tama.Client{}
+
+	for _, resourceFunc := range p.Resources(ctx) {
+		r := resourceFunc()
+
+		var metadata resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "tama"}, &metadata)
+
+		configurable, ok := r.(resource.ResourceWithConfigure)
+		if !ok {
+			continue
+		}
+
+		var resp resource.ConfigureResponse
+		configurable.Configure(ctx, resource.ConfigureRequest{ProviderData: providerData}, &resp)
+
+		if resp.Diagnostics.ErrorsCount() != 1 {
+			t.Errorf("resource %s: expected 1 error, got %v", metadata.TypeName, resp.Diagnostics)
+			continue
+		}
+		if got := resp.Diagnostics.Errors()[0].Summary(); got != "Unexpected Resource Configure Type" {
+			t.Errorf("resource %s: unexpected summary %q", metadata.TypeName, got)
+		}
+		if got := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(got, "Expected *meta.ProviderMeta, got: *tama.Client") {
+			t.Errorf("resource %s: unexpected detail %q", metadata.TypeName, got)
+		}
+	}
+
+	for _, dataSourceFunc := range p.DataSources(ctx) {
+		d := dataSourceFunc()
+
+		var metadata datasource.MetadataResponse
+		d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "tama"}, &metadata)
+
+		configurable, ok := d.(datasource.DataSourceWithConfigure)
+		if !ok {
+			continue
+		}
+
+		var resp datasource.ConfigureResponse
+		configurable.Configure(ctx, datasource.ConfigureRequest{ProviderData: providerData}, &resp)
+
+		if resp.Diagnostics.ErrorsCount() != 1 {
+			t.Errorf("data source %s: expected 1 error, got %v", metadata.TypeName, resp.Diagnostics)
+			continue
+		}
+		if got := resp.Diagnostics.Errors()[0].Summary(); got != "Unexpected Data Source Configure Type" {
+			t.Errorf("data source %s: unexpected summary %q", metadata.TypeName, got)
+		}
+		if got := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(got, "Expected *meta.ProviderMeta, got: *tama.Client") {
+			t.Errorf("data source %s: unexpected detail %q", metadata.TypeName, got)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tama
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Environment variables each provider attribute falls back to when it is not
+// set in the provider configuration. Empty variables are treated as unset.
+const (
+	envAPIVersion               = "TAMA_API_VERSION"
+	envBaseURL                  = "TAMA_BASE_URL"
+	envClientID                 = "TAMA_CLIENT_ID"
+	envClientSecret             = "TAMA_CLIENT_SECRET"
+	envDebugExposeRaw           = "TAMA_DEBUG_EXPOSE_RAW"
+	envDisableJSONNormalization = "TAMA_DISABLE_JSON_NORMALIZATION"
+	envIdleConnTimeout          = "TAMA_IDLE_CONN_TIMEOUT"
+	envInsecureSkipVerify       = "TAMA_INSECURE_SKIP_VERIFY"
+	envMaxConnsPerHost          = "TAMA_MAX_CONNS_PER_HOST"
+	envMaxIdleConns             = "TAMA_MAX_IDLE_CONNS"
+	envRequestsPerSecond        = "TAMA_REQUESTS_PER_SECOND"
+	envRequireSemver            = "TAMA_REQUIRE_SEMVER"
+	envSchemaSizeWarnBytes      = "TAMA_SCHEMA_SIZE_WARN_BYTES"
+	envScopes                   = "TAMA_SCOPES"
+	envStrictModelModality      = "TAMA_STRICT_MODEL_MODALITY"
+	envStrictParameterConflicts = "TAMA_STRICT_PARAMETER_CONFLICTS"
+	envTimeout                  = "TAMA_TIMEOUT"
+	envTLSMinVersion            = "TAMA_TLS_MIN_VERSION"
+)
+
+// envDescription returns the sentence appended to the description of an
+// attribute naming its environment variable.
+func envDescription(name string) string {
+	return " Can also be set via the " + name + " environment variable."
+}
+
+// The settings below resolve an attribute with the precedence
+// configuration > environment variable > default.
+
+func stringSetting(value types.String, env string, fallback string) string {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueString()
+	}
+	if raw := os.Getenv(env); raw != "" {
+		return raw
+	}
+	return fallback
+}
+
+func boolSetting(value types.Bool, env string, fallback bool) (bool, error) {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueBool(), nil
+	}
+
+	raw := os.Getenv(env)
+	if raw == "" {
+		return fallback, nil
+	}
+
+	parsed, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("%s: %q is not a valid boolean", env, raw)
+	}
+	return parsed, nil
+}
+
+func int64Setting(value types.Int64, env string, fallback int64) (int64, error) {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueInt64(), nil
+	}
+
+	raw := os.Getenv(env)
+	if raw == "" {
+		return fallback, nil
+	}
+
+	parsed, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %q is not a valid integer", env, raw)
+	}
+	return parsed, nil
+}
+
+func float64Setting(value types.Float64, env string, fallback float64) (float64, error) {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueFloat64(), nil
+	}
+
+	raw := os.Getenv(env)
+	if raw == "" {
+		return fallback, nil
+	}
+
+	parsed, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %q is not a valid number", env, raw)
+	}
+	return parsed, nil
+}
+
+// listSetting resolves a list of strings, read from the environment as comma
+// separated values.
+func listSetting(ctx context.Context, value types.List, env string, fallback []string) ([]string, diag.Diagnostics) {
+	if !value.IsNull() && !value.IsUnknown() {
+		var values []string
+		diags := value.ElementsAs(ctx, &values, false)
+		return values, diags
+	}
+
+	raw := os.Getenv(env)
+	if raw == "" {
+		return fallback, nil
+	}
+
+	var values []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values, nil
+}
+
+// addEnvError reports an environment variable that could not be parsed.
+func addEnvError(diags *diag.Diagnostics, err error) {
+	if err != nil {
+		diags.AddError("Invalid Environment Variable", err.Error())
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tama
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
+)
+
+const testEnv = "TAMA_PROVIDER_ENV_TEST"
+
+func TestStringSetting(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    types.String
+		env      string
+		expected string
+	}{
+		{"config wins over env", types.StringValue("config"), "env", "config"},
+		{"env wins over default", types.StringNull(), "env", "env"},
+		{"default when unset", types.StringNull(), "", "default"},
+		{"empty config is kept", types.StringValue(""), "env", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(testEnv, tt.env)
+
+			if got := stringSetting(tt.value, testEnv, "default"); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBoolSetting(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       types.Bool
+		env         string
+		expected    bool
+		expectError bool
+	}{
+		{"config wins over env", types.BoolValue(false), "true", false, false},
+		{"env wins over default", types.BoolNull(), "true", true, false},
+		{"default when unset", types.BoolNull(), "", true, false},
+		{"invalid env", types.BoolNull(), "yes", false, true},
+		{"invalid env ignored when configured", types.BoolValue(true), "yes", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(testEnv, tt.env)
+
+			got, err := boolSetting(tt.value, testEnv, true)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("expected error %t, got %v", tt.expectError, err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestInt64Setting(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       types.Int64
+		env         string
+		expected    int64
+		expectError bool
+	}{
+		{"config wins over env", types.Int64Value(10), "60", 10, false},
+		{"env wins over default", types.Int64Null(), "60", 60, false},
+		{"default when unset", types.Int64Null(), "", 30, false},
+		{"invalid env", types.Int64Null(), "1m", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(testEnv, tt.env)
+
+			got, err := int64Setting(tt.value, testEnv, 30)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("expected error %t, got %v", tt.expectError, err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFloat64Setting(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       types.Float64
+		env         string
+		expected    float64
+		expectError bool
+	}{
+		{"config wins over env", types.Float64Value(2), "5.5", 2, false},
+		{"env wins over default", types.Float64Null(), "5.5", 5.5, false},
+		{"default when unset", types.Float64Null(), "", 1, false},
+		{"invalid env", types.Float64Null(), "fast", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(testEnv, tt.env)
+
+			got, err := float64Setting(tt.value, testEnv, 1)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("expected error %t, got %v", tt.expectError, err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %g, got %g", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestListSetting(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    types.List
+		env      string
+		expected []string
+	}{
+		{"config wins over env", types.ListValueMust(types.StringType, []attr.Value{types.StringValue("config")}), "env", []string{"config"}},
+		{"env wins over default", types.ListNull(types.StringType), "provision.all, read.all,", []string{"provision.all", "read.all"}},
+		{"default when unset", types.ListNull(types.StringType), "", []string{"default"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(testEnv, tt.env)
+
+			got, diags := listSetting(context.Background(), tt.value, testEnv, []string{"default"})
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestProvider_ConfigureEnvironment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "test-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name                string
+		env                 map[string]string
+		strictModality      types.Bool
+		expectError         string
+		expectRequireSemver bool
+		expectStrict        bool
+		expectSchemaSize    int64
+	}{
+		{
+			name:             "defaults",
+			expectSchemaSize: 256 * 1024,
+		},
+		{
+			name: "environment",
+			env: map[string]string{
+				envRequireSemver:            "true",
+				envStrictModelModality:      "true",
+				envSchemaSizeWarnBytes:      "1024",
+				envClientSecret:             "env-secret",
+				envStrictParameterConflicts: "false",
+			},
+			expectRequireSemver: true,
+			expectStrict:        true,
+			expectSchemaSize:    1024,
+		},
+		{
+			name:             "configuration over environment",
+			env:              map[string]string{envStrictModelModality: "true"},
+			strictModality:   types.BoolValue(false),
+			expectSchemaSize: 256 * 1024,
+		},
+		{
+			name:        "invalid boolean",
+			env:         map[string]string{envRequireSemver: "sometimes"},
+			expectError: "Invalid Environment Variable",
+		},
+		{
+			name:        "invalid api version",
+			env:         map[string]string{envAPIVersion: "1"},
+			expectError: "Invalid API Version",
+		},
+		{
+			name:        "invalid tls version",
+			env:         map[string]string{envTLSMinVersion: "2.0"},
+			expectError: "Invalid TLS Version",
+		},
+		{
+			name:        "empty connection pool",
+			env:         map[string]string{envMaxIdleConns: "0"},
+			expectError: "Invalid Connection Pool Size",
+		},
+		{
+			name:             "connection pooling",
+			env:              map[string]string{envMaxIdleConns: "10", envMaxConnsPerHost: "20", envIdleConnTimeout: "30"},
+			expectSchemaSize: 256 * 1024,
+		},
+		{
+			name:        "negative schema size",
+			env:         map[string]string{envSchemaSizeWarnBytes: "-1"},
+			expectError: "Invalid Schema Size Warning",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{
+				envAPIVersion, envBaseURL, envClientID, envClientSecret, envDebugExposeRaw,
+				envIdleConnTimeout, envInsecureSkipVerify, envMaxConnsPerHost, envMaxIdleConns,
+				envRequestsPerSecond, envRequireSemver, envSchemaSizeWarnBytes, envScopes,
+				envStrictModelModality, envStrictParameterConflicts, envTimeout, envTLSMinVersion,
+			} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			ctx := context.Background()
+			p := &TamaProvider{version: "test"}
+
+			var schemaResp provider.SchemaResponse
+			p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+			data := TamaProviderModel{
+				BaseURL:             types.StringValue(server.URL),
+				ClientID:            types.StringValue("client-id"),
+				ClientSecret:        types.StringNull(),
+				Scopes:              types.ListNull(types.StringType),
+				StrictModelModality: tt.strictModality,
+			}
+			if _, ok := tt.env[envClientSecret]; !ok {
+				data.ClientSecret = types.StringValue("client-secret")
+			}
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			if diags := state.Set(ctx, &data); diags.HasError() {
+				t.Fatalf("unable to build config: %v", diags)
+			}
+
+			var resp provider.ConfigureResponse
+			p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, &resp)
+
+			if tt.expectError != "" {
+				if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != tt.expectError {
+					t.Fatalf("expected %q error, got %v", tt.expectError, resp.Diagnostics)
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+
+			providerMeta, ok := resp.ResourceData.(*meta.ProviderMeta)
+			if !ok {
+				t.Fatalf("expected provider meta, got %T", resp.ResourceData)
+			}
+			if providerMeta.RequireSemver != tt.expectRequireSemver {
+				t.Errorf("expected require_semver %t, got %t", tt.expectRequireSemver, providerMeta.RequireSemver)
+			}
+			if providerMeta.StrictModelModality != tt.expectStrict {
+				t.Errorf("expected strict_model_modality %t, got %t", tt.expectStrict, providerMeta.StrictModelModality)
+			}
+			if providerMeta.SchemaSizeWarnBytes != tt.expectSchemaSize {
+				t.Errorf("expected schema_size_warn_bytes %d, got %d", tt.expectSchemaSize, providerMeta.SchemaSizeWarnBytes)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tama
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dataschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// credentialAttributeName matches attribute names that hold credentials.
+// Names such as max_tokens only mention a credential word and do not match.
+var credentialAttributeName = regexp.MustCompile(`(^|_)(api_key|secret|token|password)$`)
+
+func TestProvider_CredentialAttributesAreSensitive(t *testing.T) {
+	ctx := context.Background()
+	p := &TamaProvider{}
+
+	var providerSchema provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &providerSchema)
+
+	for name, attribute := range providerSchema.Schema.Attributes {
+		if credentialAttributeName.MatchString(name) && !attribute.IsSensitive() {
+			t.Errorf("provider attribute %q holds a credential but is not marked sensitive", name)
+		}
+	}
+
+	for _, resourceFunc := range p.Resources(ctx) {
+		r := resourceFunc()
+
+		var metadata resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "tama"}, &metadata)
+
+		var schemaResp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+		checkSensitiveAttributes(t, metadata.TypeName, schemaResp.Schema.Attributes)
+		checkSensitiveBlocks(t, metadata.TypeName, schemaResp.Schema.Blocks)
+	}
+
+	for _, dataSourceFunc := range p.DataSources(ctx) {
+		d := dataSourceFunc()
+
+		var metadata datasource.MetadataResponse
+		d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "tama"}, &metadata)
+
+		var schemaResp datasource.SchemaResponse
+		d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+		checkSensitiveDataSourceAttributes(t, "data."+metadata.TypeName, schemaResp.Schema.Attributes)
+		checkSensitiveDataSourceBlocks(t, "data."+metadata.TypeName, schemaResp.Schema.Blocks)
+	}
+}
+
+func checkSensitiveAttributes(t *testing.T, prefix string, attributes map[string]schema.Attribute) {
+	t.Helper()
+
+	for name, attribute := range attributes {
+		attributePath := prefix + "." + name
+
+		if credentialAttributeName.MatchString(name) && !attribute.IsSensitive() {
+			t.Errorf("attribute %q holds a credential but is not marked sensitive", attributePath)
+		}
+
+		switch nested := attribute.(type) {
+		case schema.SingleNestedAttribute:
+			checkSensitiveAttributes(t, attributePath, nested.Attributes)
+		case schema.ListNestedAttribute:
+			checkSensitiveAttributes(t, attributePath, nested.NestedObject.Attributes)
+		case schema.SetNestedAttribute:
+			checkSensitiveAttributes(t, attributePath, nested.NestedObject.Attributes)
+		case schema.MapNestedAttribute:
+			checkSensitiveAttributes(t, attributePath, nested.NestedObject.Attributes)
+		}
+	}
+}
+
+func checkSensitiveBlocks(t *testing.T, prefix string, blocks map[string]schema.Block) {
+	t.Helper()
+
+	for name, block := range blocks {
+		blockPath := prefix + "." + name
+
+		switch nested := block.(type) {
+		case schema.SingleNestedBlock:
+			checkSensitiveAttributes(t, blockPath, nested.Attributes)
+			checkSensitiveBlocks(t, blockPath, nested.Blocks)
+		case schema.ListNestedBlock:
+			checkSensitiveAttributes(t, blockPath, nested.NestedObject.Attributes)
+			checkSensitiveBlocks(t, blockPath, nested.NestedObject.Blocks)
+		case schema.SetNestedBlock:
+			checkSensitiveAttributes(t, blockPath, nested.NestedObject.Attributes)
+			checkSensitiveBlocks(t, blockPath, nested.NestedObject.Blocks)
+		}
+	}
+}
+
+// checkSensitiveDataSourceAttributes mirrors checkSensitiveAttributes for
+// data source schemas, which use their own attribute types.
+func checkSensitiveDataSourceAttributes(t *testing.T, prefix string, attributes map[string]dataschema.Attribute) {
+	t.Helper()
+
+	for name, attribute := range attributes {
+		attributePath := prefix + "." + name
+
+		if credentialAttributeName.MatchString(name) && !attribute.IsSensitive() {
+			t.Errorf("attribute %q holds a credential but is not marked sensitive", attributePath)
+		}
+
+		switch nested := attribute.(type) {
+		case dataschema.SingleNestedAttribute:
+			checkSensitiveDataSourceAttributes(t, attributePath, nested.Attributes)
+		case dataschema.ListNestedAttribute:
+			checkSensitiveDataSourceAttributes(t, attributePath, nested.NestedObject.Attributes)
+		case dataschema.SetNestedAttribute:
+			checkSensitiveDataSourceAttributes(t, attributePath, nested.NestedObject.Attributes)
+		case dataschema.MapNestedAttribute:
+			checkSensitiveDataSourceAttributes(t, attributePath, nested.NestedObject.Attributes)
+		}
+	}
+}
+
+func checkSensitiveDataSourceBlocks(t *testing.T, prefix string, blocks map[string]dataschema.Block) {
+	t.Helper()
+
+	for name, block := range blocks {
+		blockPath := prefix + "." + name
+
+		switch nested := block.(type) {
+		case dataschema.SingleNestedBlock:
+			checkSensitiveDataSourceAttributes(t, blockPath, nested.Attributes)
+			checkSensitiveDataSourceBlocks(t, blockPath, nested.Blocks)
+		case dataschema.ListNestedBlock:
+			checkSensitiveDataSourceAttributes(t, blockPath, nested.NestedObject.Attributes)
+			checkSensitiveDataSourceBlocks(t, blockPath, nested.NestedObject.Blocks)
+		case dataschema.SetNestedBlock:
+			checkSensitiveDataSourceAttributes(t, blockPath, nested.NestedObject.Attributes)
+			checkSensitiveDataSourceBlocks(t, blockPath, nested.NestedObject.Blocks)
+		}
+	}
+}
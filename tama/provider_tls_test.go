@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tama
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
+)
+
+func TestProvider_ConfigureInsecureSkipVerify(t *testing.T) {
+	// Keep credentials from the environment out of the test
+	t.Setenv("TAMA_BASE_URL", "")
+	t.Setenv("TAMA_CLIENT_ID", "")
+	t.Setenv("TAMA_CLIENT_SECRET", "")
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "test-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name               string
+		insecureSkipVerify types.Bool
+		expectWarning      bool
+		expectError        bool
+	}{
+		{"unset rejects self-signed certificate", types.BoolNull(), false, true},
+		{"disabled rejects self-signed certificate", types.BoolValue(false), false, true},
+		{"enabled accepts self-signed certificate", types.BoolValue(true), true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			p := &TamaProvider{version: "test"}
+
+			var schemaResp provider.SchemaResponse
+			p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+			data := TamaProviderModel{
+				BaseURL:            types.StringValue(server.URL),
+				ClientID:           types.StringValue("client-id"),
+				ClientSecret:       types.StringValue("client-secret"),
+				Scopes:             types.ListNull(types.StringType),
+				InsecureSkipVerify: tt.insecureSkipVerify,
+			}
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			if diags := state.Set(ctx, &data); diags.HasError() {
+				t.Fatalf("unable to build config: %v", diags)
+			}
+
+			var resp provider.ConfigureResponse
+			p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, &resp)
+
+			warned := false
+			for _, warning := range resp.Diagnostics.Warnings() {
+				if warning.Summary() == "TLS Certificate Verification Disabled" {
+					warned = true
+				}
+			}
+			if warned != tt.expectWarning {
+				t.Errorf("expected warning %t, got %v", tt.expectWarning, resp.Diagnostics)
+			}
+
+			if tt.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected the TLS handshake to fail")
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if _, ok := resp.ResourceData.(*meta.ProviderMeta); !ok {
+				t.Errorf("expected provider meta, got %T", resp.ResourceData)
+			}
+		})
+	}
+}
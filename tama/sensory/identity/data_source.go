@@ -12,6 +12,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
+	"github.com/upmaru/tama-go/sensory"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
+	"github.com/upmaru/terraform-provider-tama/internal/wait"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -41,6 +45,7 @@ type DataSourceModel struct {
 	Validation      *DataSourceValidationModel `tfsdk:"validation"`
 	ProvisionState  types.String               `tfsdk:"provision_state"`
 	CurrentState    types.String               `tfsdk:"current_state"`
+	WaitFor         []wait.DataSourceWaitFor   `tfsdk:"wait_for"`
 }
 
 func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -74,26 +79,33 @@ func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, r
 			},
 		},
 
-		Blocks: map[string]schema.Block{
-			"validation": schema.SingleNestedBlock{
-				MarkdownDescription: "Validation configuration for the identity",
-				Attributes: map[string]schema.Attribute{
-					"path": schema.StringAttribute{
-						MarkdownDescription: "Validation endpoint path",
-						Computed:            true,
-					},
-					"method": schema.StringAttribute{
-						MarkdownDescription: "HTTP method for validation",
-						Computed:            true,
-					},
-					"codes": schema.ListAttribute{
-						MarkdownDescription: "List of acceptable HTTP status codes",
-						Computed:            true,
-						ElementType:         types.Int64Type,
+		Blocks: func() map[string]schema.Block {
+			blocks := map[string]schema.Block{
+				"validation": schema.SingleNestedBlock{
+					MarkdownDescription: "Validation configuration for the identity",
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							MarkdownDescription: "Validation endpoint path",
+							Computed:            true,
+						},
+						"method": schema.StringAttribute{
+							MarkdownDescription: "HTTP method for validation",
+							Computed:            true,
+						},
+						"codes": schema.ListAttribute{
+							MarkdownDescription: "List of acceptable HTTP status codes",
+							Computed:            true,
+							ElementType:         types.Int64Type,
+						},
 					},
 				},
-			},
-		},
+			}
+			// Add wait_for blocks from the shared utility
+			for key, block := range wait.WaitForDataSourceBlockSchema(sensory.Identity{}) {
+				blocks[key] = block
+			}
+			return blocks
+		}(),
 	}
 }
 
@@ -103,18 +115,18 @@ func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerMeta.Client
 }
 
 func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -127,6 +139,17 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 		return
 	}
 
+	// Handle wait_for conditions before reading so state reflects the awaited object
+	if len(data.WaitFor) > 0 {
+		getIdentityFunc := func(id string) (any, error) {
+			return d.client.Sensory.GetIdentity(id)
+		}
+		if err := wait.ForDataSource(ctx, getIdentityFunc, data.Id.ValueString(), data.WaitFor); err != nil {
+			resp.Diagnostics.AddError("Wait Condition Failed", fmt.Sprintf("Unable to satisfy wait conditions: %s", err))
+			return
+		}
+	}
+
 	// Get identity from API
 	tflog.Debug(ctx, "Reading source identity", map[string]any{
 		"id": data.Id.ValueString(),
@@ -134,7 +157,7 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 
 	identityResponse, err := d.client.Sensory.GetIdentity(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read source identity, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read source identity, got error: %s", err)))
 		return
 	}
 
@@ -265,6 +265,84 @@ func TestAccSourceIdentityDataSource_Multiple(t *testing.T) {
 	})
 }
 
+func TestAccSourceIdentityDataSource_WaitFor(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSourceIdentityDataSourceConfigWaitFor("5m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.tama_source_identity.test", "id", "tama_source_identity.test", "id"),
+					resource.TestCheckResourceAttr("data.tama_source_identity.test", "provision_state", "active"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSourceIdentityDataSource_WaitForInvalidTimeout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSourceIdentityDataSourceConfigWaitFor("five minutes"),
+				ExpectError: regexp.MustCompile("invalid timeout"),
+			},
+		},
+	})
+}
+
+func testAccSourceIdentityDataSourceConfigWaitFor(timeout string) string {
+	timestamp := time.Now().UnixNano()
+	return acceptance.ProviderConfig + fmt.Sprintf(`
+resource "tama_space" "test_space" {
+  name = "test-space-for-identity-ds-wait-%d"
+  type = "root"
+}
+
+resource "tama_specification" "test_spec" {
+  space_id = tama_space.test_space.id
+  version  = "1.0.0"
+  endpoint = "https://elasticsearch.arrakis.upmaru.network"
+  schema   = jsonencode(jsondecode(file("${path.module}/testdata/elasticsearch_schema.json")))
+
+  wait_for {
+    field {
+      name = "current_state"
+      in   = ["completed"]
+    }
+  }
+}
+
+resource "tama_source_identity" "test" {
+  specification_id = tama_specification.test_spec.id
+  identifier       = "ApiKey"
+  api_key          = "test-api-key"
+
+  validation {
+    path   = "/health"
+    method = "GET"
+    codes  = [200]
+  }
+}
+
+data "tama_source_identity" "test" {
+  id = tama_source_identity.test.id
+
+  wait_for {
+    timeout = %[2]q
+
+    field {
+      name = "provision_state"
+      in   = ["active"]
+    }
+  }
+}
+`, timestamp, timeout)
+}
+
 func testAccSourceIdentityDataSourceConfig(identifier, apiKey, validationPath, validationMethod, validationCodes string) string {
 	timestamp := time.Now().UnixNano()
 	return acceptance.ProviderConfig + fmt.Sprintf(`
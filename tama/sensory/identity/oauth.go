@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package source_identity
+
+import (
+	"net/url"
+	"sort"
+
+	"github.com/upmaru/terraform-provider-tama/internal/endpoint"
+)
+
+// securityScheme returns the security scheme named identifier in the
+// components of an OpenAPI schema. ok is false when the schema does not
+// declare it.
+func securityScheme(schema map[string]any, identifier string) (scheme map[string]any, ok bool) {
+	components, _ := schema["components"].(map[string]any)
+	securitySchemes, _ := components["securitySchemes"].(map[string]any)
+	scheme, ok = securitySchemes[identifier].(map[string]any)
+	return scheme, ok
+}
+
+// clientCredentials returns the token URL and the sorted scopes declared by
+// the client credentials flow of the OAuth2 security scheme named identifier
+// in an OpenAPI schema, which is where the engine discovers them from. A
+// relative token URL is appended to base, the endpoint of the
+// specification. ok is false when the scheme is missing or does not declare
+// a client credentials flow.
+func clientCredentials(schema map[string]any, identifier, base string) (tokenURL string, scopes []string, ok bool) {
+	scheme, _ := securityScheme(schema, identifier)
+	if scheme["type"] != "oauth2" {
+		return "", nil, false
+	}
+
+	flows, _ := scheme["flows"].(map[string]any)
+	flow, isObject := flows["clientCredentials"].(map[string]any)
+	if !isObject {
+		return "", nil, false
+	}
+
+	tokenURL, _ = flow["tokenUrl"].(string)
+	if reference, err := url.Parse(tokenURL); err == nil && !reference.IsAbs() && tokenURL != "" {
+		tokenURL = endpoint.Join(base, tokenURL)
+	}
+
+	declared, _ := flow["scopes"].(map[string]any)
+	for scope := range declared {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+
+	return tokenURL, scopes, true
+}
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package source_identity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClientCredentials(t *testing.T) {
+	t.Parallel()
+
+	schemaWith := func(scheme map[string]any) map[string]any {
+		return map[string]any{
+			"components": map[string]any{
+				"securitySchemes": map[string]any{"OAuth": scheme},
+			},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		schema           map[string]any
+		expectedTokenURL string
+		expectedScopes   []string
+		expectedOK       bool
+	}{
+		{
+			name: "client credentials",
+			schema: schemaWith(map[string]any{
+				"type": "oauth2",
+				"flows": map[string]any{
+					"clientCredentials": map[string]any{
+						"tokenUrl": "https://auth.example.com/oauth/token",
+						"scopes":   map[string]any{"write": "Write access", "read": "Read access"},
+					},
+				},
+			}),
+			expectedTokenURL: "https://auth.example.com/oauth/token",
+			expectedScopes:   []string{"read", "write"},
+			expectedOK:       true,
+		},
+		{
+			name: "relative token URL",
+			schema: schemaWith(map[string]any{
+				"type": "oauth2",
+				"flows": map[string]any{
+					"clientCredentials": map[string]any{"tokenUrl": "/oauth/token", "scopes": map[string]any{}},
+				},
+			}),
+			expectedTokenURL: "https://api.example.com/v1/oauth/token",
+			expectedOK:       true,
+		},
+		{
+			name: "authorization code only",
+			schema: schemaWith(map[string]any{
+				"type": "oauth2",
+				"flows": map[string]any{
+					"authorizationCode": map[string]any{"tokenUrl": "https://auth.example.com/oauth/token"},
+				},
+			}),
+		},
+		{
+			name:   "api key",
+			schema: schemaWith(map[string]any{"type": "apiKey", "in": "header", "name": "X-API-Key"}),
+		},
+		{
+			name:   "no security schemes",
+			schema: map[string]any{"openapi": "3.0.3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tokenURL, scopes, ok := clientCredentials(tt.schema, "OAuth", "https://api.example.com/v1")
+			if ok != tt.expectedOK {
+				t.Fatalf("expected ok %t, got %t", tt.expectedOK, ok)
+			}
+			if tokenURL != tt.expectedTokenURL {
+				t.Errorf("expected token URL %q, got %q", tt.expectedTokenURL, tokenURL)
+			}
+			if !reflect.DeepEqual(scopes, tt.expectedScopes) {
+				t.Errorf("expected scopes %q, got %q", tt.expectedScopes, scopes)
+			}
+		})
+	}
+}
+
+func TestSecurityScheme(t *testing.T) {
+	t.Parallel()
+
+	schema := map[string]any{
+		"components": map[string]any{
+			"securitySchemes": map[string]any{
+				"ApiKey": map[string]any{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+			},
+		},
+	}
+
+	if scheme, ok := securityScheme(schema, "ApiKey"); !ok || scheme["type"] != "apiKey" {
+		t.Errorf("expected the ApiKey scheme, got %v (%t)", scheme, ok)
+	}
+	if _, ok := securityScheme(schema, "OAuth"); ok {
+		t.Error("expected no scheme for an undeclared identifier")
+	}
+	if _, ok := securityScheme(map[string]any{"openapi": "3.0.3"}, "ApiKey"); ok {
+		t.Error("expected no scheme without components")
+	}
+}
@@ -6,19 +6,29 @@ package source_identity
 import (
 	"context"
 	"fmt"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	resourcevalidator "github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/sensory"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/debug"
+	"github.com/upmaru/terraform-provider-tama/internal/endpoint"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
+	"github.com/upmaru/terraform-provider-tama/internal/provision"
 	"github.com/upmaru/terraform-provider-tama/internal/wait"
 )
 
@@ -33,7 +43,8 @@ func NewResource() resource.Resource {
 
 // Resource defines the resource implementation.
 type Resource struct {
-	client *tama.Client
+	client    *tama.Client
+	responses *client.Responses
 }
 
 // ValidationModel describes the validation nested object.
@@ -52,9 +63,14 @@ type ResourceModel struct {
 	ClientID        types.String     `tfsdk:"client_id"`
 	ClientSecret    types.String     `tfsdk:"client_secret"`
 	Validation      *ValidationModel `tfsdk:"validation"`
+	ValidationURL   types.String     `tfsdk:"validation_url"`
+	TokenURL        types.String     `tfsdk:"token_url"`
+	Scopes          types.List       `tfsdk:"scopes"`
 	ProvisionState  types.String     `tfsdk:"provision_state"`
 	CurrentState    types.String     `tfsdk:"current_state"`
+	Timeouts        timeouts.Value   `tfsdk:"timeouts"`
 	WaitFor         []wait.WaitFor   `tfsdk:"wait_for"`
+	RawResponseJSON types.String     `tfsdk:"raw_response_json"`
 }
 
 func (r *Resource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -101,10 +117,30 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"validation_url": schema.StringAttribute{
+				MarkdownDescription: "Full URL probed when validating the identity, combining the specification endpoint with the validation path. The specification is read on every refresh to resolve it, together with `token_url` and `scopes`, which costs one extra API request per identity",
+				Computed:            true,
+			},
+			"token_url": schema.StringAttribute{
+				MarkdownDescription: "Token URL of the OAuth2 client credentials flow declared for the identifier in the securitySchemes of the specification, which the engine requests tokens from. Informational only, null for identities without a client credentials flow.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scopes": schema.ListAttribute{
+				MarkdownDescription: "Scopes of the OAuth2 client credentials flow declared for the identifier in the securitySchemes of the specification, sorted. Informational only, null for identities without a client credentials flow.",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"provision_state": schema.StringAttribute{
 				MarkdownDescription: "Current provision state of the identity",
 				Computed:            true,
 			},
+			"raw_response_json": debug.RawResponseAttribute(),
 			"current_state": schema.StringAttribute{
 				MarkdownDescription: "Current state of the identity",
 				Computed:            true,
@@ -125,20 +161,26 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 							Required:            true,
 						},
 						"codes": schema.ListAttribute{
-							MarkdownDescription: "List of acceptable HTTP status codes",
+							MarkdownDescription: "List of acceptable HTTP status codes, each between 100 and 599",
 							Required:            true,
 							ElementType:         types.Int64Type,
-							PlanModifiers: []planmodifier.List{
-								listplanmodifier.RequiresReplace(),
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+								listvalidator.ValueInt64sAre(int64validator.Between(100, 599)),
 							},
 						},
 					},
 				},
 			}
 			// Add wait_for blocks from the shared utility
-			for key, block := range wait.WaitForBlockSchema() {
+			for key, block := range wait.WaitForBlockSchema(sensory.Identity{}) {
 				blocks[key] = block
 			}
+			blocks["timeouts"] = timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			})
 			return blocks
 		}(),
 	}
@@ -175,18 +217,19 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerMeta.Client
+	r.responses = providerMeta.Responses
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -199,6 +242,15 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, wait.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Convert codes from types.List to []int
 	var codes []int64
 	resp.Diagnostics.Append(data.Validation.Codes.ElementsAs(ctx, &codes, false)...)
@@ -239,7 +291,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		createRequest,
 	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create source identity, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to create source identity, got error: %s", err)))
 		return
 	}
 
@@ -267,23 +319,36 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		Codes:  codesList,
 	}
 
-	// Handle wait_for conditions if specified
+	resp.Diagnostics.Append(r.readSpecification(identityResponse, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Handle wait_for conditions if specified, recording the states read
+	// once they hold rather than the transient ones from the create response
 	if len(data.WaitFor) > 0 {
 		getIdentityFunc := func(id string) (any, error) {
 			return r.client.Sensory.GetIdentity(id)
 		}
-		for _, waitFor := range data.WaitFor {
-			err := wait.ForConditions(ctx, getIdentityFunc, data.Id.ValueString(), waitFor.Field, 10*time.Minute)
-			if err != nil {
-				resp.Diagnostics.AddError("Wait Condition Failed", fmt.Sprintf("Unable to satisfy wait conditions: %s", err))
-				return
-			}
+		settled, err := wait.Until(ctx, getIdentityFunc, data.Id.ValueString(), data.WaitFor, createTimeout)
+		if err != nil {
+			err = wait.PhaseTimeoutError(ctx, "create", createTimeout, err)
+			resp.Diagnostics.AddError("Wait Condition Failed", fmt.Sprintf("Unable to satisfy wait conditions: %s", err))
+			return
+		}
+		if identity, ok := settled.(*sensory.Identity); ok {
+			identityResponse = identity
+			data.ProvisionState = types.StringValue(identityResponse.ProvisionState)
+			data.CurrentState = types.StringValue(identityResponse.CurrentState)
 		}
 	}
 
 	// Write logs using the tflog package
 	tflog.Trace(ctx, "created a source identity resource")
 
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, identityResponse.ID)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -301,7 +366,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	// Get identity from API
 	identityResponse, err := r.client.Sensory.GetIdentity(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read source identity, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read source identity, got error: %s", err)))
 		return
 	}
 
@@ -328,13 +393,26 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		Codes:  codesList,
 	}
 
+	resp.Diagnostics.Append(r.readSpecification(identityResponse, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Note: API key is not returned in response, keep the original value
 
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, identityResponse.ID)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(provision.CheckUpdatable(ctx, req.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ResourceModel
 
 	// Read Terraform plan data into the model
@@ -344,6 +422,15 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, wait.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Convert codes from types.List to []int
 	var codes []int64
 	resp.Diagnostics.Append(data.Validation.Codes.ElementsAs(ctx, &codes, false)...)
@@ -379,7 +466,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	identityResponse, err := r.client.Sensory.UpdateIdentity(data.Id.ValueString(), updateRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update source identity, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to update source identity, got error: %s", err)))
 		return
 	}
 
@@ -406,22 +493,35 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		Codes:  codesList,
 	}
 
+	resp.Diagnostics.Append(r.readSpecification(identityResponse, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Note: API key is not returned in response, keep the original value
 
-	// Handle wait_for conditions if specified
+	// Handle wait_for conditions if specified, recording the states read
+	// once they hold rather than the transient ones from the update response
 	if len(data.WaitFor) > 0 {
 		getIdentityFunc := func(id string) (any, error) {
 			return r.client.Sensory.GetIdentity(id)
 		}
-		for _, waitFor := range data.WaitFor {
-			err := wait.ForConditions(ctx, getIdentityFunc, data.Id.ValueString(), waitFor.Field, 10*time.Minute)
-			if err != nil {
-				resp.Diagnostics.AddError("Wait Condition Failed", fmt.Sprintf("Unable to satisfy wait conditions: %s", err))
-				return
-			}
+		settled, err := wait.Until(ctx, getIdentityFunc, data.Id.ValueString(), data.WaitFor, updateTimeout)
+		if err != nil {
+			err = wait.PhaseTimeoutError(ctx, "update", updateTimeout, err)
+			resp.Diagnostics.AddError("Wait Condition Failed", fmt.Sprintf("Unable to satisfy wait conditions: %s", err))
+			return
+		}
+		if identity, ok := settled.(*sensory.Identity); ok {
+			identityResponse = identity
+			data.ProvisionState = types.StringValue(identityResponse.ProvisionState)
+			data.CurrentState = types.StringValue(identityResponse.CurrentState)
 		}
 	}
 
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, identityResponse.ID)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -436,6 +536,15 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, wait.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete identity using the Tama client
 	tflog.Debug(ctx, "Deleting source identity", map[string]any{
 		"id": data.Id.ValueString(),
@@ -443,7 +552,8 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 
 	err := r.client.Sensory.DeleteIdentity(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete source identity, got error: %s", err))
+		err = wait.PhaseTimeoutError(ctx, "delete", deleteTimeout, err)
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to delete source identity, got error: %s", err)))
 		return
 	}
 }
@@ -452,7 +562,7 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 	// Get identity from API to populate state
 	identityResponse, err := r.client.Sensory.GetIdentity(req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import source identity, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to import source identity, got error: %s", err)))
 		return
 	}
 
@@ -484,8 +594,63 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 		ApiKey:       types.StringValue(""),
 		ClientID:     types.StringValue(""),
 		ClientSecret: types.StringValue(""),
+		Timeouts:     wait.NullTimeouts(),
+	}
+
+	resp.Diagnostics.Append(r.readSpecification(identityResponse, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, identityResponse.ID)
+
 	// Save imported data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// readSpecification sets the attributes derived from the specification of
+// the identity: the URL probed when validating the identity, the endpoint
+// joined with the validation path, and the token URL and scopes of its OAuth2
+// client credentials flow, which are null for other kinds of identities. An
+// identifier the specification does not declare as a security scheme is
+// reported as a warning.
+func (r *Resource) readSpecification(identity *sensory.Identity, data *ResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	specification, err := r.client.Sensory.GetSpecification(identity.SpecificationID)
+	if err != nil {
+		diags.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read specification for source identity, got error: %s", err)))
+		return diags
+	}
+
+	data.ValidationURL = types.StringValue(endpoint.Join(specification.Endpoint, identity.Validation.Path))
+	data.TokenURL = types.StringNull()
+	data.Scopes = types.ListNull(types.StringType)
+
+	if _, declared := securityScheme(specification.Schema, identity.Identifier); !declared {
+		diags.AddAttributeWarning(
+			path.Root("identifier"),
+			"Security Scheme Not Found",
+			fmt.Sprintf("The specification does not declare a security scheme named %q in components.securitySchemes, so token_url and scopes are left null.", identity.Identifier),
+		)
+		return diags
+	}
+
+	tokenURL, scopes, ok := clientCredentials(specification.Schema, identity.Identifier, specification.Endpoint)
+	if !ok {
+		return diags
+	}
+
+	if tokenURL != "" {
+		data.TokenURL = types.StringValue(tokenURL)
+	}
+
+	scopeValues := make([]attr.Value, len(scopes))
+	for i, scope := range scopes {
+		scopeValues[i] = types.StringValue(scope)
+	}
+	data.Scopes = types.ListValueMust(types.StringType, scopeValues)
+
+	return diags
+}
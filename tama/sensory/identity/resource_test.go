@@ -10,10 +10,14 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/upmaru/terraform-provider-tama/internal/acceptance"
 )
 
 func TestAccSourceIdentityResource(t *testing.T) {
+	var identityID string
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
 		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
@@ -28,10 +32,14 @@ func TestAccSourceIdentityResource(t *testing.T) {
 					resource.TestCheckResourceAttr("tama_source_identity.test", "validation.method", "GET"),
 					resource.TestCheckResourceAttr("tama_source_identity.test", "validation.codes.#", "1"),
 					resource.TestCheckResourceAttr("tama_source_identity.test", "validation.codes.0", "200"),
+					resource.TestCheckResourceAttr("tama_source_identity.test", "validation_url", "https://elasticsearch.arrakis.upmaru.network/health"),
+					resource.TestCheckNoResourceAttr("tama_source_identity.test", "token_url"),
+					resource.TestCheckNoResourceAttr("tama_source_identity.test", "scopes.#"),
 					resource.TestCheckResourceAttrSet("tama_source_identity.test", "id"),
 					resource.TestCheckResourceAttrSet("tama_source_identity.test", "specification_id"),
 					resource.TestCheckResourceAttrSet("tama_source_identity.test", "provision_state"),
 					resource.TestCheckResourceAttrSet("tama_source_identity.test", "current_state"),
+					testAccCaptureSourceIdentityID("tama_source_identity.test", &identityID),
 				),
 			},
 			// ImportState testing
@@ -41,10 +49,16 @@ func TestAccSourceIdentityResource(t *testing.T) {
 				ImportStateVerify:       false, // api_key cannot be retrieved from API
 				ImportStateVerifyIgnore: []string{"api_key"},
 			},
-			// Update and Read testing
+			// Update and Read testing, validation changes are applied in place
 			{
 				Config: testAccSourceIdentityResourceConfig("ApiKey", "updated-api-key", "/status", "POST", "[200, 201]"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("tama_source_identity.test", plancheck.ResourceActionUpdate),
+					},
+				},
 				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckSourceIdentityID("tama_source_identity.test", &identityID),
 					resource.TestCheckResourceAttr("tama_source_identity.test", "identifier", "ApiKey"),
 					resource.TestCheckResourceAttr("tama_source_identity.test", "api_key", "updated-api-key"),
 					resource.TestCheckResourceAttr("tama_source_identity.test", "validation.path", "/status"),
@@ -52,6 +66,7 @@ func TestAccSourceIdentityResource(t *testing.T) {
 					resource.TestCheckResourceAttr("tama_source_identity.test", "validation.codes.#", "2"),
 					resource.TestCheckResourceAttr("tama_source_identity.test", "validation.codes.0", "200"),
 					resource.TestCheckResourceAttr("tama_source_identity.test", "validation.codes.1", "201"),
+					resource.TestCheckResourceAttr("tama_source_identity.test", "validation_url", "https://elasticsearch.arrakis.upmaru.network/status"),
 					resource.TestCheckResourceAttrSet("tama_source_identity.test", "id"),
 					resource.TestCheckResourceAttrSet("tama_source_identity.test", "provision_state"),
 					resource.TestCheckResourceAttrSet("tama_source_identity.test", "current_state"),
@@ -83,8 +98,16 @@ func TestAccSourceIdentityResource_ClientCredentials(t *testing.T) {
 					resource.TestCheckResourceAttrSet("tama_source_identity.test", "specification_id"),
 					resource.TestCheckResourceAttrSet("tama_source_identity.test", "provision_state"),
 					resource.TestCheckResourceAttrSet("tama_source_identity.test", "current_state"),
+					resource.TestCheckResourceAttr("tama_source_identity.test", "token_url", "http://localhost:4001/tama/auth/tokens"),
+					resource.TestCheckResourceAttr("tama_source_identity.test", "scopes.#", "1"),
+					resource.TestCheckResourceAttr("tama_source_identity.test", "scopes.0", "all"),
 				),
 			},
+			// token_url and scopes are informational and never cause a change
+			{
+				Config:   testAccSourceIdentityResourceConfigWithClientCredentials("oauth", "test-client-id", "test-client-secret", "/health", "GET", "[200]"),
+				PlanOnly: true,
+			},
 		},
 	})
 }
@@ -135,12 +158,30 @@ func TestAccSourceIdentityResource_EmptyCodes(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config:      testAccSourceIdentityResourceConfig("ApiKey", "test-api-key", "/health", "GET", "[]"),
-				ExpectError: regexp.MustCompile("Unable to create source identity"),
+				ExpectError: regexp.MustCompile("must contain at least 1 elements"),
 			},
 		},
 	})
 }
 
+func TestAccSourceIdentityResource_OutOfRangeCodes(t *testing.T) {
+	for _, codes := range []string{"[20]", "[200, 999]"} {
+		t.Run(codes, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccSourceIdentityResourceConfig("ApiKey", "test-api-key", "/health", "GET", codes),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile("value must be between 100 and 599"),
+					},
+				},
+			})
+		})
+	}
+}
+
 func TestAccSourceIdentityResource_DifferentIdentifiers(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
@@ -358,6 +399,48 @@ func TestAccSourceIdentityResource_WaitForMultipleConditions(t *testing.T) {
 	})
 }
 
+func TestAccSourceIdentityResource_Timeouts(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSourceIdentityResourceConfigTimeouts("1s"),
+				ExpectError: regexp.MustCompile(`create timeout of 1s exceeded`),
+			},
+		},
+	})
+}
+
+// testAccCaptureSourceIdentityID records the identity id so later steps can
+// assert that it did not change.
+func testAccCaptureSourceIdentityID(resourceName string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+// testAccCheckSourceIdentityID verifies the identity id matches the captured one.
+func testAccCheckSourceIdentityID(resourceName string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		if rs.Primary.ID != *id {
+			return fmt.Errorf("expected identity %s to be updated in place, got new id %s", *id, rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
 func testAccSourceIdentityResourceConfig(identifier, apiKey, validationPath, validationMethod, validationCodes string) string {
 	timestamp := time.Now().UnixNano()
 	return acceptance.ProviderConfig + fmt.Sprintf(`
@@ -630,3 +713,50 @@ resource "tama_source_identity" "test" {
 }
 `, identifier, apiKey, validationPath, validationMethod, validationCodes)
 }
+
+func testAccSourceIdentityResourceConfigTimeouts(createTimeout string) string {
+	timestamp := time.Now().UnixNano()
+	return acceptance.ProviderConfig + fmt.Sprintf(`
+resource "tama_space" "test_space" {
+  name = "test-space-for-identity-timeouts-%d"
+  type = "root"
+}
+
+resource "tama_specification" "test_spec" {
+  space_id = tama_space.test_space.id
+  version  = "1.0.0"
+  endpoint = "https://elasticsearch.arrakis.upmaru.network"
+  schema   = jsonencode(jsondecode(file("${path.module}/testdata/elasticsearch_schema.json")))
+
+  wait_for {
+    field {
+      name = "current_state"
+      in   = ["completed"]
+    }
+  }
+}
+
+resource "tama_source_identity" "test" {
+  specification_id = tama_specification.test_spec.id
+  identifier       = "ApiKey"
+  api_key          = "test-api-key"
+
+  validation {
+    path   = "/health"
+    method = "GET"
+    codes  = [200]
+  }
+
+  wait_for {
+    field {
+      name = "current_state"
+      in   = ["never"]
+    }
+  }
+
+  timeouts {
+    create = %q
+  }
+}
+`, timestamp, createTimeout)
+}
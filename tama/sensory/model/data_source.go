@@ -13,6 +13,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -29,10 +31,11 @@ type DataSource struct {
 
 // DataSourceModel describes the data source data model.
 type DataSourceModel struct {
-	Id         types.String `tfsdk:"id"`
-	Identifier types.String `tfsdk:"identifier"`
-	Path       types.String `tfsdk:"path"`
-	Parameters types.String `tfsdk:"parameters"`
+	Id             types.String `tfsdk:"id"`
+	Identifier     types.String `tfsdk:"identifier"`
+	Path           types.String `tfsdk:"path"`
+	Parameters     types.String `tfsdk:"parameters"`
+	ProvisionState types.String `tfsdk:"provision_state"`
 }
 
 func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -60,6 +63,10 @@ func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, r
 				MarkdownDescription: "Model parameters as JSON string",
 				Computed:            true,
 			},
+			"provision_state": schema.StringAttribute{
+				MarkdownDescription: "Current provision state of the model",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -70,18 +77,18 @@ func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerMeta.Client
 }
 
 func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -101,13 +108,14 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 
 	modelResponse, err := d.client.Sensory.GetModel(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read model, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read model, got error: %s", err)))
 		return
 	}
 
 	// Map response to data source schema
 	data.Id = types.StringValue(modelResponse.ID)
 	data.Identifier = types.StringValue(modelResponse.Identifier)
+	data.ProvisionState = types.StringValue(modelResponse.ProvisionState)
 	// Note: Path is not available in API response
 
 	// Handle parameters from response
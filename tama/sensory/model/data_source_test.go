@@ -23,6 +23,7 @@ func TestAccModelDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.tama_model.test", "identifier", "test-model"),
 					resource.TestCheckResourceAttrSet("data.tama_model.test", "id"),
 					resource.TestCheckResourceAttr("data.tama_model.test", "parameters", ""),
+					resource.TestCheckResourceAttrSet("data.tama_model.test", "provision_state"),
 				),
 			},
 		},
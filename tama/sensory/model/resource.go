@@ -8,20 +8,31 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/sensory"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/debug"
+	"github.com/upmaru/terraform-provider-tama/internal/endpoint"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
 	internalplanmodifier "github.com/upmaru/terraform-provider-tama/internal/planmodifier"
+	"github.com/upmaru/terraform-provider-tama/internal/processor"
+	"github.com/upmaru/terraform-provider-tama/internal/provision"
+	"github.com/upmaru/terraform-provider-tama/internal/validators"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
+var _ resource.ResourceWithModifyPlan = &Resource{}
 
 func NewResource() resource.Resource {
 	return &Resource{}
@@ -29,16 +40,23 @@ func NewResource() resource.Resource {
 
 // Resource defines the resource implementation.
 type Resource struct {
-	client *tama.Client
+	client               *tama.Client
+	responses            *client.Responses
+	disableNormalization bool
 }
 
 // ResourceModel describes the resource data model.
 type ResourceModel struct {
-	Id         types.String `tfsdk:"id"`
-	SourceId   types.String `tfsdk:"source_id"`
-	Identifier types.String `tfsdk:"identifier"`
-	Path       types.String `tfsdk:"path"`
-	Parameters types.String `tfsdk:"parameters"`
+	Id                  types.String `tfsdk:"id"`
+	SourceId            types.String `tfsdk:"source_id"`
+	Identifier          types.String `tfsdk:"identifier"`
+	Path                types.String `tfsdk:"path"`
+	Parameters          types.String `tfsdk:"parameters"`
+	ParametersWO        types.String `tfsdk:"parameters_wo"`
+	ParametersWOVersion types.Int64  `tfsdk:"parameters_wo_version"`
+	EffectiveURL        types.String `tfsdk:"effective_url"`
+	ProvisionState      types.String `tfsdk:"provision_state"`
+	RawResponseJSON     types.String `tfsdk:"raw_response_json"`
 }
 
 func (r *Resource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -69,17 +87,46 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				Required:            true,
 			},
 			"path": schema.StringAttribute{
-				MarkdownDescription: "API path for the model (e.g., '/chat/completions')",
+				MarkdownDescription: "API path for the model (e.g., '/chat/completions'). Changing the path to a different endpoint category (completions, embeddings or rerank) forces a new resource to be created",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceOnModalityChange,
+						"Changing the path to a different endpoint category forces a new resource to be created.",
+						"Changing the path to a different endpoint category forces a new resource to be created.",
+					),
+				},
 			},
 			"parameters": schema.StringAttribute{
-				MarkdownDescription: "Model parameters as JSON string (e.g., '{\"temperature\": 0.8, \"max_tokens\": 1500}')",
+				MarkdownDescription: "Model parameters as JSON string (e.g., '{\"temperature\": 0.8, \"max_tokens\": 1500}'). The same keys in the completion parameters of a processor using the model take precedence",
 				Optional:            true,
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					internalplanmodifier.JSONNormalize(),
 				},
 			},
+			"parameters_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only model parameters as JSON string, for values such as keys which must not be stored in state. They are merged with `parameters` when the model is sent, a key may not be set in both. Requires Terraform 1.11 or later",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					validators.JSONObject(),
+				},
+			},
+			"parameters_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `parameters_wo`. Terraform does not track write-only values, change this to send updated write-only parameters",
+				Optional:            true,
+			},
+			"effective_url": schema.StringAttribute{
+				MarkdownDescription: "URL requests for this model are sent to, the source endpoint joined with the model path. Resolved when the model is created and when `source_id` or `path` changes, otherwise kept from state. A change to the endpoint of the source alone is picked up the next time it is resolved",
+				Computed:            true,
+			},
+			"provision_state": schema.StringAttribute{
+				MarkdownDescription: "Current provision state of the model",
+				Computed:            true,
+			},
+			"raw_response_json": debug.RawResponseAttribute(),
 		},
 	}
 }
@@ -90,18 +137,58 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerMeta.Client
+	r.responses = providerMeta.Responses
+	r.disableNormalization = providerMeta.DisableJSONNormalization
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Plan formatting changes when the provider disables normalization
+	if r.disableNormalization {
+		resp.Diagnostics.Append(internalplanmodifier.KeepConfiguredJSON(ctx, req.Config, &resp.Plan, path.MatchRoot("parameters"))...)
+	}
+
+	// Nothing to plan on create or destroy, effective_url is then known
+	// after apply
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state ResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// effective_url is only resolved again when the source or the path
+	// changes, otherwise the value in state is kept
+	if plan.SourceId.Equal(state.SourceId) && plan.Path.Equal(state.Path) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("effective_url"), state.EffectiveURL)...)
+	}
+}
+
+// requiresReplaceOnModalityChange replaces the model when its path moves to a
+// different endpoint category, e.g. from /chat/completions to /embeddings.
+// Processors built on the model expect the category it was created with.
+func requiresReplaceOnModalityChange(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	resp.RequiresReplace = processor.ModalityForPath(req.StateValue.ValueString()) != processor.ModalityForPath(req.PlanValue.ValueString())
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -123,31 +210,51 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		}
 	}
 
+	// Add the write-only parameters, which are only found in the configuration
+	writeOnly, diags := writeOnlyParameters(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	merged, err := mergeParameters(parameters, writeOnly)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("parameters_wo"), "Invalid Parameters", err.Error())
+		return
+	}
+	writeOnlyKeys := sortedKeys(writeOnly)
+
 	// Create model using the Tama client
 	createRequest := sensory.CreateModelRequest{
 		Model: sensory.ModelRequestData{
 			Identifier: data.Identifier.ValueString(),
 			Path:       data.Path.ValueString(),
-			Parameters: parameters,
+			Parameters: merged,
 		},
 	}
 
-	tflog.Debug(ctx, "Creating model", map[string]any{
-		"source_id":  data.SourceId.ValueString(),
-		"identifier": data.Identifier.ValueString(),
-		"path":       data.Path.ValueString(),
-		"parameters": parameters,
-	})
+	tflog.Debug(ctx, "Creating model", debug.LogFields(map[string]any{
+		"source_id":          data.SourceId.ValueString(),
+		"identifier":         data.Identifier.ValueString(),
+		"path":               data.Path.ValueString(),
+		"parameters":         parameters,
+		"parameters_wo_keys": writeOnlyKeys,
+	}))
 
 	modelResponse, err := r.client.Sensory.CreateModel(data.SourceId.ValueString(), createRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create model, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to create model, got error: %s", err)))
 		return
 	}
 
+	// Remember which parameters are write-only so later reads leave them out
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, writeOnlyKeysKey, encodeWriteOnlyKeys(writeOnlyKeys))...)
+	modelResponse = redactModel(modelResponse, writeOnlyKeys)
+
 	// Map response body to schema and populate Computed attribute values
 	data.Id = types.StringValue(modelResponse.ID)
 	data.Identifier = types.StringValue(modelResponse.Identifier)
+	data.ProvisionState = types.StringValue(modelResponse.ProvisionState)
 	// Note: Path is not returned in response, keep the original value
 
 	// Handle parameters from response
@@ -168,6 +275,14 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	// Write logs using the tflog package
 	tflog.Trace(ctx, "created a model resource")
 
+	// Resolve the URL after the write, the source may have changed in the
+	// same apply
+	data.EffectiveURL, diags = r.resolveEffectiveURL(data.SourceId.ValueString(), data.Path.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, modelResponse.ID)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -185,12 +300,20 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	// Get model from API
 	modelResponse, err := r.client.Sensory.GetModel(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read model, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read model, got error: %s", err)))
 		return
 	}
 
+	writeOnlyKeys, diags := storedWriteOnlyKeys(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	modelResponse = redactModel(modelResponse, writeOnlyKeys)
+
 	// Update the model with the latest data
 	data.Identifier = types.StringValue(modelResponse.Identifier)
+	data.ProvisionState = types.StringValue(modelResponse.ProvisionState)
 	// Note: Path is not returned in response, keep the existing value
 
 	// Handle parameters from response
@@ -208,11 +331,22 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		data.Parameters = types.StringValue("")
 	}
 
+	// effective_url is kept from state: source_id and path are not returned
+	// by the API, so they cannot change on read
+
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, modelResponse.ID)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(provision.CheckUpdatable(ctx, req.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ResourceModel
 
 	// Read Terraform plan data into the model
@@ -231,30 +365,50 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		}
 	}
 
+	// Add the write-only parameters, which are only found in the configuration
+	writeOnly, diags := writeOnlyParameters(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	merged, err := mergeParameters(parameters, writeOnly)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("parameters_wo"), "Invalid Parameters", err.Error())
+		return
+	}
+	writeOnlyKeys := sortedKeys(writeOnly)
+
 	// Update model using the Tama client
 	updateRequest := sensory.UpdateModelRequest{
 		Model: sensory.UpdateModelData{
 			Identifier: data.Identifier.ValueString(),
 			Path:       data.Path.ValueString(),
-			Parameters: parameters,
+			Parameters: merged,
 		},
 	}
 
-	tflog.Debug(ctx, "Updating model", map[string]any{
-		"id":         data.Id.ValueString(),
-		"identifier": data.Identifier.ValueString(),
-		"path":       data.Path.ValueString(),
-		"parameters": parameters,
-	})
+	tflog.Debug(ctx, "Updating model", debug.LogFields(map[string]any{
+		"id":                 data.Id.ValueString(),
+		"identifier":         data.Identifier.ValueString(),
+		"path":               data.Path.ValueString(),
+		"parameters":         parameters,
+		"parameters_wo_keys": writeOnlyKeys,
+	}))
 
 	modelResponse, err := r.client.Sensory.UpdateModel(data.Id.ValueString(), updateRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update model, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to update model, got error: %s", err)))
 		return
 	}
 
+	// Remember which parameters are write-only so later reads leave them out
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, writeOnlyKeysKey, encodeWriteOnlyKeys(writeOnlyKeys))...)
+	modelResponse = redactModel(modelResponse, writeOnlyKeys)
+
 	// Update the model with the response data
 	data.Identifier = types.StringValue(modelResponse.Identifier)
+	data.ProvisionState = types.StringValue(modelResponse.ProvisionState)
 	// Note: Path is not returned in response, keep the existing value
 
 	// Handle parameters from response
@@ -272,6 +426,14 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		data.Parameters = types.StringValue("")
 	}
 
+	// Resolve the URL after the write, the source may have changed in the
+	// same apply
+	data.EffectiveURL, diags = r.resolveEffectiveURL(data.SourceId.ValueString(), data.Path.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, modelResponse.ID)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -293,7 +455,7 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 
 	err := r.client.Sensory.DeleteModel(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete model, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to delete model, got error: %s", err)))
 		return
 	}
 }
@@ -302,7 +464,7 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 	// Get model from API to populate state
 	modelResponse, err := r.client.Sensory.GetModel(req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import model, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to import model, got error: %s", err)))
 		return
 	}
 
@@ -326,10 +488,58 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 		Parameters: parametersValue,
 		// SourceId and Path cannot be retrieved from API response
 		// These will need to be manually set after import
-		SourceId: types.StringValue(""),
-		Path:     types.StringValue(""),
+		SourceId:       types.StringValue(""),
+		Path:           types.StringValue(""),
+		EffectiveURL:   types.StringNull(),
+		ProvisionState: types.StringValue(modelResponse.ProvisionState),
 	}
 
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, modelResponse.ID)
+
 	// Save imported data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// effectiveURL joins the endpoint of the source with the model path.
+func (r *Resource) effectiveURL(sourceID, modelPath string) (string, error) {
+	sourceResponse, err := r.client.Sensory.GetSource(sourceID)
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint.Join(sourceResponse.Endpoint, modelPath), nil
+}
+
+// resolveEffectiveURL returns the effective URL for state, or null when it
+// cannot be determined (e.g. after import where source_id is not known). The
+// URL is checked for suspicious joins, and a source that cannot be read is
+// reported as a warning.
+func (r *Resource) resolveEffectiveURL(sourceID, modelPath string) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if sourceID == "" {
+		return types.StringNull(), diags
+	}
+
+	effectiveURL, err := r.effectiveURL(sourceID, modelPath)
+	if err != nil {
+		diags.AddAttributeWarning(
+			path.Root("effective_url"),
+			"Unable to Resolve Model URL",
+			fmt.Sprintf("Unable to read source %s, effective_url is left empty: %s", sourceID, err),
+		)
+		return types.StringNull(), diags
+	}
+
+	for _, issue := range endpoint.Lint(effectiveURL) {
+		diags.AddAttributeWarning(
+			path.Root("path"),
+			"Suspicious Model URL",
+			fmt.Sprintf("The source endpoint joined with the model path gives %s. "+
+				"This usually means the version prefix is present in both the source endpoint and the model path.", issue),
+		)
+	}
+
+	return types.StringValue(effectiveURL), diags
+}
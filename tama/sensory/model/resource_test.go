@@ -11,7 +11,9 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"github.com/upmaru/terraform-provider-tama/internal/acceptance"
 )
 
@@ -59,8 +61,10 @@ func TestAccModelResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("tama_model.test", "identifier", "mistral-small-latest"),
 					resource.TestCheckResourceAttr("tama_model.test", "path", "/chat/completions"),
+					resource.TestCheckResourceAttr("tama_model.test", "effective_url", "https://api.example.com/chat/completions"),
 					resource.TestCheckResourceAttrSet("tama_model.test", "id"),
 					resource.TestCheckResourceAttrSet("tama_model.test", "source_id"),
+					resource.TestCheckResourceAttrSet("tama_model.test", "provision_state"),
 				),
 			},
 			// ImportState testing
@@ -68,7 +72,7 @@ func TestAccModelResource(t *testing.T) {
 				ResourceName:            "tama_model.test",
 				ImportState:             true,
 				ImportStateVerify:       false, // SourceId and Path cannot be retrieved from API
-				ImportStateVerifyIgnore: []string{"source_id", "path"},
+				ImportStateVerifyIgnore: []string{"source_id", "path", "effective_url"},
 			},
 			// Update and Read testing
 			{
@@ -76,6 +80,7 @@ func TestAccModelResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("tama_model.test", "identifier", "mistral-large-latest"),
 					resource.TestCheckResourceAttr("tama_model.test", "path", "/v1/chat/completions"),
+					resource.TestCheckResourceAttr("tama_model.test", "effective_url", "https://api.example.com/v1/chat/completions"),
 					resource.TestCheckResourceAttrSet("tama_model.test", "id"),
 				),
 			},
@@ -240,6 +245,39 @@ func TestAccModelResource_PathVariations(t *testing.T) {
 	}
 }
 
+func TestAccModelResource_PathCategoryChange(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccModelResourceConfig("test-model", "/chat/completions"),
+				Check:  resource.TestCheckResourceAttr("tama_model.test", "path", "/chat/completions"),
+			},
+			// A path edit within the same category is updated in place
+			{
+				Config: testAccModelResourceConfig("test-model", "/v1/chat/completions"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("tama_model.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("tama_model.test", "path", "/v1/chat/completions"),
+			},
+			// Moving to a different category recreates the model
+			{
+				Config: testAccModelResourceConfig("test-model", "/v1/embeddings"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("tama_model.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("tama_model.test", "path", "/v1/embeddings"),
+			},
+		},
+	})
+}
+
 func TestAccModelResource_LongIdentifier(t *testing.T) {
 	longIdentifier := "this-is-a-very-long-model-identifier-that-might-exceed-database-limits-and-should-be-tested-for-proper-error-handling"
 	resource.Test(t, resource.TestCase{
@@ -624,6 +662,98 @@ resource "tama_model" "anthropic" {
 `
 }
 
+// testCheckSentParameter checks the parameters the API holds for the model,
+// as read by the data source, include key with the expected value.
+func testCheckSentParameter(key, expected string) resource.TestCheckFunc {
+	return resource.TestCheckResourceAttrWith("data.tama_model.test", "parameters", func(value string) error {
+		var parameters map[string]any
+		if err := json.Unmarshal([]byte(value), &parameters); err != nil {
+			return fmt.Errorf("parameters are not valid JSON: %v", err)
+		}
+
+		if actual, ok := parameters[key]; !ok || actual != expected {
+			return fmt.Errorf("expected parameter %q to be %q, got %v", key, expected, actual)
+		}
+
+		return nil
+	})
+}
+
+func TestAccModelResource_WriteOnlyParameters(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			// The write-only parameters are sent, the data source reads them
+			// back from the API, but they never reach the resource state
+			{
+				Config: testAccModelResourceConfigWriteOnlyParameters(`{"temperature": 0.8}`, `{"project_key": "secret-key-1"}`, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckJSONEqual(`{"temperature": 0.8}`),
+					resource.TestCheckNoResourceAttr("tama_model.test", "parameters_wo"),
+					resource.TestCheckResourceAttr("tama_model.test", "parameters_wo_version", "1"),
+					testCheckSentParameter("project_key", "secret-key-1"),
+				),
+			},
+			// Bumping the version sends the new write-only parameters
+			{
+				Config: testAccModelResourceConfigWriteOnlyParameters(`{"temperature": 0.8}`, `{"project_key": "secret-key-2"}`, 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckJSONEqual(`{"temperature": 0.8}`),
+					resource.TestCheckNoResourceAttr("tama_model.test", "parameters_wo"),
+					resource.TestCheckResourceAttr("tama_model.test", "parameters_wo_version", "2"),
+					testCheckSentParameter("project_key", "secret-key-2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccModelResource_WriteOnlyParametersWithoutParameters(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			// The computed parameters must not pick up the write-only values
+			{
+				Config: testAccModelResourceConfigWriteOnlyParameters("", `{"project_key": "secret-key"}`, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tama_model.test", "parameters", ""),
+					resource.TestCheckNoResourceAttr("tama_model.test", "parameters_wo"),
+					testCheckSentParameter("project_key", "secret-key"),
+				),
+			},
+			// Reading the model again keeps them out of state
+			{
+				Config:   testAccModelResourceConfigWriteOnlyParameters("", `{"project_key": "secret-key"}`, 1),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccModelResource_WriteOnlyParametersConflict(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccModelResourceConfigWriteOnlyParameters(`{"project_key": "public"}`, `{"project_key": "secret-key"}`, 1),
+				ExpectError: regexp.MustCompile("set in both parameters and parameters_wo"),
+			},
+		},
+	})
+}
+
 func testAccModelResourceConfigWithParameters(identifier, path, parameters string) string {
 	timestamp := time.Now().UnixNano()
 	config := acceptance.ProviderConfig + fmt.Sprintf(`
@@ -655,3 +785,42 @@ resource "tama_model" "test" {
 
 	return config
 }
+
+func testAccModelResourceConfigWriteOnlyParameters(parameters, writeOnly string, version int) string {
+	timestamp := time.Now().UnixNano()
+	config := acceptance.ProviderConfig + fmt.Sprintf(`
+resource "tama_space" "test_space" {
+  name = "test-space-for-model-wo-%d"
+  type = "root"
+}
+
+resource "tama_source" "test_source" {
+  space_id = tama_space.test_space.id
+  name     = "test-source-for-model-wo"
+  type     = "model"
+  endpoint = "https://api.example.com"
+  api_key  = "test-api-key"
+}
+
+resource "tama_model" "test" {
+  source_id  = tama_source.test_source.id
+  identifier = "gpt-4o"
+  path       = "/chat/completions"`, timestamp)
+
+	if parameters != "" {
+		config += fmt.Sprintf(`
+  parameters = %[1]q`, parameters)
+	}
+
+	config += fmt.Sprintf(`
+  parameters_wo         = %[1]q
+  parameters_wo_version = %[2]d
+}
+
+data "tama_model" "test" {
+  id = tama_model.test.id
+}
+`, writeOnly, version)
+
+	return config
+}
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func newTestModel() ResourceModel {
+	return ResourceModel{
+		Id:                  types.StringValue("model-1"),
+		SourceId:            types.StringValue("source-1"),
+		Identifier:          types.StringValue("mistral-small-latest"),
+		Path:                types.StringValue("/chat/completions"),
+		Parameters:          types.StringValue(""),
+		ParametersWO:        types.StringNull(),
+		ParametersWOVersion: types.Int64Null(),
+		EffectiveURL:        types.StringValue("https://api.example.com/chat/completions"),
+		ProvisionState:      types.StringValue("active"),
+		RawResponseJSON:     types.StringNull(),
+	}
+}
+
+func TestResourceModifyPlan_EffectiveURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		change  func(*ResourceModel)
+		unknown bool
+	}{
+		{name: "unchanged", change: func(m *ResourceModel) {}},
+		{name: "identifier changed", change: func(m *ResourceModel) { m.Identifier = types.StringValue("mistral-large-latest") }},
+		{name: "path changed", change: func(m *ResourceModel) { m.Path = types.StringValue("/v1/chat/completions") }, unknown: true},
+		{name: "source changed", change: func(m *ResourceModel) { m.SourceId = types.StringUnknown() }, unknown: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+
+			// No client: planning must not call the API
+			r := &Resource{}
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			tfType := schemaResp.Schema.Type().TerraformType(ctx)
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(tfType, nil)}
+			if diags := state.Set(ctx, newTestModel()); diags.HasError() {
+				t.Fatalf("unable to build state: %v", diags)
+			}
+
+			// The framework plans computed attributes unknown on update
+			data := newTestModel()
+			tt.change(&data)
+			data.EffectiveURL = types.StringUnknown()
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(tfType, nil)}
+			if diags := plan.Set(ctx, data); diags.HasError() {
+				t.Fatalf("unable to build plan: %v", diags)
+			}
+
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var result ResourceModel
+			resp.Diagnostics.Append(resp.Plan.Get(ctx, &result)...)
+
+			if result.EffectiveURL.IsUnknown() != tt.unknown {
+				t.Errorf("expected effective_url unknown to be %t, got %s", tt.unknown, result.EffectiveURL)
+			}
+			if !tt.unknown && result.EffectiveURL.ValueString() != "https://api.example.com/chat/completions" {
+				t.Errorf("expected effective_url kept from state, got %s", result.EffectiveURL)
+			}
+		})
+	}
+}
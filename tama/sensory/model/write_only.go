@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/upmaru/tama-go/sensory"
+)
+
+// writeOnlyKeysKey is the private state key holding the names of the
+// parameters set through parameters_wo, so their values can be left out of
+// state when the model is read back.
+const writeOnlyKeysKey = "parameters_wo_keys"
+
+// privateState is the private state of a resource, as found on the requests
+// and responses of each operation.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// writeOnlyParameters returns the parameters configured in parameters_wo,
+// which are only available from the configuration.
+func writeOnlyParameters(ctx context.Context, config tfsdk.Config) (map[string]any, diag.Diagnostics) {
+	var value types.String
+	diags := config.GetAttribute(ctx, path.Root("parameters_wo"), &value)
+	if diags.HasError() || value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return nil, diags
+	}
+
+	var parameters map[string]any
+	if err := json.Unmarshal([]byte(value.ValueString()), &parameters); err != nil {
+		diags.AddAttributeError(path.Root("parameters_wo"), "Invalid Parameters", fmt.Sprintf("Unable to parse write-only parameters JSON: %s", err))
+		return nil, diags
+	}
+
+	return parameters, diags
+}
+
+// mergeParameters returns parameters with the write-only parameters added. A
+// key set in both is rejected, as it is unclear which value is meant.
+func mergeParameters(parameters, writeOnly map[string]any) (map[string]any, error) {
+	if len(writeOnly) == 0 {
+		return parameters, nil
+	}
+
+	merged := maps.Clone(parameters)
+	if merged == nil {
+		merged = map[string]any{}
+	}
+
+	for _, key := range sortedKeys(writeOnly) {
+		if _, ok := merged[key]; ok {
+			return nil, fmt.Errorf("parameter %q is set in both parameters and parameters_wo", key)
+		}
+		merged[key] = writeOnly[key]
+	}
+
+	return merged, nil
+}
+
+// withoutKeys returns a copy of parameters without keys.
+func withoutKeys(parameters map[string]any, keys []string) map[string]any {
+	if len(keys) == 0 || parameters == nil {
+		return parameters
+	}
+
+	filtered := maps.Clone(parameters)
+	for _, key := range keys {
+		delete(filtered, key)
+	}
+
+	return filtered
+}
+
+// redactModel returns a copy of model without the write-only parameters, so
+// their values reach neither the state nor the raw response.
+func redactModel(model *sensory.Model, keys []string) *sensory.Model {
+	redacted := *model
+	redacted.Parameters = withoutKeys(model.Parameters, keys)
+
+	return &redacted
+}
+
+// storedWriteOnlyKeys returns the write-only parameter names recorded in the
+// private state.
+func storedWriteOnlyKeys(ctx context.Context, private privateState) ([]string, diag.Diagnostics) {
+	raw, diags := private.GetKey(ctx, writeOnlyKeysKey)
+	if diags.HasError() || len(raw) == 0 {
+		return nil, diags
+	}
+
+	var keys []string
+	if err := json.Unmarshal(raw, &keys); err != nil {
+		diags.AddError("Private State Error", fmt.Sprintf("Unable to parse write-only parameter names: %s", err))
+		return nil, diags
+	}
+
+	return keys, diags
+}
+
+// encodeWriteOnlyKeys returns the private state value recording keys.
+func encodeWriteOnlyKeys(keys []string) []byte {
+	if keys == nil {
+		keys = []string{}
+	}
+
+	raw, _ := json.Marshal(keys)
+	return raw
+}
+
+// sortedKeys returns the keys of parameters in order.
+func sortedKeys(parameters map[string]any) []string {
+	keys := make([]string, 0, len(parameters))
+	for key := range parameters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
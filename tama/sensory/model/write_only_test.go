@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/upmaru/tama-go/sensory"
+)
+
+func TestMergeParameters(t *testing.T) {
+	parameters := map[string]any{"temperature": 0.8}
+	writeOnly := map[string]any{"project_key": "secret", "organization": "org-1"}
+
+	merged, err := mergeParameters(parameters, writeOnly)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := map[string]any{"temperature": 0.8, "project_key": "secret", "organization": "org-1"}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("expected %v, got %v", want, merged)
+	}
+
+	if len(parameters) != 1 {
+		t.Errorf("expected parameters to be left untouched, got %v", parameters)
+	}
+
+	if merged, err := mergeParameters(nil, writeOnly); err != nil || !reflect.DeepEqual(merged, writeOnly) {
+		t.Errorf("expected only the write-only parameters, got %v (%v)", merged, err)
+	}
+
+	if merged, err := mergeParameters(parameters, nil); err != nil || !reflect.DeepEqual(merged, parameters) {
+		t.Errorf("expected only the parameters, got %v (%v)", merged, err)
+	}
+
+	if _, err := mergeParameters(parameters, map[string]any{"temperature": 0.2}); err == nil {
+		t.Error("expected an error for a key set in both")
+	}
+}
+
+func TestRedactModel(t *testing.T) {
+	model := &sensory.Model{
+		ID:         "model-1",
+		Identifier: "gpt-4o",
+		Parameters: map[string]any{"temperature": 0.8, "project_key": "secret"},
+	}
+
+	redacted := redactModel(model, []string{"project_key"})
+
+	if want := map[string]any{"temperature": 0.8}; !reflect.DeepEqual(redacted.Parameters, want) {
+		t.Errorf("expected %v, got %v", want, redacted.Parameters)
+	}
+	if redacted.ID != model.ID || redacted.Identifier != model.Identifier {
+		t.Errorf("expected the other fields to be kept, got %+v", redacted)
+	}
+	if _, ok := model.Parameters["project_key"]; !ok {
+		t.Error("expected the original model to be left untouched")
+	}
+}
+
+func TestEncodeWriteOnlyKeys(t *testing.T) {
+	if got := string(encodeWriteOnlyKeys(nil)); got != "[]" {
+		t.Errorf("expected an empty list, got %s", got)
+	}
+	if got := string(encodeWriteOnlyKeys([]string{"organization", "project_key"})); got != `["organization","project_key"]` {
+		t.Errorf("unexpected encoding %s", got)
+	}
+}
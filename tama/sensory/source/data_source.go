@@ -13,6 +13,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/sensory"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
+	"github.com/upmaru/terraform-provider-tama/internal/wait"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -29,14 +32,15 @@ type DataSource struct {
 
 // DataSourceModel describes the data source data model.
 type DataSourceModel struct {
-	Id              types.String `tfsdk:"id"`
-	SpecificationId types.String `tfsdk:"specification_id"`
-	Slug            types.String `tfsdk:"slug"`
-	Name            types.String `tfsdk:"name"`
-	Type            types.String `tfsdk:"type"`
-	Endpoint        types.String `tfsdk:"endpoint"`
-	SpaceId         types.String `tfsdk:"space_id"`
-	ProvisionState  types.String `tfsdk:"provision_state"`
+	Id              types.String             `tfsdk:"id"`
+	SpecificationId types.String             `tfsdk:"specification_id"`
+	Slug            types.String             `tfsdk:"slug"`
+	Name            types.String             `tfsdk:"name"`
+	Type            types.String             `tfsdk:"type"`
+	Endpoint        types.String             `tfsdk:"endpoint"`
+	SpaceId         types.String             `tfsdk:"space_id"`
+	ProvisionState  types.String             `tfsdk:"provision_state"`
+	WaitFor         []wait.DataSourceWaitFor `tfsdk:"wait_for"`
 }
 
 func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -83,6 +87,7 @@ func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, r
 				Computed:            true,
 			},
 		},
+		Blocks: wait.WaitForDataSourceBlockSchema(sensory.Source{}),
 	}
 }
 
@@ -92,18 +97,18 @@ func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerMeta.Client
 }
 
 func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -161,10 +166,27 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 	}
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read source, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read source, got error: %s", err)))
 		return
 	}
 
+	// Handle wait_for conditions and read the source again once they are met
+	if len(data.WaitFor) > 0 {
+		getSourceFunc := func(id string) (any, error) {
+			return d.client.Sensory.GetSource(id)
+		}
+		if err := wait.ForDataSource(ctx, getSourceFunc, sourceResponse.ID, data.WaitFor); err != nil {
+			resp.Diagnostics.AddError("Wait Condition Failed", fmt.Sprintf("Unable to satisfy wait conditions: %s", err))
+			return
+		}
+
+		sourceResponse, err = d.client.Sensory.GetSource(sourceResponse.ID)
+		if err != nil {
+			resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read source, got error: %s", err)))
+			return
+		}
+	}
+
 	// Map response to data source schema
 	data.Id = types.StringValue(sourceResponse.ID)
 	data.Name = types.StringValue(sourceResponse.Name)
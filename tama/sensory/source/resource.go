@@ -7,14 +7,22 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/sensory"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/debug"
+	"github.com/upmaru/terraform-provider-tama/internal/endpoint"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
+	"github.com/upmaru/terraform-provider-tama/internal/protection"
+	"github.com/upmaru/terraform-provider-tama/internal/provision"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -27,20 +35,23 @@ func NewResource() resource.Resource {
 
 // Resource defines the resource implementation.
 type Resource struct {
-	client *tama.Client
+	client    *tama.Client
+	responses *client.Responses
 }
 
 // ResourceModel describes the resource data model.
 type ResourceModel struct {
-	Id             types.String  `tfsdk:"id"`
-	SpaceId        types.String  `tfsdk:"space_id"`
-	Name           types.String  `tfsdk:"name"`
-	Slug           types.String  `tfsdk:"slug"`
-	Type           types.String  `tfsdk:"type"`
-	Endpoint       types.String  `tfsdk:"endpoint"`
-	ApiKey         types.String  `tfsdk:"api_key"`
-	ProvisionState types.String  `tfsdk:"provision_state"`
-	Request        *RequestModel `tfsdk:"request"`
+	Id                 types.String  `tfsdk:"id"`
+	SpaceId            types.String  `tfsdk:"space_id"`
+	Name               types.String  `tfsdk:"name"`
+	Slug               types.String  `tfsdk:"slug"`
+	Type               types.String  `tfsdk:"type"`
+	Endpoint           types.String  `tfsdk:"endpoint"`
+	ApiKey             types.String  `tfsdk:"api_key"`
+	ProvisionState     types.String  `tfsdk:"provision_state"`
+	Request            *RequestModel `tfsdk:"request"`
+	DeletionProtection types.Bool    `tfsdk:"deletion_protection"`
+	RawResponseJSON    types.String  `tfsdk:"raw_response_json"`
 }
 
 // RequestModel describes the request configuration.
@@ -98,8 +109,12 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				Required:            true,
 			},
 			"endpoint": schema.StringAttribute{
-				MarkdownDescription: "API endpoint URL for the source",
+				MarkdownDescription: "API endpoint URL for the source. Must be an absolute http or https URL. May reference engine variables such as `${TAMA_REGION}`, which are resolved by the server and stored literally in state. Escape them as `$${TAMA_REGION}` in Terraform configuration.",
 				Required:            true,
+				Validators: []validator.String{
+					endpoint.URL(),
+					endpoint.Template(),
+				},
 			},
 			"api_key": schema.StringAttribute{
 				MarkdownDescription: "API key for authenticating with the source",
@@ -110,6 +125,8 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				MarkdownDescription: "Current state of the source ('active' or 'inactive')",
 				Computed:            true,
 			},
+			"deletion_protection": protection.Attribute(),
+			"raw_response_json":   debug.RawResponseAttribute(),
 			"request": schema.SingleNestedAttribute{
 				MarkdownDescription: "Request configuration for the source",
 				Optional:            true,
@@ -135,16 +152,25 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 						Optional:            true,
 						Attributes: map[string]schema.Attribute{
 							"location": schema.StringAttribute{
-								MarkdownDescription: "Location of the session affinity value (header or body)",
+								MarkdownDescription: "Location of the session affinity value. Valid values are `header` and `body`",
 								Required:            true,
+								Validators: []validator.String{
+									stringvalidator.OneOf("header", "body"),
+								},
 							},
 							"key": schema.StringAttribute{
 								MarkdownDescription: "Key for the session affinity",
 								Required:            true,
+								Validators: []validator.String{
+									stringvalidator.LengthAtLeast(1),
+								},
 							},
 							"value": schema.StringAttribute{
-								MarkdownDescription: "Value for the session affinity (e.g., 'actor_id')",
+								MarkdownDescription: "Value for the session affinity. Valid values are `actor_id`",
 								Required:            true,
+								Validators: []validator.String{
+									stringvalidator.OneOf("actor_id"),
+								},
 							},
 						},
 					},
@@ -160,18 +186,19 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerMeta.Client
+	r.responses = providerMeta.Responses
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -197,31 +224,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	}
 
 	// Add request configuration if provided
-	if data.Request != nil {
-		requestData := &sensory.Request{}
-
-		// Add headers if provided
-		if len(data.Request.Headers) > 0 {
-			requestData.Headers = make([]sensory.Header, len(data.Request.Headers))
-			for i, h := range data.Request.Headers {
-				requestData.Headers[i] = sensory.Header{
-					Name:  h.Name.ValueString(),
-					Value: h.Value.ValueString(),
-				}
-			}
-		}
-
-		// Add session affinity if provided
-		if data.Request.SessionAffinity != nil {
-			requestData.SessionAffinity = &sensory.SessionAffinity{
-				Location: data.Request.SessionAffinity.Location.ValueString(),
-				Key:      data.Request.SessionAffinity.Key.ValueString(),
-				Value:    data.Request.SessionAffinity.Value.ValueString(),
-			}
-		}
-
-		createRequest.Source.Request = requestData
-	}
+	createRequest.Source.Request = expandRequest(data.Request)
 
 	tflog.Debug(ctx, "Creating source", map[string]any{
 		"space_id": data.SpaceId.ValueString(),
@@ -232,7 +235,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	sourceResponse, err := r.client.Sensory.CreateSource(data.SpaceId.ValueString(), createRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create source, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to create source, got error: %s", err)))
 		return
 	}
 
@@ -248,32 +251,15 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	// Populate request data from response if available
 	if sourceResponse.Request != nil {
-		data.Request = &RequestModel{}
-
-		// Populate headers
-		if len(sourceResponse.Request.Headers) > 0 {
-			data.Request.Headers = make([]HeaderModel, len(sourceResponse.Request.Headers))
-			for i, h := range sourceResponse.Request.Headers {
-				data.Request.Headers[i] = HeaderModel{
-					Name:  types.StringValue(h.Name),
-					Value: types.StringValue(h.Value),
-				}
-			}
-		}
-
-		// Populate session affinity
-		if sourceResponse.Request.SessionAffinity != nil {
-			data.Request.SessionAffinity = &SessionAffinityModel{
-				Location: types.StringValue(sourceResponse.Request.SessionAffinity.Location),
-				Key:      types.StringValue(sourceResponse.Request.SessionAffinity.Key),
-				Value:    types.StringValue(sourceResponse.Request.SessionAffinity.Value),
-			}
-		}
+		data.Request = flattenRequest(sourceResponse.Request)
 	}
 
 	// Write logs using the tflog package
 	tflog.Trace(ctx, "created a source resource")
 
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, sourceResponse.ID)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -291,7 +277,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	// Get source from API
 	sourceResponse, err := r.client.Sensory.GetSource(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read source, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read source, got error: %s", err)))
 		return
 	}
 
@@ -304,39 +290,22 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	data.Endpoint = types.StringValue(sourceResponse.Endpoint)
 	// Note: API key is not returned in response, keep the original value
 
-	// Populate request data from response if available
-	if sourceResponse.Request != nil {
-		data.Request = &RequestModel{}
-
-		// Populate headers
-		if len(sourceResponse.Request.Headers) > 0 {
-			data.Request.Headers = make([]HeaderModel, len(sourceResponse.Request.Headers))
-			for i, h := range sourceResponse.Request.Headers {
-				data.Request.Headers[i] = HeaderModel{
-					Name:  types.StringValue(h.Name),
-					Value: types.StringValue(h.Value),
-				}
-			}
-		}
+	// Populate request data from response
+	data.Request = flattenRequest(sourceResponse.Request)
 
-		// Populate session affinity
-		if sourceResponse.Request.SessionAffinity != nil {
-			data.Request.SessionAffinity = &SessionAffinityModel{
-				Location: types.StringValue(sourceResponse.Request.SessionAffinity.Location),
-				Key:      types.StringValue(sourceResponse.Request.SessionAffinity.Key),
-				Value:    types.StringValue(sourceResponse.Request.SessionAffinity.Value),
-			}
-		}
-	} else {
-		// Clear request data if not present in response
-		data.Request = nil
-	}
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, sourceResponse.ID)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(provision.CheckUpdatable(ctx, req.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ResourceModel
 
 	// Read Terraform plan data into the model
@@ -358,32 +327,10 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		},
 	}
 
-	// Add request configuration if provided
-	if data.Request != nil {
-		requestData := &sensory.Request{}
-
-		// Add headers if provided
-		if len(data.Request.Headers) > 0 {
-			requestData.Headers = make([]sensory.Header, len(data.Request.Headers))
-			for i, h := range data.Request.Headers {
-				requestData.Headers[i] = sensory.Header{
-					Name:  h.Name.ValueString(),
-					Value: h.Value.ValueString(),
-				}
-			}
-		}
-
-		// Add session affinity if provided
-		if data.Request.SessionAffinity != nil {
-			requestData.SessionAffinity = &sensory.SessionAffinity{
-				Location: data.Request.SessionAffinity.Location.ValueString(),
-				Key:      data.Request.SessionAffinity.Key.ValueString(),
-				Value:    data.Request.SessionAffinity.Value.ValueString(),
-			}
-		}
-
-		updateRequest.Source.Request = requestData
-	}
+	// The source is updated with PATCH semantics, so always send the complete
+	// desired request configuration from the plan. Sending only the changed
+	// fields can cause the API to clear headers or session affinity.
+	updateRequest.Source.Request = expandRequest(data.Request)
 
 	tflog.Debug(ctx, "Updating source", map[string]any{
 		"id":       data.Id.ValueString(),
@@ -394,7 +341,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	sourceResponse, err := r.client.Sensory.UpdateSource(data.Id.ValueString(), updateRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update source, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to update source, got error: %s", err)))
 		return
 	}
 
@@ -407,33 +354,11 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	data.Endpoint = types.StringValue(sourceResponse.Endpoint)
 	// Note: API key is not returned in response, keep the original value
 
-	// Populate request data from response if available
-	if sourceResponse.Request != nil {
-		data.Request = &RequestModel{}
-
-		// Populate headers
-		if len(sourceResponse.Request.Headers) > 0 {
-			data.Request.Headers = make([]HeaderModel, len(sourceResponse.Request.Headers))
-			for i, h := range sourceResponse.Request.Headers {
-				data.Request.Headers[i] = HeaderModel{
-					Name:  types.StringValue(h.Name),
-					Value: types.StringValue(h.Value),
-				}
-			}
-		}
+	// Populate request data from response
+	data.Request = flattenRequest(sourceResponse.Request)
 
-		// Populate session affinity
-		if sourceResponse.Request.SessionAffinity != nil {
-			data.Request.SessionAffinity = &SessionAffinityModel{
-				Location: types.StringValue(sourceResponse.Request.SessionAffinity.Location),
-				Key:      types.StringValue(sourceResponse.Request.SessionAffinity.Key),
-				Value:    types.StringValue(sourceResponse.Request.SessionAffinity.Value),
-			}
-		}
-	} else {
-		// Clear request data if not present in response
-		data.Request = nil
-	}
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, sourceResponse.ID)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -449,6 +374,12 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
+	// Refuse to delete while deletion protection is enabled
+	resp.Diagnostics.Append(protection.CheckDelete(data.DeletionProtection, "tama_source", data.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete source using the Tama client
 	tflog.Debug(ctx, "Deleting source", map[string]any{
 		"id": data.Id.ValueString(),
@@ -456,7 +387,7 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 
 	err := r.client.Sensory.DeleteSource(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete source, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to delete source, got error: %s", err)))
 		return
 	}
 }
@@ -465,7 +396,7 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 	// Get source from API to populate state
 	sourceResponse, err := r.client.Sensory.GetSource(req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import source, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to import source, got error: %s", err)))
 		return
 	}
 
@@ -480,9 +411,76 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 		Endpoint:       types.StringValue(sourceResponse.Endpoint),
 		// ApiKey cannot be retrieved from API response
 		// This will need to be manually set after import
-		ApiKey: types.StringValue(""),
+		ApiKey:             types.StringValue(""),
+		Request:            flattenRequest(sourceResponse.Request),
+		DeletionProtection: types.BoolValue(false),
 	}
 
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, sourceResponse.ID)
+
 	// Save imported data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// expandRequest converts the request model into the API request configuration.
+func expandRequest(request *RequestModel) *sensory.Request {
+	if request == nil {
+		return nil
+	}
+
+	requestData := &sensory.Request{}
+
+	// Add headers if provided
+	if len(request.Headers) > 0 {
+		requestData.Headers = make([]sensory.Header, len(request.Headers))
+		for i, h := range request.Headers {
+			requestData.Headers[i] = sensory.Header{
+				Name:  h.Name.ValueString(),
+				Value: h.Value.ValueString(),
+			}
+		}
+	}
+
+	// Add session affinity if provided
+	if request.SessionAffinity != nil {
+		requestData.SessionAffinity = &sensory.SessionAffinity{
+			Location: request.SessionAffinity.Location.ValueString(),
+			Key:      request.SessionAffinity.Key.ValueString(),
+			Value:    request.SessionAffinity.Value.ValueString(),
+		}
+	}
+
+	return requestData
+}
+
+// flattenRequest converts the API request configuration into the request model.
+func flattenRequest(request *sensory.Request) *RequestModel {
+	if request == nil {
+		return nil
+	}
+
+	data := &RequestModel{}
+
+	// Populate headers
+	if len(request.Headers) > 0 {
+		data.Headers = make([]HeaderModel, len(request.Headers))
+		for i, h := range request.Headers {
+			data.Headers[i] = HeaderModel{
+				Name:  types.StringValue(h.Name),
+				Value: types.StringValue(h.Value),
+			}
+		}
+	}
+
+	// Populate session affinity
+	if request.SessionAffinity != nil {
+		data.SessionAffinity = &SessionAffinityModel{
+			Location: types.StringValue(request.SessionAffinity.Location),
+			Key:      types.StringValue(request.SessionAffinity.Key),
+			Value:    types.StringValue(request.SessionAffinity.Value),
+		}
+	}
+
+	return data
+}
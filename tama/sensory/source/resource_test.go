@@ -63,7 +63,42 @@ func TestAccSourceResource_InvalidEndpoint(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config:      testAccSourceResourceConfig("test-source", "model", "invalid-url", "test-api-key"),
-				ExpectError: regexp.MustCompile("Unable to create source"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid URL"),
+			},
+		},
+	})
+}
+
+func TestAccSourceResource_TemplatedEndpoint(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSourceResourceConfig("test-source", "model", "https://$${TAMA_REGION}.api.example.com/v1", "test-api-key"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tama_source.test", "endpoint", "https://${TAMA_REGION}.api.example.com/v1"),
+					resource.TestCheckResourceAttrSet("tama_source.test", "id"),
+				),
+			},
+			// Re-applying the same configuration must not produce a diff
+			{
+				Config:   testAccSourceResourceConfig("test-source", "model", "https://$${TAMA_REGION}.api.example.com/v1", "test-api-key"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccSourceResource_MalformedEndpointTemplate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSourceResourceConfig("test-source", "model", "https://$${TAMA-REGION}.api.example.com/v1", "test-api-key"),
+				ExpectError: regexp.MustCompile("Invalid Endpoint Template"),
 			},
 		},
 	})
@@ -539,3 +574,91 @@ func TestAccSourceResource_WithFullRequest(t *testing.T) {
 		},
 	})
 }
+
+func TestAccSourceResource_EndpointUpdatePreservesRequest(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with headers and session affinity
+			{
+				Config: testAccSourceResourceConfigWithFullRequest("test-source-endpoint-update", "model", "https://api.example.com", "test-api-key"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tama_source.test", "endpoint", "https://api.example.com"),
+					resource.TestCheckResourceAttr("tama_source.test", "request.headers.#", "2"),
+					resource.TestCheckResourceAttr("tama_source.test", "request.session_affinity.location", "header"),
+				),
+			},
+			// Update only the endpoint, request configuration must survive
+			{
+				Config: testAccSourceResourceConfigWithFullRequest("test-source-endpoint-update", "model", "https://api.updated-example.com", "test-api-key"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tama_source.test", "endpoint", "https://api.updated-example.com"),
+					resource.TestCheckResourceAttr("tama_source.test", "request.headers.#", "2"),
+					resource.TestCheckResourceAttr("tama_source.test", "request.headers.0.name", "x-http"),
+					resource.TestCheckResourceAttr("tama_source.test", "request.headers.0.value", "something"),
+					resource.TestCheckResourceAttr("tama_source.test", "request.headers.1.name", "authorization"),
+					resource.TestCheckResourceAttr("tama_source.test", "request.headers.1.value", "Bearer token"),
+					resource.TestCheckResourceAttr("tama_source.test", "request.session_affinity.location", "header"),
+					resource.TestCheckResourceAttr("tama_source.test", "request.session_affinity.key", "x-session-affinity"),
+					resource.TestCheckResourceAttr("tama_source.test", "request.session_affinity.value", "actor_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSourceResource_InvalidSessionAffinity(t *testing.T) {
+	testCases := []struct {
+		name        string
+		location    string
+		key         string
+		value       string
+		expectError string
+	}{
+		{"invalid location", "headers", "x-session-affinity", "actor_id", `Attribute request.session_affinity.location value must be one of`},
+		{"empty key", "header", "", "actor_id", `Attribute request.session_affinity.key string length must be at least 1`},
+		{"invalid value", "body", "session_id", "user_id", `Attribute request.session_affinity.value value must be one of`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+				ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccSourceResourceConfigWithCustomSessionAffinity(tc.location, tc.key, tc.value),
+						ExpectError: regexp.MustCompile(tc.expectError),
+					},
+				},
+			})
+		})
+	}
+}
+
+func testAccSourceResourceConfigWithCustomSessionAffinity(location, key, value string) string {
+	timestamp := time.Now().UnixNano()
+	return acceptance.ProviderConfig + fmt.Sprintf(`
+resource "tama_space" "test_space" {
+  name = "test-space-for-source-%d"
+  type = "root"
+}`, timestamp) + fmt.Sprintf(`
+
+resource "tama_source" "test" {
+  space_id = tama_space.test_space.id
+  name     = "test-source-session-affinity"
+  type     = "model"
+  endpoint = "https://api.example.com"
+  api_key  = "test-api-key"
+
+  request = {
+    session_affinity = {
+      location = %[1]q
+      key      = %[2]q
+      value    = %[3]q
+    }
+  }
+}
+`, location, key, value)
+}
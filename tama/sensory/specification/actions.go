@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package specification
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/upmaru/tama-go/motor"
+	"github.com/upmaru/tama-go/sensory"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+)
+
+// completedState is the current_state of a specification once its actions
+// have been generated.
+const completedState = "completed"
+
+// ActionGetter looks up the action generated for an operation of a specification.
+type ActionGetter interface {
+	GetActionByPathAndMethod(specID, path, method string) (*motor.Action, error)
+}
+
+// ActionModel describes a callable action generated from the specification.
+type ActionModel struct {
+	Id          types.String `tfsdk:"id"`
+	OperationId types.String `tfsdk:"operation_id"`
+	Method      types.String `tfsdk:"method"`
+	Path        types.String `tfsdk:"path"`
+}
+
+// actionObjectType is the element type of the actions attribute.
+var actionObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":           types.StringType,
+		"operation_id": types.StringType,
+		"method":       types.StringType,
+		"path":         types.StringType,
+	},
+}
+
+// operationMethods are the OpenAPI path item keys which describe operations.
+var operationMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// operation is a single path and method pair declared in an OpenAPI schema.
+type operation struct {
+	path        string
+	method      string
+	operationID string
+}
+
+// schemaOperations returns the operations declared in an OpenAPI schema,
+// ordered by path and then by method.
+func schemaOperations(schema map[string]any) []operation {
+	paths, ok := schema["paths"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	pathNames := make([]string, 0, len(paths))
+	for name := range paths {
+		pathNames = append(pathNames, name)
+	}
+	sort.Strings(pathNames)
+
+	var operations []operation
+	for _, name := range pathNames {
+		item, ok := paths[name].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for _, method := range operationMethods {
+			definition, ok := item[method].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			operationID, _ := definition["operationId"].(string)
+			operations = append(operations, operation{
+				path:        name,
+				method:      method,
+				operationID: operationID,
+			})
+		}
+	}
+
+	return operations
+}
+
+// specificationActions resolves the action of every operation in the
+// specification schema, one request per operation. Operations without an
+// action are left out. The result is null when actions are not requested
+// or the specification has not finished provisioning yet.
+func specificationActions(ctx context.Context, actions ActionGetter, include types.Bool, spec *sensory.Specification) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !include.ValueBool() || spec.CurrentState != completedState {
+		return types.ListNull(actionObjectType), diags
+	}
+
+	models := []ActionModel{}
+	for _, op := range schemaOperations(spec.Schema) {
+		action, err := actions.GetActionByPathAndMethod(spec.ID, op.path, op.method)
+		if client.IsNotFound(err) {
+			tflog.Debug(ctx, "No action for specification operation", map[string]any{
+				"specification_id": spec.ID,
+				"method":           op.method,
+				"path":             op.path,
+			})
+			continue
+		}
+		if err != nil {
+			diags.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read action for %s %s, got error: %s", op.method, op.path, err)))
+			return types.ListNull(actionObjectType), diags
+		}
+
+		models = append(models, ActionModel{
+			Id:          types.StringValue(action.ID),
+			OperationId: types.StringValue(op.operationID),
+			Method:      types.StringValue(action.Method),
+			Path:        types.StringValue(action.Path),
+		})
+	}
+
+	list, listDiags := types.ListValueFrom(ctx, actionObjectType, models)
+	diags.Append(listDiags...)
+
+	return list, diags
+}
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package specification
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/upmaru/tama-go/motor"
+	"github.com/upmaru/tama-go/sensory"
+	"github.com/upmaru/terraform-provider-tama/internal/fake"
+)
+
+func testActionsSchema() map[string]any {
+	return map[string]any{
+		"openapi": "3.0.3",
+		"paths": map[string]any{
+			"/messages": map[string]any{
+				"parameters": []any{},
+				"post":       map[string]any{"operationId": "createMessage"},
+				"get":        map[string]any{"operationId": "listMessages"},
+			},
+			"/health": map[string]any{
+				"get": map[string]any{},
+			},
+		},
+	}
+}
+
+func TestSchemaOperations(t *testing.T) {
+	got := schemaOperations(testActionsSchema())
+	want := []operation{
+		{path: "/health", method: "get"},
+		{path: "/messages", method: "get", operationID: "listMessages"},
+		{path: "/messages", method: "post", operationID: "createMessage"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	if operations := schemaOperations(map[string]any{"openapi": "3.0.3"}); len(operations) != 0 {
+		t.Fatalf("expected no operations without paths, got %v", operations)
+	}
+}
+
+func TestSpecificationActions(t *testing.T) {
+	ctx := context.Background()
+	actions := fake.NewActions(
+		&motor.Action{ID: "action-1", SpecificationID: "spec-1", Path: "/health", Method: "GET"},
+		&motor.Action{ID: "action-2", SpecificationID: "spec-1", Path: "/messages", Method: "GET"},
+		&motor.Action{ID: "action-3", SpecificationID: "spec-1", Path: "/messages", Method: "POST"},
+	)
+	spec := &sensory.Specification{ID: "spec-1", CurrentState: completedState, Schema: testActionsSchema()}
+
+	t.Run("disabled", func(t *testing.T) {
+		list, diags := specificationActions(ctx, actions, types.BoolNull(), spec)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if !list.IsNull() {
+			t.Fatalf("expected null actions, got %v", list)
+		}
+	})
+
+	t.Run("not provisioned", func(t *testing.T) {
+		processing := *spec
+		processing.CurrentState = "processing"
+
+		list, diags := specificationActions(ctx, actions, types.BoolValue(true), &processing)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if !list.IsNull() {
+			t.Fatalf("expected null actions, got %v", list)
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		list, diags := specificationActions(ctx, actions, types.BoolValue(true), spec)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		var got []ActionModel
+		if diags := list.ElementsAs(ctx, &got, false); diags.HasError() {
+			t.Fatalf("unable to read actions: %v", diags)
+		}
+
+		want := []ActionModel{
+			{Id: types.StringValue("action-1"), OperationId: types.StringValue(""), Method: types.StringValue("GET"), Path: types.StringValue("/health")},
+			{Id: types.StringValue("action-2"), OperationId: types.StringValue("listMessages"), Method: types.StringValue("GET"), Path: types.StringValue("/messages")},
+			{Id: types.StringValue("action-3"), OperationId: types.StringValue("createMessage"), Method: types.StringValue("POST"), Path: types.StringValue("/messages")},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("missing action", func(t *testing.T) {
+		list, diags := specificationActions(ctx, fake.NewActions(actions.Actions[0]), types.BoolValue(true), spec)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if len(list.Elements()) != 1 {
+			t.Fatalf("expected operations without an action to be left out, got %v", list)
+		}
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		failing := fake.NewActions()
+		failing.Err = &motor.Error{StatusCode: 500}
+
+		_, diags := specificationActions(ctx, failing, types.BoolValue(true), spec)
+		if !diags.HasError() {
+			t.Fatal("expected an error when actions cannot be read")
+		}
+	})
+}
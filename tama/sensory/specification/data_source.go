@@ -13,6 +13,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -29,13 +31,14 @@ type DataSource struct {
 
 // DataSourceModel describes the data source data model.
 type DataSourceModel struct {
-	Id             types.String `tfsdk:"id"`
-	SpaceId        types.String `tfsdk:"space_id"`
-	Schema         types.String `tfsdk:"schema"`
-	Version        types.String `tfsdk:"version"`
-	Endpoint       types.String `tfsdk:"endpoint"`
-	CurrentState   types.String `tfsdk:"current_state"`
-	ProvisionState types.String `tfsdk:"provision_state"`
+	Id              types.String `tfsdk:"id"`
+	SpaceId         types.String `tfsdk:"space_id"`
+	Schema          types.String `tfsdk:"schema"`
+	Version         types.String `tfsdk:"version"`
+	Endpoint        types.String `tfsdk:"endpoint"`
+	CurrentState    types.String `tfsdk:"current_state"`
+	ProvisionState  types.String `tfsdk:"provision_state"`
+	ResolvedServers types.List   `tfsdk:"resolved_servers"`
 }
 
 func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -75,6 +78,11 @@ func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, r
 				MarkdownDescription: "Provision state of the specification",
 				Computed:            true,
 			},
+			"resolved_servers": schema.ListAttribute{
+				MarkdownDescription: "URLs of the `servers` section of the schema as stored by Tama, with server variables replaced by their defaults",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 		},
 	}
 }
@@ -85,18 +93,18 @@ func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerMeta.Client
 }
 
 func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -116,7 +124,7 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 
 	specResponse, err := d.client.Sensory.GetSpecification(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read specification, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read specification, got error: %s", err)))
 		return
 	}
 
@@ -127,6 +135,7 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 	data.Endpoint = types.StringValue(specResponse.Endpoint)
 	data.CurrentState = types.StringValue(specResponse.CurrentState)
 	data.ProvisionState = types.StringValue(specResponse.ProvisionState)
+	data.ResolvedServers = resolvedServers(specResponse.Schema)
 
 	// Handle schema from response
 	if len(specResponse.Schema) > 0 {
@@ -34,6 +34,8 @@ func TestAccSpecificationDataSource(t *testing.T) {
 					// Verify that states are not empty
 					resource.TestMatchResourceAttr("data.tama_specification.test", "current_state", regexp.MustCompile(".+")),
 					resource.TestMatchResourceAttr("data.tama_specification.test", "provision_state", regexp.MustCompile(".+")),
+					resource.TestCheckResourceAttr("data.tama_specification.test", "resolved_servers.#", "1"),
+					resource.TestCheckResourceAttr("data.tama_specification.test", "resolved_servers.0", "https://api.example.com"),
 				),
 			},
 		},
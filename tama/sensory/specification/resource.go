@@ -7,23 +7,34 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/sensory"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/debug"
+	"github.com/upmaru/terraform-provider-tama/internal/endpoint"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
 	internalplanmodifier "github.com/upmaru/terraform-provider-tama/internal/planmodifier"
+	"github.com/upmaru/terraform-provider-tama/internal/protection"
+	"github.com/upmaru/terraform-provider-tama/internal/provision"
+	"github.com/upmaru/terraform-provider-tama/internal/validators"
 	"github.com/upmaru/terraform-provider-tama/internal/wait"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
+var _ resource.ResourceWithModifyPlan = &Resource{}
 
 func NewResource() resource.Resource {
 	return &Resource{}
@@ -31,19 +42,30 @@ func NewResource() resource.Resource {
 
 // Resource defines the resource implementation.
 type Resource struct {
-	client *tama.Client
+	client        *tama.Client
+	actions       ActionGetter
+	requireSemver bool
+	responses     *client.Responses
+
+	disableNormalization bool
 }
 
 // ResourceModel describes the resource data model.
 type ResourceModel struct {
-	Id             types.String   `tfsdk:"id"`
-	SpaceId        types.String   `tfsdk:"space_id"`
-	Schema         types.String   `tfsdk:"schema"`
-	Version        types.String   `tfsdk:"version"`
-	Endpoint       types.String   `tfsdk:"endpoint"`
-	CurrentState   types.String   `tfsdk:"current_state"`
-	ProvisionState types.String   `tfsdk:"provision_state"`
-	WaitFor        []wait.WaitFor `tfsdk:"wait_for"`
+	Id                 types.String   `tfsdk:"id"`
+	SpaceId            types.String   `tfsdk:"space_id"`
+	Schema             types.String   `tfsdk:"schema"`
+	Version            types.String   `tfsdk:"version"`
+	Endpoint           types.String   `tfsdk:"endpoint"`
+	CurrentState       types.String   `tfsdk:"current_state"`
+	ProvisionState     types.String   `tfsdk:"provision_state"`
+	IncludeActions     types.Bool     `tfsdk:"include_actions"`
+	Actions            types.List     `tfsdk:"actions"`
+	ResolvedServers    types.List     `tfsdk:"resolved_servers"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+	WaitFor            []wait.WaitFor `tfsdk:"wait_for"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	RawResponseJSON    types.String   `tfsdk:"raw_response_json"`
 }
 
 func (r *Resource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -81,8 +103,11 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				Required:            true,
 			},
 			"endpoint": schema.StringAttribute{
-				MarkdownDescription: "API endpoint URL for the specification",
+				MarkdownDescription: "API endpoint URL for the specification. Must be an absolute http or https URL.",
 				Required:            true,
+				Validators: []validator.String{
+					endpoint.URL(),
+				},
 			},
 			"current_state": schema.StringAttribute{
 				MarkdownDescription: "Current state of the specification",
@@ -92,8 +117,54 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				MarkdownDescription: "Provision state of the specification",
 				Computed:            true,
 			},
+			"include_actions": schema.BoolAttribute{
+				MarkdownDescription: "Whether to populate `actions` with the actions generated from the schema. Disabled by default to keep state small for large specifications. When enabled, every refresh makes one API request per operation in the schema.",
+				Optional:            true,
+			},
+			"actions": schema.ListNestedAttribute{
+				MarkdownDescription: "Actions generated from the schema operations, populated once the specification has finished provisioning and `include_actions` is enabled",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Action identifier",
+							Computed:            true,
+						},
+						"operation_id": schema.StringAttribute{
+							MarkdownDescription: "OpenAPI operationId of the operation the action was generated from",
+							Computed:            true,
+						},
+						"method": schema.StringAttribute{
+							MarkdownDescription: "HTTP method of the action",
+							Computed:            true,
+						},
+						"path": schema.StringAttribute{
+							MarkdownDescription: "API endpoint path of the action",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"resolved_servers": schema.ListAttribute{
+				MarkdownDescription: "URLs of the `servers` section of the schema as stored by Tama, with server variables replaced by their defaults. Refreshed on read",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deletion_protection": protection.Attribute(),
+			"raw_response_json":   debug.RawResponseAttribute(),
 		},
-		Blocks: wait.WaitForBlockSchema(),
+		Blocks: func() map[string]schema.Block {
+			blocks := wait.WaitForBlockSchema(sensory.Specification{})
+			blocks["timeouts"] = timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			})
+			return blocks
+		}(),
 	}
 }
 
@@ -103,18 +174,69 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerMeta.Client
+	r.actions = providerMeta.Client.Motor
+	r.responses = providerMeta.Responses
+	r.requireSemver = providerMeta.RequireSemver
+	r.disableNormalization = providerMeta.DisableJSONNormalization
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Plan formatting changes when the provider disables normalization
+	if r.disableNormalization {
+		resp.Diagnostics.Append(internalplanmodifier.KeepConfiguredJSON(ctx, req.Config, &resp.Plan, path.MatchRoot("schema"))...)
+	}
+
+	// Nothing to plan when destroying
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// resolved_servers is derived from the schema, so a schema change
+	// resolves it again on apply
+	if !req.State.Raw.IsNull() {
+		var planSchema, stateSchema types.String
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("schema"), &planSchema)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("schema"), &stateSchema)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !planSchema.Equal(stateSchema) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("resolved_servers"), types.ListUnknown(types.StringType))...)
+		}
+	}
+
+	// Nothing to validate when strict versioning is off
+	if !r.requireSemver {
+		return
+	}
+
+	var version types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("version"), &version)...)
+
+	if resp.Diagnostics.HasError() || version.IsNull() || version.IsUnknown() {
+		return
+	}
+
+	if !validators.IsSemver(version.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("version"),
+			"Invalid Specification Version",
+			fmt.Sprintf("The provider has require_semver enabled, but %q is not a valid semantic version (MAJOR.MINOR.PATCH, e.g. 1.2.3).", version.ValueString()),
+		)
+	}
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -127,6 +249,15 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, wait.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Parse schema JSON
 	var schemaMap map[string]any
 	if err := json.Unmarshal([]byte(data.Schema.ValueString()), &schemaMap); err != nil {
@@ -152,7 +283,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 
 	specResponse, err := r.client.Sensory.CreateSpecification(data.SpaceId.ValueString(), createRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create specification, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to create specification, got error: %s", err)))
 		return
 	}
 
@@ -171,26 +302,44 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 			resp.Diagnostics.AddError("Schema Serialization Error", fmt.Sprintf("Unable to serialize schema: %s", err))
 			return
 		}
-		data.Schema = types.StringValue(string(schemaJSON))
+		data.Schema = internalplanmodifier.KeepEquivalent(data.Schema, string(schemaJSON), internalplanmodifier.NormalizeJSON)
 	}
 
-	// Handle wait_for conditions if specified
+	// Handle wait_for conditions if specified. The specification read once
+	// every condition holds replaces the create response, which may still
+	// carry a transient state while the engine provisions it.
+	// Without wait_for the mutation response is already the latest state.
 	if len(data.WaitFor) > 0 {
 		getSpecificationFunc := func(id string) (interface{}, error) {
 			return r.client.Sensory.GetSpecification(id)
 		}
-		for _, waitFor := range data.WaitFor {
-			err := wait.ForConditions(ctx, getSpecificationFunc, data.Id.ValueString(), waitFor.Field, 10*time.Minute)
-			if err != nil {
-				resp.Diagnostics.AddError("Wait Condition Failed", fmt.Sprintf("Unable to satisfy wait conditions: %s", err))
-				return
-			}
+		settled, err := wait.Until(ctx, getSpecificationFunc, data.Id.ValueString(), data.WaitFor, createTimeout)
+		if err != nil {
+			err = wait.PhaseTimeoutError(ctx, "create", createTimeout, err)
+			resp.Diagnostics.AddError("Wait Condition Failed", fmt.Sprintf("Unable to satisfy wait conditions: %s", err))
+			return
+		}
+		if spec, ok := settled.(*sensory.Specification); ok {
+			specResponse = spec
+			data.CurrentState = types.StringValue(specResponse.CurrentState)
+			data.ProvisionState = types.StringValue(specResponse.ProvisionState)
 		}
 	}
 
+	actions, diags := specificationActions(ctx, r.actions, data.IncludeActions, specResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Actions = actions
+	data.ResolvedServers = resolvedServers(specResponse.Schema)
+
 	// Write logs using the tflog package
 	tflog.Trace(ctx, "created a specification resource")
 
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, specResponse.ID)
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -208,7 +357,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	// Get specification from API
 	specResponse, err := r.client.Sensory.GetSpecification(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read specification, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read specification, got error: %s", err)))
 		return
 	}
 
@@ -226,14 +375,30 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 			resp.Diagnostics.AddError("Schema Serialization Error", fmt.Sprintf("Unable to serialize schema: %s", err))
 			return
 		}
-		data.Schema = types.StringValue(string(schemaJSON))
+		data.Schema = internalplanmodifier.KeepEquivalent(data.Schema, string(schemaJSON), internalplanmodifier.NormalizeJSON)
+	}
+
+	actions, diags := specificationActions(ctx, r.actions, data.IncludeActions, specResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	data.Actions = actions
+	data.ResolvedServers = resolvedServers(specResponse.Schema)
+
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, specResponse.ID)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(provision.CheckUpdatable(ctx, req.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ResourceModel
 
 	// Read Terraform plan data into the model
@@ -243,6 +408,15 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, wait.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Parse schema JSON
 	var schemaMap map[string]any
 	if err := json.Unmarshal([]byte(data.Schema.ValueString()), &schemaMap); err != nil {
@@ -268,7 +442,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 
 	specResponse, err := r.client.Sensory.UpdateSpecification(data.Id.ValueString(), updateRequest)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update specification, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to update specification, got error: %s", err)))
 		return
 	}
 
@@ -286,22 +460,40 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 			resp.Diagnostics.AddError("Schema Serialization Error", fmt.Sprintf("Unable to serialize schema: %s", err))
 			return
 		}
-		data.Schema = types.StringValue(string(schemaJSON))
+		data.Schema = internalplanmodifier.KeepEquivalent(data.Schema, string(schemaJSON), internalplanmodifier.NormalizeJSON)
 	}
 
-	// Handle wait_for conditions if specified
+	// Handle wait_for conditions if specified. The specification read once
+	// every condition holds replaces the update response, which may still
+	// carry a transient state while the engine provisions it.
+	// Without wait_for the mutation response is already the latest state.
 	if len(data.WaitFor) > 0 {
 		getSpecificationFunc := func(id string) (interface{}, error) {
 			return r.client.Sensory.GetSpecification(id)
 		}
-		for _, waitFor := range data.WaitFor {
-			err := wait.ForConditions(ctx, getSpecificationFunc, data.Id.ValueString(), waitFor.Field, 10*time.Minute)
-			if err != nil {
-				resp.Diagnostics.AddError("Wait Condition Failed", fmt.Sprintf("Unable to satisfy wait conditions: %s", err))
-				return
-			}
+		settled, err := wait.Until(ctx, getSpecificationFunc, data.Id.ValueString(), data.WaitFor, updateTimeout)
+		if err != nil {
+			err = wait.PhaseTimeoutError(ctx, "update", updateTimeout, err)
+			resp.Diagnostics.AddError("Wait Condition Failed", fmt.Sprintf("Unable to satisfy wait conditions: %s", err))
+			return
 		}
+		if spec, ok := settled.(*sensory.Specification); ok {
+			specResponse = spec
+			data.CurrentState = types.StringValue(specResponse.CurrentState)
+			data.ProvisionState = types.StringValue(specResponse.ProvisionState)
+		}
+	}
+
+	actions, diags := specificationActions(ctx, r.actions, data.IncludeActions, specResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	data.Actions = actions
+	data.ResolvedServers = resolvedServers(specResponse.Schema)
+
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, specResponse.ID)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -317,6 +509,21 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
+	// Refuse to delete while deletion protection is enabled
+	resp.Diagnostics.Append(protection.CheckDelete(data.DeletionProtection, "tama_specification", data.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, wait.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete specification using the Tama client
 	tflog.Debug(ctx, "Deleting specification", map[string]any{
 		"id": data.Id.ValueString(),
@@ -324,7 +531,8 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 
 	err := r.client.Sensory.DeleteSpecification(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete specification, got error: %s", err))
+		err = wait.PhaseTimeoutError(ctx, "delete", deleteTimeout, err)
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to delete specification, got error: %s", err)))
 		return
 	}
 }
@@ -333,7 +541,7 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 	// Get specification from API to populate state
 	specResponse, err := r.client.Sensory.GetSpecification(req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import specification, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to import specification, got error: %s", err)))
 		return
 	}
 
@@ -352,15 +560,22 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 
 	// Create model from API response
 	data := ResourceModel{
-		Id:             types.StringValue(specResponse.ID),
-		SpaceId:        types.StringValue(specResponse.SpaceID),
-		Schema:         schemaValue,
-		Version:        types.StringValue(specResponse.Version),
-		Endpoint:       types.StringValue(specResponse.Endpoint),
-		CurrentState:   types.StringValue(specResponse.CurrentState),
-		ProvisionState: types.StringValue(specResponse.ProvisionState),
+		Id:                 types.StringValue(specResponse.ID),
+		SpaceId:            types.StringValue(specResponse.SpaceID),
+		Schema:             schemaValue,
+		Version:            types.StringValue(specResponse.Version),
+		Endpoint:           types.StringValue(specResponse.Endpoint),
+		CurrentState:       types.StringValue(specResponse.CurrentState),
+		ProvisionState:     types.StringValue(specResponse.ProvisionState),
+		Actions:            types.ListNull(actionObjectType),
+		ResolvedServers:    resolvedServers(specResponse.Schema),
+		Timeouts:           wait.NullTimeouts(),
+		DeletionProtection: types.BoolValue(false),
 	}
 
+	// Store the raw API response when debugging is enabled
+	data.RawResponseJSON = debug.RawResponse(r.responses, specResponse.ID)
+
 	// Save imported data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
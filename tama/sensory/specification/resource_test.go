@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/upmaru/terraform-provider-tama/internal/acceptance"
 	"github.com/upmaru/terraform-provider-tama/tama/sensory/specification/testhelpers"
 )
@@ -30,8 +31,15 @@ func TestAccSpecificationResource(t *testing.T) {
 					resource.TestCheckResourceAttrSet("tama_specification.test", "schema"),
 					resource.TestCheckResourceAttrSet("tama_specification.test", "current_state"),
 					resource.TestCheckResourceAttrSet("tama_specification.test", "provision_state"),
+					resource.TestCheckResourceAttr("tama_specification.test", "resolved_servers.#", "1"),
+					resource.TestCheckResourceAttr("tama_specification.test", "resolved_servers.0", "https://api.example.com"),
 				),
 			},
+			// resolved_servers is only refreshed, it never plans a change
+			{
+				Config:   testAccSpecificationResourceConfig("3.1.0", "https://elasticsearch.arrakis.upmaru.network", testhelpers.MustMarshalJSON(testhelpers.TestSchema())),
+				PlanOnly: true,
+			},
 			// ImportState testing
 			{
 				ResourceName:      "tama_specification.test",
@@ -71,6 +79,20 @@ func TestAccSpecificationResource_EmptyVersion(t *testing.T) {
 	})
 }
 
+func TestAccSpecificationResource_InvalidEndpoint(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSpecificationResourceConfig("3.1.0", "invalid-url", testhelpers.MustMarshalJSON(testhelpers.TestSchema())),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid URL"),
+			},
+		},
+	})
+}
+
 func TestAccSpecificationResource_InvalidSchema(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
@@ -281,6 +303,38 @@ func TestAccSpecificationResource_WaitFor(t *testing.T) {
 	})
 }
 
+func TestAccSpecificationResource_UpdateWaitsForReprovision(t *testing.T) {
+	spaceName := fmt.Sprintf("test-space-for-spec-reprovision-%d", time.Now().UnixNano())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpecificationResourceConfigReprovision(spaceName, "1.0.0", testhelpers.MustMarshalJSON(testhelpers.TestSchema())),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tama_specification.test", "version", "1.0.0"),
+					resource.TestCheckResourceAttr("tama_specification.test", "current_state", "completed"),
+				),
+			},
+			// Updating the schema reprovisions the specification; state must
+			// record the settled state, not the transient one from the update.
+			{
+				Config: testAccSpecificationResourceConfigReprovision(spaceName, "2.0.0", testhelpers.MustMarshalJSON(testhelpers.TestSchemaUpdated())),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("tama_specification.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tama_specification.test", "version", "2.0.0"),
+					resource.TestCheckResourceAttr("tama_specification.test", "current_state", "completed"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccSpecificationResource_WaitForMultipleConditions(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
@@ -342,6 +396,56 @@ func TestAccSpecificationResource_WaitForMultipleValues(t *testing.T) {
 	})
 }
 
+func TestAccSpecificationResource_IncludeActions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Actions are not tracked by default
+			{
+				Config: testAccSpecificationResourceConfigWaitFor("1.0.0", "https://api.example.com", testhelpers.MustMarshalJSON(testhelpers.TestSchema())),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("tama_specification.test", "include_actions"),
+					resource.TestCheckNoResourceAttr("tama_specification.test", "actions"),
+				),
+			},
+			// Actions are populated once provisioning completes
+			{
+				Config: testAccSpecificationResourceConfigIncludeActions("1.0.0", "https://api.example.com", testhelpers.MustMarshalJSON(testhelpers.TestSchema())),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tama_specification.test", "include_actions", "true"),
+					resource.TestCheckResourceAttr("tama_specification.test", "current_state", "completed"),
+					resource.TestCheckResourceAttr("tama_specification.test", "actions.#", "1"),
+					resource.TestCheckResourceAttrSet("tama_specification.test", "actions.0.id"),
+					resource.TestCheckResourceAttr("tama_specification.test", "actions.0.operation_id", "createMessage"),
+					resource.TestCheckResourceAttr("tama_specification.test", "actions.0.path", "/messages"),
+					resource.TestCheckResourceAttrSet("tama_specification.test", "actions.0.method"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSpecificationResource_Timeouts(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSpecificationResourceConfigTimeouts("1s", "never"),
+				ExpectError: regexp.MustCompile(`create timeout of 1s exceeded`),
+			},
+			{
+				Config: testAccSpecificationResourceConfigTimeouts("15m", "completed"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tama_specification.test", "timeouts.create", "15m"),
+					resource.TestCheckResourceAttr("tama_specification.test", "current_state", "completed"),
+				),
+			},
+		},
+	})
+}
+
 func testAccSpecificationResourceConfigMultiple() string {
 	timestamp := time.Now().UnixNano()
 	return acceptance.ProviderConfig + fmt.Sprintf(`
@@ -390,6 +494,29 @@ resource "tama_specification" "test" {
 `, version, endpoint, schema)
 }
 
+func testAccSpecificationResourceConfigReprovision(spaceName, version, schema string) string {
+	return acceptance.ProviderConfig + fmt.Sprintf(`
+resource "tama_space" "test_space" {
+  name = %[1]q
+  type = "root"
+}
+
+resource "tama_specification" "test" {
+  space_id = tama_space.test_space.id
+  version  = %[2]q
+  endpoint = "https://api.example.com"
+  schema   = %[3]q
+
+  wait_for {
+    field {
+      name = "current_state"
+      in   = ["completed"]
+    }
+  }
+}
+`, spaceName, version, schema)
+}
+
 func testAccSpecificationResourceConfigWaitForMultiple(version, endpoint, schema string) string {
 	timestamp := time.Now().UnixNano()
 	return acceptance.ProviderConfig + fmt.Sprintf(`
@@ -442,3 +569,58 @@ resource "tama_specification" "test" {
 }
 `, version, endpoint, schema)
 }
+
+func testAccSpecificationResourceConfigIncludeActions(version, endpoint, schema string) string {
+	timestamp := time.Now().UnixNano()
+	return acceptance.ProviderConfig + fmt.Sprintf(`
+resource "tama_space" "test_space" {
+  name = "test-space-for-spec-wait-%d"
+  type = "root"
+}`, timestamp) + fmt.Sprintf(`
+
+resource "tama_specification" "test" {
+  space_id        = tama_space.test_space.id
+  version         = %[1]q
+  endpoint        = %[2]q
+  schema          = %[3]q
+  include_actions = true
+
+  wait_for {
+    field {
+      name = "current_state"
+      in   = ["completed"]
+    }
+  }
+}
+`, version, endpoint, schema)
+}
+
+func testAccSpecificationResourceConfigTimeouts(createTimeout, currentState string) string {
+	timestamp := time.Now().UnixNano()
+	return acceptance.ProviderConfig + fmt.Sprintf(`
+resource "tama_space" "test_space" {
+  name = "test-space-for-spec-timeouts-%d"
+  type = "root"
+}`, timestamp) + fmt.Sprintf(`
+
+resource "tama_specification" "test" {
+  space_id = tama_space.test_space.id
+  version  = "1.0.0"
+  endpoint = "https://api.example.com"
+  schema   = %[1]q
+
+  wait_for {
+    field {
+      name = "current_state"
+      in   = [%[2]q]
+    }
+  }
+
+  timeouts {
+    create = %[3]q
+    update = "10m"
+    delete = "5m"
+  }
+}
+`, testhelpers.MustMarshalJSON(testhelpers.TestSchema()), currentState, createTimeout)
+}
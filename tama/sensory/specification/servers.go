@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package specification
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// resolvedServers returns the URLs of the OpenAPI servers section of a
+// specification schema, as stored by the API. Server variables are replaced
+// by their default values. The list is empty when the schema declares no
+// servers.
+func resolvedServers(schema map[string]any) types.List {
+	servers, _ := schema["servers"].([]any)
+
+	values := make([]attr.Value, 0, len(servers))
+	for _, server := range servers {
+		object, ok := server.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		serverURL, ok := object["url"].(string)
+		if !ok || serverURL == "" {
+			continue
+		}
+
+		values = append(values, types.StringValue(substituteVariables(serverURL, object["variables"])))
+	}
+
+	return types.ListValueMust(types.StringType, values)
+}
+
+// substituteVariables replaces the {name} placeholders of a server URL with
+// the default of the matching server variable. Placeholders without a
+// default are kept.
+func substituteVariables(serverURL string, variables any) string {
+	definitions, ok := variables.(map[string]any)
+	if !ok {
+		return serverURL
+	}
+
+	for name, definition := range definitions {
+		variable, ok := definition.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if value, ok := variable["default"].(string); ok {
+			serverURL = strings.ReplaceAll(serverURL, "{"+name+"}", value)
+		}
+	}
+
+	return serverURL
+}
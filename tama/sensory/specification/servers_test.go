@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package specification
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResolvedServers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		schema   map[string]any
+		expected []string
+	}{
+		{
+			name:     "no servers",
+			schema:   map[string]any{"openapi": "3.0.3"},
+			expected: []string{},
+		},
+		{
+			name: "plain urls",
+			schema: map[string]any{"servers": []any{
+				map[string]any{"url": "https://api.example.com/v1"},
+				map[string]any{"url": "https://staging.example.com/v1", "description": "Staging"},
+			}},
+			expected: []string{"https://api.example.com/v1", "https://staging.example.com/v1"},
+		},
+		{
+			name: "variables",
+			schema: map[string]any{"servers": []any{
+				map[string]any{
+					"url": "https://{environment}.example.com/{version}/{tenant}",
+					"variables": map[string]any{
+						"environment": map[string]any{"default": "prod", "enum": []any{"prod", "staging"}},
+						"version":     map[string]any{"default": "v2"},
+						"tenant":      map[string]any{"description": "No default"},
+					},
+				},
+			}},
+			expected: []string{"https://prod.example.com/v2/{tenant}"},
+		},
+		{
+			name: "malformed entries are skipped",
+			schema: map[string]any{"servers": []any{
+				"https://ignored.example.com",
+				map[string]any{"description": "No url"},
+				map[string]any{"url": "/relative"},
+			}},
+			expected: []string{"/relative"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			values := make([]attr.Value, len(tt.expected))
+			for i, value := range tt.expected {
+				values[i] = types.StringValue(value)
+			}
+			expected := types.ListValueMust(types.StringType, values)
+
+			if got := resolvedServers(tt.schema); !got.Equal(expected) {
+				t.Errorf("expected %s, got %s", expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package specification
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/upmaru/terraform-provider-tama/internal/endpoint"
+)
+
+// schemaErrors runs local structural checks against a specification schema
+// and endpoint without contacting the engine, returning a description of each
+// problem found. An empty result means the schema and endpoint are valid.
+func schemaErrors(schema string, specEndpoint string) []string {
+	var problems []string
+
+	if err := endpoint.ValidateURL(specEndpoint); err != nil {
+		problems = append(problems, fmt.Sprintf("endpoint: %s", err))
+	}
+
+	var document any
+	if err := json.Unmarshal([]byte(schema), &document); err != nil {
+		return append(problems, fmt.Sprintf("schema: not valid JSON: %s", err))
+	}
+
+	root, ok := document.(map[string]any)
+	if !ok {
+		return append(problems, "schema: must be a JSON object")
+	}
+
+	switch version, ok := root["openapi"].(string); {
+	case !ok:
+		problems = append(problems, "openapi: must be set to an OpenAPI 3.x version string")
+	case !strings.HasPrefix(version, "3."):
+		problems = append(problems, fmt.Sprintf("openapi: unsupported version %q, expected 3.x", version))
+	}
+
+	if info, ok := root["info"].(map[string]any); ok {
+		for _, field := range []string{"title", "version"} {
+			if value, _ := info[field].(string); value == "" {
+				problems = append(problems, fmt.Sprintf("info.%s: must be a non-empty string", field))
+			}
+		}
+	} else {
+		problems = append(problems, "info: must be an object")
+	}
+
+	paths, ok := root["paths"].(map[string]any)
+	if !ok {
+		return append(problems, "paths: must be an object")
+	}
+
+	return append(problems, pathErrors(paths)...)
+}
+
+// pathErrors checks every path item and operation in an OpenAPI paths object,
+// ordered by path and then by method.
+func pathErrors(paths map[string]any) []string {
+	var problems []string
+
+	pathNames := make([]string, 0, len(paths))
+	for name := range paths {
+		pathNames = append(pathNames, name)
+	}
+	sort.Strings(pathNames)
+
+	operationIDs := map[string]string{}
+	for _, name := range pathNames {
+		if !strings.HasPrefix(name, "/") {
+			problems = append(problems, fmt.Sprintf("paths.%s: path must begin with /", name))
+		}
+
+		item, ok := paths[name].(map[string]any)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("paths.%s: must be an object", name))
+			continue
+		}
+
+		for _, method := range operationMethods {
+			value, exists := item[method]
+			if !exists {
+				continue
+			}
+
+			location := fmt.Sprintf("paths.%s.%s", name, method)
+			definition, ok := value.(map[string]any)
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s: must be an object", location))
+				continue
+			}
+
+			if responses, ok := definition["responses"].(map[string]any); !ok || len(responses) == 0 {
+				problems = append(problems, fmt.Sprintf("%s.responses: must declare at least one response", location))
+			}
+
+			operationID, _ := definition["operationId"].(string)
+			if operationID == "" {
+				continue
+			}
+
+			if previous, seen := operationIDs[operationID]; seen {
+				problems = append(problems, fmt.Sprintf("%s.operationId: %q is already used by %s", location, operationID, previous))
+				continue
+			}
+			operationIDs[operationID] = location
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package specification
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ValidateDataSource{}
+
+func NewValidateDataSource() datasource.DataSource {
+	return &ValidateDataSource{}
+}
+
+// ValidateDataSource checks a specification schema locally, so problems are
+// reported before a specification is created.
+type ValidateDataSource struct{}
+
+// ValidateDataSourceModel describes the validate data source data model.
+type ValidateDataSourceModel struct {
+	Schema   types.String `tfsdk:"schema"`
+	Endpoint types.String `tfsdk:"endpoint"`
+	Valid    types.Bool   `tfsdk:"valid"`
+	Errors   types.List   `tfsdk:"errors"`
+}
+
+func (d *ValidateDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_specification_validate"
+}
+
+func (d *ValidateDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Validates an OpenAPI schema and endpoint for a Tama Sensory Specification without creating it. The checks run locally and do not contact the Tama API.",
+
+		Attributes: map[string]schema.Attribute{
+			"schema": schema.StringAttribute{
+				MarkdownDescription: "OpenAPI 3.0 schema definition to validate",
+				Required:            true,
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "API endpoint URL the specification would be created with",
+				Required:            true,
+			},
+			"valid": schema.BoolAttribute{
+				MarkdownDescription: "Whether the schema and endpoint passed every check",
+				Computed:            true,
+			},
+			"errors": schema.ListAttribute{
+				MarkdownDescription: "Description of each problem found, prefixed with the location of the problem. Empty when `valid` is true.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ValidateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ValidateDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	problems := schemaErrors(data.Schema.ValueString(), data.Endpoint.ValueString())
+
+	tflog.Debug(ctx, "Validated specification schema", map[string]any{
+		"endpoint": data.Endpoint.ValueString(),
+		"errors":   len(problems),
+	})
+
+	// A valid schema reports an empty list rather than null
+	if problems == nil {
+		problems = []string{}
+	}
+
+	errors, diags := types.ListValueFrom(ctx, types.StringType, problems)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Valid = types.BoolValue(len(problems) == 0)
+	data.Errors = errors
+
+	// Write logs using the tflog package
+	tflog.Trace(ctx, "read a specification validate data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package specification_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/upmaru/terraform-provider-tama/internal/acceptance"
+	"github.com/upmaru/terraform-provider-tama/tama/sensory/specification/testhelpers"
+)
+
+func TestAccSpecificationValidateDataSource_Valid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpecificationValidateDataSourceConfig("https://api.example.com", testhelpers.MustMarshalJSON(testhelpers.TestSchema())),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tama_specification_validate.test", "valid", "true"),
+					resource.TestCheckResourceAttr("data.tama_specification_validate.test", "errors.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSpecificationValidateDataSource_Invalid(t *testing.T) {
+	schema := `{"openapi":"2.0","info":{"title":"Test API","version":"1.0.0"},"paths":{"/messages":{"post":{"operationId":"createMessage"}}}}`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acceptance.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acceptance.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSpecificationValidateDataSourceConfig("https://api.example.com", schema),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tama_specification_validate.test", "valid", "false"),
+					resource.TestCheckResourceAttr("data.tama_specification_validate.test", "errors.#", "2"),
+					resource.TestCheckResourceAttr("data.tama_specification_validate.test", "errors.0", `openapi: unsupported version "2.0", expected 3.x`),
+					resource.TestCheckResourceAttr("data.tama_specification_validate.test", "errors.1", "paths./messages.post.responses: must declare at least one response"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSpecificationValidateDataSourceConfig(endpoint, schema string) string {
+	return acceptance.ProviderConfig + fmt.Sprintf(`
+data "tama_specification_validate" "test" {
+  endpoint = %[1]q
+  schema   = %[2]q
+}
+`, endpoint, schema)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package specification
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/upmaru/terraform-provider-tama/tama/sensory/specification/testhelpers"
+)
+
+func TestSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		endpoint string
+		want     []string
+	}{
+		{
+			name:     "valid schema",
+			schema:   testhelpers.MustMarshalJSON(testhelpers.TestSchema()),
+			endpoint: "https://api.example.com",
+		},
+		{
+			name:     "templated endpoint",
+			schema:   testhelpers.MustMarshalJSON(testhelpers.TestComplexSchema()),
+			endpoint: "https://${TAMA_REGION}.api.example.com",
+		},
+		{
+			name:     "invalid endpoint",
+			schema:   testhelpers.MustMarshalJSON(testhelpers.TestSchema()),
+			endpoint: "api.example.com",
+			want:     []string{`endpoint: "api.example.com" must use the http or https scheme`},
+		},
+		{
+			name:     "invalid JSON",
+			schema:   `{"openapi":`,
+			endpoint: "https://api.example.com",
+			want:     []string{"schema: not valid JSON: unexpected end of JSON input"},
+		},
+		{
+			name:     "not an object",
+			schema:   `["openapi"]`,
+			endpoint: "https://api.example.com",
+			want:     []string{"schema: must be a JSON object"},
+		},
+		{
+			name:     "missing top level fields",
+			schema:   `{}`,
+			endpoint: "https://api.example.com",
+			want: []string{
+				"openapi: must be set to an OpenAPI 3.x version string",
+				"info: must be an object",
+				"paths: must be an object",
+			},
+		},
+		{
+			name:     "swagger version",
+			schema:   `{"openapi":"2.0","info":{"title":"Test API","version":"1.0.0"},"paths":{}}`,
+			endpoint: "https://api.example.com",
+			want:     []string{`openapi: unsupported version "2.0", expected 3.x`},
+		},
+		{
+			name:     "incomplete info",
+			schema:   `{"openapi":"3.0.3","info":{"title":""},"paths":{}}`,
+			endpoint: "https://api.example.com",
+			want: []string{
+				"info.title: must be a non-empty string",
+				"info.version: must be a non-empty string",
+			},
+		},
+		{
+			name: "invalid paths",
+			schema: `{"openapi":"3.0.3","info":{"title":"Test API","version":"1.0.0"},"paths":{
+				"messages":{"get":{"operationId":"listMessages","responses":{"200":{"description":"OK"}}}},
+				"/health":"up",
+				"/users":{
+					"get":{"operationId":"listMessages","responses":{"200":{"description":"OK"}}},
+					"post":{"operationId":"createUser"},
+					"put":true
+				}
+			}}`,
+			endpoint: "https://api.example.com",
+			want: []string{
+				"paths./health: must be an object",
+				"paths./users.put: must be an object",
+				"paths./users.post.responses: must declare at least one response",
+				"paths.messages: path must begin with /",
+				`paths.messages.get.operationId: "listMessages" is already used by paths./users.get`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := schemaErrors(tt.schema, tt.endpoint)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
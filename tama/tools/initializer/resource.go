@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
@@ -17,12 +18,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	tama "github.com/upmaru/tama-go"
 	"github.com/upmaru/tama-go/tools"
+	"github.com/upmaru/terraform-provider-tama/internal/client"
+	"github.com/upmaru/terraform-provider-tama/internal/meta"
 	internalplanmodifier "github.com/upmaru/terraform-provider-tama/internal/planmodifier"
+	"github.com/upmaru/terraform-provider-tama/internal/provision"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
+var _ resource.ResourceWithModifyPlan = &Resource{}
 
 func NewResource() resource.Resource {
 	return &Resource{}
@@ -30,7 +35,8 @@ func NewResource() resource.Resource {
 
 // Resource defines the resource implementation.
 type Resource struct {
-	client *tama.Client
+	client               *tama.Client
+	disableNormalization bool
 }
 
 // ResourceModel describes the resource data model.
@@ -99,18 +105,26 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		return
 	}
 
-	client, ok := req.ProviderData.(*tama.Client)
+	providerMeta, ok := req.ProviderData.(*meta.ProviderMeta)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *tama.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *meta.ProviderMeta, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerMeta.Client
+	r.disableNormalization = providerMeta.DisableJSONNormalization
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Plan formatting changes when the provider disables normalization
+	if r.disableNormalization {
+		resp.Diagnostics.Append(internalplanmodifier.KeepConfiguredJSON(ctx, req.Config, &resp.Plan, path.MatchRoot("parameters"))...)
+	}
 }
 
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -157,7 +171,7 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	// Create initializer
 	initializerResponse, err := r.client.Tools.CreateInitializer(data.ThoughtToolId.ValueString(), createReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create tool initializer, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to create tool initializer, got error: %s", err)))
 		return
 	}
 
@@ -198,7 +212,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 
 	initializerResponse, err := r.client.Tools.GetInitializer(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read tool initializer, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read tool initializer, got error: %s", err)))
 		return
 	}
 
@@ -220,6 +234,11 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 }
 
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(provision.CheckUpdatable(ctx, req.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ResourceModel
 
 	// Read Terraform plan data into the model
@@ -263,7 +282,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	// Update initializer
 	initializerResponse, err := r.client.Tools.UpdateInitializer(data.Id.ValueString(), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update tool initializer, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to update tool initializer, got error: %s", err)))
 		return
 	}
 
@@ -301,7 +320,7 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 
 	err := r.client.Tools.DeleteInitializer(data.Id.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete tool initializer, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to delete tool initializer, got error: %s", err)))
 		return
 	}
 }
@@ -314,7 +333,7 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 
 	initializerResponse, err := r.client.Tools.GetInitializer(req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read tool initializer for import, got error: %s", err))
+		resp.Diagnostics.Append(client.ErrorDiagnostic(err, fmt.Sprintf("Unable to read tool initializer for import, got error: %s", err)))
 		return
 	}
 
@@ -338,7 +357,8 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 
 // updateParametersFromResponse updates the parameters field in the resource model from the API response.
 func (r *Resource) updateParametersFromResponse(responseParameters map[string]any, data *ResourceModel) error {
-	// Handle parameters - always use the server response as the source of truth
+	// Handle parameters - the server response is the source of truth, the
+	// configured string is only kept while it is equivalent
 	if responseParameters != nil {
 		// Use server response as-is since it includes defaults and server-side processing
 		parametersJSON, err := json.Marshal(responseParameters)
@@ -351,7 +371,7 @@ func (r *Resource) updateParametersFromResponse(responseParameters map[string]an
 		if err != nil {
 			return fmt.Errorf("unable to normalize parameters JSON: %s", err)
 		}
-		data.Parameters = types.StringValue(normalizedJSON)
+		data.Parameters = internalplanmodifier.KeepEquivalent(data.Parameters, normalizedJSON, internalplanmodifier.NormalizeJSON)
 	} else {
 		data.Parameters = types.StringNull()
 	}